@@ -0,0 +1,33 @@
+package api
+
+import (
+	"log/slog"
+	"net"
+
+	"github.com/labstack/echo/v4"
+)
+
+// clientIPExtractor はc.RealIP()でクライアントのIPアドレスを取得する方法を返します
+// Echoの既定ではX-Forwarded-For・X-Real-IPをどのクライアントからでも信頼するため、ヘッダーを書き換えるだけで
+// ゲストの閲覧者を区別するIPアドレスを偽装できてしまいます。そのため、trustedProxiesが未設定の場合は接続元のアドレスを使い、
+// 設定されている場合はその範囲のプロキシが付けたX-Forwarded-Forのみ信頼します
+func clientIPExtractor(trustedProxies []string) echo.IPExtractor {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, cidr := range trustedProxies {
+		_, ipRange, err := net.ParseCIDR(cidr)
+		if err != nil {
+			slog.Warn("信頼するプロキシのアドレス範囲が不正なため無視します", "cidr", cidr, "error", err)
+			continue
+		}
+		options = append(options, echo.TrustIPRange(ipRange))
+	}
+	return echo.ExtractIPFromXFFHeader(options...)
+}
@@ -0,0 +1,36 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientIPExtractor(t *testing.T) {
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		remoteAddr     string
+		forwardedFor   string
+		want           string
+	}{
+		{"プロキシ未設定ではX-Forwarded-Forを無視", nil, "203.0.113.10:1234", "198.51.100.1", "203.0.113.10"},
+		{"プロキシ未設定ではプライベートIPからでも無視", nil, "10.0.0.5:1234", "198.51.100.1", "10.0.0.5"},
+		{"信頼するプロキシからのX-Forwarded-Forを使う", []string{"10.0.0.0/8"}, "10.0.0.5:1234", "198.51.100.1", "198.51.100.1"},
+		{"信頼しないクライアントのX-Forwarded-Forは無視", []string{"10.0.0.0/8"}, "203.0.113.10:1234", "198.51.100.1", "203.0.113.10"},
+		{"クライアントが先頭に付けた偽のアドレスは無視", []string{"10.0.0.0/8"}, "10.0.0.5:1234", "192.0.2.99, 198.51.100.1", "198.51.100.1"},
+		{"不正なCIDRは無視", []string{"not-a-cidr"}, "10.0.0.5:1234", "198.51.100.1", "10.0.0.5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			req.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			req.Header.Set("X-Real-IP", tt.forwardedFor)
+
+			if got := clientIPExtractor(tt.trustedProxies)(req); got != tt.want {
+				t.Errorf("IP = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
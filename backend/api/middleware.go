@@ -1,16 +1,20 @@
 package api
 
 import (
+	"log/slog"
+	"net/http"
+	"slices"
 	"strings"
 
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/labstack/echo/v4"
 	"github.com/yamada-mikiya/team1-hackathon/models"
+	"github.com/yamada-mikiya/team1-hackathon/repositories"
+	"github.com/yamada-mikiya/team1-hackathon/services"
 )
 
 // OptionalAuthMiddleware はJWTトークンがあれば検証してユーザー情報をセットし、
-// なければゲスト扱いで通すミドルウェア
-func OptionalAuthMiddleware(secretKey string) echo.MiddlewareFunc {
+// なければゲスト扱いで通すミドルウェア。ログアウト等で失効したトークンもゲスト扱いにします
+func OptionalAuthMiddleware(secretKey string, revokedTokens repositories.RevokedTokenRepository) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			// JWTトークンの取得を試みる
@@ -18,16 +22,12 @@ func OptionalAuthMiddleware(secretKey string) echo.MiddlewareFunc {
 
 			if tokenString != "" {
 				// トークンがある場合は検証を試みる
-				token, err := jwt.ParseWithClaims(tokenString, &models.JwtCustomClaims{}, func(token *jwt.Token) (interface{}, error) {
-					return []byte(secretKey), nil
-				})
-
-				if err == nil && token.Valid {
+				// （プレビュー用・メールアドレス確認用など、ログイン用でないトークンはログインとして扱わない）
+				claims, err := services.ParseAccessToken(secretKey, tokenString)
+				if err == nil && !isRevoked(c, revokedTokens, claims.ID) {
 					// トークンが有効な場合、ユーザー情報をContextにセット
-					if claims, ok := token.Claims.(*models.JwtCustomClaims); ok {
-						c.Set("user", claims)
-						c.Set("user_id", claims.UserID)
-					}
+					c.Set("user", claims)
+					c.Set("user_id", claims.UserID)
 				}
 				// エラーがあっても続行（ゲスト扱い）
 			}
@@ -38,6 +38,40 @@ func OptionalAuthMiddleware(secretKey string) echo.MiddlewareFunc {
 	}
 }
 
+// isRevoked はトークンが失効リストに含まれているかを返します
+// 確認に失敗した場合は安全側に倒して失効扱いにします
+func isRevoked(c echo.Context, revokedTokens repositories.RevokedTokenRepository, jti string) bool {
+	revoked, err := revokedTokens.IsRevoked(c.Request().Context(), jti)
+	if err != nil {
+		slog.Error("トークンの失効確認に失敗しました", "error", err)
+		return true
+	}
+	return revoked
+}
+
+// RequireRole はログインユーザーのロールが指定したいずれかであることを要求するミドルウェア
+// OptionalAuthMiddlewareの後に適用してください。未ログインなら401、ロールが足りなければ403を返します
+func RequireRole(roles ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			claims, ok := c.Get("user").(*models.JwtCustomClaims)
+			if !ok {
+				return c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+					Error: "認証されていません",
+				})
+			}
+
+			if !slices.Contains(roles, claims.Role) {
+				return c.JSON(http.StatusForbidden, models.ErrorResponse{
+					Error: "この操作を行う権限がありません",
+				})
+			}
+
+			return next(c)
+		}
+	}
+}
+
 // extractToken はリクエストからJWTトークンを抽出する
 func extractToken(c echo.Context) string {
 	// 1. Cookieから取得を試みる
@@ -8,11 +8,20 @@ import (
 	echoSwagger "github.com/swaggo/echo-swagger"
 	"github.com/yamada-mikiya/team1-hackathon/config"
 	"github.com/yamada-mikiya/team1-hackathon/controller"
+	"github.com/yamada-mikiya/team1-hackathon/linkpreview"
+	"github.com/yamada-mikiya/team1-hackathon/mailer"
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"github.com/yamada-mikiya/team1-hackathon/repositories"
+	"github.com/yamada-mikiya/team1-hackathon/services"
+	"github.com/yamada-mikiya/team1-hackathon/storage"
 	"gorm.io/gorm"
 )
 
-func SetupRouter(cfg *config.Config, db *gorm.DB) *echo.Echo {
+// viewsには記事の閲覧を数えるworkers.ViewCounterを指定します
+func SetupRouter(cfg *config.Config, db *gorm.DB, store storage.BlobStore, m mailer.Mailer, views services.ViewRecorder) *echo.Echo {
 	router := echo.New()
+	// 閲覧数の重複判定などに使うクライアントのIPアドレス（信頼するプロキシ以外のX-Forwarded-Forは使わない）
+	router.IPExtractor = clientIPExtractor(cfg.Server.TrustedProxies)
 
 	corsConfig := middleware.CORSConfig{
 		AllowOrigins:     cfg.CORS.AllowedOrigins,
@@ -34,8 +43,35 @@ func SetupRouter(cfg *config.Config, db *gorm.DB) *echo.Echo {
 	router.GET("/swagger/*", echoSwagger.WrapHandler)
 
 	// コントローラー初期化
-	articleController := controller.NewArticleController(db)
-	authController := controller.NewAuthController(cfg, db)
+	// 外部記事のリンクプレビュー（OGP）の取得
+	linkPreview := linkpreview.NewClient(cfg.LinkPreview)
+
+	articleController := controller.NewArticleController(cfg, db, linkPreview, views)
+	authController := controller.NewAuthController(cfg, db, m)
+	tagController := controller.NewTagController(db)
+	revisionController := controller.NewRevisionController(db, linkPreview)
+	reviewController := controller.NewReviewController(db)
+	reactionController := controller.NewReactionController(db)
+	commentController := controller.NewCommentController(db)
+	userController := controller.NewUserController(db, store)
+	mediaController := controller.NewMediaController(db, store)
+	feedController := controller.NewFeedController(cfg, db)
+	sitemapController := controller.NewSitemapController(cfg, db)
+
+	// 認証ミドルウェア（トークンがあれば認証、なければゲスト扱い。失効済みのトークンはゲスト扱い）
+	optionalAuth := OptionalAuthMiddleware(cfg.SecretKey, repositories.NewRevokedTokenRepository(db))
+
+	// アップロードされたファイルの配信（保存先に関わらずこのサーバーから配信する）
+	router.Match([]string{http.MethodGet, http.MethodHead}, storage.PublicPathPrefix+"/*", mediaController.ServeMedia)
+
+	// RSS・Atomフィード（公開記事のみ。?department=, ?tag=, ?author_id= で絞り込み）
+	router.GET("/feed.xml", feedController.GetRSSFeed)
+	router.GET("/atom.xml", feedController.GetAtomFeed)
+
+	// サイトマップ・robots.txt（公開記事のみ）
+	router.GET("/sitemap.xml", sitemapController.GetSitemap)
+	router.GET("/sitemaps/:file", sitemapController.GetSitemapPage)
+	router.GET("/robots.txt", sitemapController.GetRobots)
 
 	// APIルート
 	api := router.Group("/api")
@@ -45,15 +81,69 @@ func SetupRouter(cfg *config.Config, db *gorm.DB) *echo.Echo {
 		{
 			auth.POST("/signup", authController.SignUpHandler)
 			auth.POST("/login", authController.LogInHandler)
+			auth.POST("/refresh", authController.RefreshHandler)
+			auth.POST("/logout", authController.LogOutHandler, optionalAuth)
+			auth.POST("/password/forgot", authController.ForgotPasswordHandler)
+			auth.POST("/password/reset", authController.ResetPasswordHandler)
+			auth.POST("/email/verify", authController.VerifyEmailHandler)
+			auth.POST("/email/resend", authController.ResendVerificationEmailHandler, optionalAuth)
 			// 認証必須エンドポイント
-			auth.GET("/me", authController.GetMeHandler, OptionalAuthMiddleware(cfg.SecretKey))
+			auth.GET("/me", authController.GetMeHandler, optionalAuth)
 		}
 
 		// 記事関連（Optional Auth - トークンがあれば認証、なければゲスト扱い）
-		articles := api.Group("/articles", OptionalAuthMiddleware(cfg.SecretKey))
+		articles := api.Group("/articles", optionalAuth)
 		{
 			articles.GET("", articleController.GetArticles)
+			articles.GET("/popular", articleController.GetPopularArticles)
+			articles.GET("/trending", articleController.GetTrendingArticles)
 			articles.GET("/:slug", articleController.GetArticleBySlug)
+			articles.POST("", articleController.CreateArticle, RequireRole(models.RoleAdmin, models.RoleEditor, models.RoleWriter))
+			articles.PUT("/:slug", articleController.UpdateArticle)
+			articles.PATCH("/:slug", articleController.PatchArticle)
+			articles.DELETE("/:slug", articleController.DeleteArticle)
+			articles.POST("/:slug/preview-tokens", articleController.CreatePreviewToken)
+			articles.GET("/:slug/preview-tokens", articleController.GetPreviewTokens)
+			articles.DELETE("/:slug/preview-tokens/:id", articleController.RevokePreviewToken)
+			articles.GET("/:slug/revisions", revisionController.GetRevisions)
+			articles.GET("/:slug/revisions/diff", revisionController.DiffRevisions)
+			articles.GET("/:slug/revisions/:number", revisionController.GetRevision)
+			articles.POST("/:slug/revisions/:number/restore", revisionController.RestoreRevision)
+			articles.POST("/:slug/reviews", reviewController.SubmitForReview)
+			articles.GET("/:slug/reviews", reviewController.GetReviewStatus)
+			articles.POST("/:slug/reviews/approve", reviewController.Approve)
+			articles.POST("/:slug/reviews/request-changes", reviewController.RequestChanges)
+			articles.POST("/:slug/reviews/comments", reviewController.AddComment)
+			articles.PUT("/:slug/reactions/:emoji", reactionController.AddReaction)
+			articles.DELETE("/:slug/reactions/:emoji", reactionController.RemoveReaction)
+			articles.GET("/:slug/comments", commentController.GetComments)
+			articles.POST("/:slug/comments", commentController.CreateComment)
+			articles.PATCH("/:slug/comments/:id", commentController.UpdateComment)
+			articles.DELETE("/:slug/comments/:id", commentController.DeleteComment)
+			articles.POST("/:slug/comments/:id/hide", commentController.HideComment)
+			articles.POST("/:slug/comments/:id/unhide", commentController.UnhideComment)
+		}
+
+		// タグ・カテゴリ関連（Optional Auth - 記事数は閲覧権限に応じて変わる）
+		tags := api.Group("/tags", optionalAuth)
+		{
+			tags.GET("", tagController.GetTags)
+			tags.GET("/:name/articles", tagController.GetArticlesByTag)
+		}
+		api.GET("/categories", tagController.GetCategories, optionalAuth)
+
+		// メディア（記事に使う画像のアップロード）
+		api.POST("/media", mediaController.UploadMedia, optionalAuth, middleware.BodyLimit("11M"), RequireRole(models.RoleAdmin, models.RoleEditor, models.RoleWriter))
+
+		// ユーザー関連（/meはログイン中のユーザー自身、一覧とロール変更は管理者のみ）
+		users := api.Group("/users", optionalAuth)
+		{
+			users.GET("/:id", userController.GetUserProfile)
+			users.PATCH("/me", userController.UpdateMe)
+			users.PUT("/me/password", authController.ChangePasswordHandler)
+			users.POST("/me/icon", userController.UploadMyIcon, middleware.BodyLimit("6M"))
+			users.GET("", userController.GetUsers, RequireRole(models.RoleAdmin))
+			users.PATCH("/:id/role", userController.UpdateUserRole, RequireRole(models.RoleAdmin))
 		}
 	}
 
@@ -5,15 +5,22 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/yamada-mikiya/team1-hackathon/api"
 	"github.com/yamada-mikiya/team1-hackathon/config"
 	"github.com/yamada-mikiya/team1-hackathon/database"
-	_ "github.com/yamada-mikiya/team1-hackathon/docs"
+	"github.com/yamada-mikiya/team1-hackathon/docs"
+	"github.com/yamada-mikiya/team1-hackathon/mailer"
+	"github.com/yamada-mikiya/team1-hackathon/repositories"
+	"github.com/yamada-mikiya/team1-hackathon/services"
+	"github.com/yamada-mikiya/team1-hackathon/storage"
+	"github.com/yamada-mikiya/team1-hackathon/workers"
 )
 
 // @title        Team1 Blog API
@@ -21,7 +28,6 @@ import (
 // @description  チーム1のテックブログシステムのためのAPI仕様書です。
 // @description  記事の取得、閲覧などの機能を提供します。
 
-// @host         localhost:8080
 // @BasePath     /
 
 // @securityDefinitions.apikey Bearer
@@ -41,6 +47,12 @@ func realMain() int {
 		return 1
 	}
 
+	// Swaggerのホストは設定の公開URLに合わせる
+	if u, err := url.Parse(cfg.Server.BaseURL()); err == nil && u.Host != "" {
+		docs.SwaggerInfo.Host = u.Host
+		docs.SwaggerInfo.Schemes = []string{u.Scheme}
+	}
+
 	// マイグレーション実行
 	if err := database.RunMigrations(cfg.Database.GetDSN()); err != nil {
 		slog.Error("マイグレーションに失敗しました", "error", err)
@@ -62,7 +74,60 @@ func realMain() int {
 		}
 	}
 
-	router := api.SetupRouter(cfg, db)
+	// 許可するドメイン以外のメールアドレスのユーザーはメンバーとして扱わない
+	// （確認の仕組みを導入する前のユーザーはマイグレーションで確認済みになっているため、起動時に未確認に戻す）
+	revoked, err := services.RevokeEmailVerificationOutsideDomains(context.Background(), repositories.NewUserRepository(db), cfg.Auth.AllowedEmailDomains)
+	if err != nil {
+		slog.Error("許可するドメイン以外のユーザーのメールアドレスの確認の取り消しに失敗しました", "error", err)
+		return 1
+	}
+	if revoked > 0 {
+		slog.Info("許可するドメイン以外のユーザーのメールアドレスを未確認に戻しました", "count", revoked)
+	}
+
+	// アップロードされたファイルの保存先
+	store, err := storage.New(cfg.Storage)
+	if err != nil {
+		slog.Error("ファイルの保存先の初期化に失敗しました", "error", err)
+		return 1
+	}
+
+	m, err := mailer.New(cfg.Mail)
+	if err != nil {
+		slog.Error("メール送信の初期化に失敗しました", "error", err)
+		return 1
+	}
+	// メールはキューに積み、ワーカーがバックグラウンドで送信する（APIは送信の完了を待たない）
+	mailQueue := workers.NewMailQueue(m)
+
+	// 記事の閲覧数（メモリ上で数えて、ワーカーがまとめてDBに書き込む）
+	viewCounter := workers.NewViewCounter(db, cfg.Views.DedupWindow, cfg.Views.FlushInterval)
+
+	router := api.SetupRouter(cfg, db, store, mailQueue, viewCounter)
+
+	// バックグラウンドワーカー起動（シャットダウン時にキャンセルして終了を待つ）
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	var workersWG sync.WaitGroup
+	workersWG.Go(func() {
+		viewCounter.Run(workerCtx)
+	})
+	workersWG.Go(func() {
+		mailQueue.Run(workerCtx)
+	})
+	publisher := workers.NewScheduledPublisher(db, cfg.Publisher.Interval)
+	workersWG.Go(func() {
+		publisher.Run(workerCtx)
+	})
+	pruner := workers.NewTokenPruner(db, cfg.Auth.RevocationPruneInterval)
+	workersWG.Go(func() {
+		pruner.Run(workerCtx)
+	})
+	// キャッシュを導入する前に保存された記事の本文のHTMLを作成する（起動時に一度だけ）
+	renderBackfill := workers.NewRenderBackfill(db)
+	workersWG.Go(func() {
+		renderBackfill.Run(workerCtx)
+	})
 
 	// サーバー設定
 	srv := &http.Server{
@@ -105,6 +170,10 @@ func realMain() int {
 		return 1
 	}
 
+	// ワーカーの停止を待つ（閲覧数ワーカーは残りの閲覧数を書き込み、メールのワーカーは送信待ちのメールを送信してから終了する）
+	stopWorkers()
+	workersWG.Wait()
+
 	slog.Info("サーバーが正常に終了しました")
 	return 0
 }
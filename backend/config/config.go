@@ -3,7 +3,9 @@ package config
 import (
 	"log/slog"
 	"net/url"
+	"strings"
 	"sync"
+	"time"
 )
 
 const DefaultConfigPath = "config/config.yaml"
@@ -15,10 +17,18 @@ var (
 )
 
 type Config struct {
-	Database  DatabaseConfig `yaml:"database"`
-	Server    ServerConfig   `yaml:"server"`
-	CORS      CorsConfig     `yaml:"cors"`
-	SecretKey string         `yaml:"secretKey" env:"SECRET_KEY"`
+	Database    DatabaseConfig    `yaml:"database"`
+	Server      ServerConfig      `yaml:"server"`
+	CORS        CorsConfig        `yaml:"cors"`
+	Preview     PreviewConfig     `yaml:"preview"`
+	Publisher   PublisherConfig   `yaml:"publisher"`
+	Auth        AuthConfig        `yaml:"auth"`
+	Mail        MailConfig        `yaml:"mail"`
+	Storage     StorageConfig     `yaml:"storage"`
+	Site        SiteConfig        `yaml:"site"`
+	LinkPreview LinkPreviewConfig `yaml:"linkPreview"`
+	Views       ViewsConfig       `yaml:"views"`
+	SecretKey   string            `yaml:"secretKey" env:"SECRET_KEY"`
 }
 
 type DatabaseConfig struct {
@@ -34,6 +44,19 @@ type ServerConfig struct {
 	Port         string `yaml:"port" env:"SERVER_PORT"`
 	Environment  string `yaml:"environment" env:"ENVIRONMENT"`
 	CookieDomain string `yaml:"cookieDomain" env:"COOKIE_DOMAIN"`
+	// このAPIサーバーを外部から見たURL（例: "https://api.example.com"）。Swaggerのホストやsitemap.xmlのURLに使います。未設定の場合はhttp://localhost:<port>
+	PublicBaseURL string `yaml:"publicBaseURL" env:"SERVER_PUBLIC_BASE_URL"`
+	// X-Forwarded-Forを信頼するリバースプロキシ・ロードバランサーのアドレス範囲（CIDR。例: ["10.0.0.0/8"]）
+	// 未設定の場合はX-Forwarded-For・X-Real-IPを使わず、接続元のアドレスをクライアントのIPアドレスとします
+	TrustedProxies []string     `yaml:"trustedProxies" env:"SERVER_TRUSTED_PROXIES" envSeparator:","`
+	Robots         RobotsConfig `yaml:"robots"`
+}
+
+type RobotsConfig struct {
+	// クローラーに巡回させないパス。未設定の場合は/api/と/swagger/
+	Disallow []string `yaml:"disallow" env:"ROBOTS_DISALLOW" envSeparator:","`
+	// trueの場合はすべてのパスの巡回を禁止します（ステージング環境など）
+	DisallowAll bool `yaml:"disallowAll" env:"ROBOTS_DISALLOW_ALL"`
 }
 
 type CorsConfig struct {
@@ -43,6 +66,101 @@ type CorsConfig struct {
 	AllowCredentials bool     `yaml:"allowCredentials" env:"CORS_ALLOW_CREDENTIALS"`
 }
 
+type PreviewConfig struct {
+	// 下書きプレビューリンクの有効期間（例: "72h"）。未設定の場合は72時間
+	TokenTTL time.Duration `yaml:"tokenTTL" env:"PREVIEW_TOKEN_TTL"`
+}
+
+type PublisherConfig struct {
+	// 予約公開ワーカーが公開日時を過ぎた記事を確認する間隔（例: "1m"）。未設定の場合は1分
+	Interval time.Duration `yaml:"interval" env:"PUBLISHER_INTERVAL"`
+}
+
+type AuthConfig struct {
+	// アクセストークン（JWT）の有効期間（例: "15m"）。未設定の場合は15分
+	AccessTokenTTL time.Duration `yaml:"accessTokenTTL" env:"ACCESS_TOKEN_TTL"`
+	// リフレッシュトークンの有効期間（例: "336h"）。未設定の場合は14日
+	RefreshTokenTTL time.Duration `yaml:"refreshTokenTTL" env:"REFRESH_TOKEN_TTL"`
+	// 期限切れの失効済みトークン・リフレッシュトークン・パスワードリセットトークンを削除する間隔（例: "1h"）。未設定の場合は1時間
+	RevocationPruneInterval time.Duration `yaml:"revocationPruneInterval" env:"REVOCATION_PRUNE_INTERVAL"`
+	// パスワードリセットメールに載せるフロントエンドの再設定ページのURL。末尾に?token=...を付けて送ります
+	PasswordResetURL string `yaml:"passwordResetURL" env:"PASSWORD_RESET_URL"`
+	// パスワードリセットトークンの有効期間（例: "1h"）。未設定の場合は1時間
+	PasswordResetTTL time.Duration `yaml:"passwordResetTTL" env:"PASSWORD_RESET_TTL"`
+	// 確認メールに載せるフロントエンドのメールアドレス確認ページのURL。末尾に?token=...を付けて送ります
+	EmailVerificationURL string `yaml:"emailVerificationURL" env:"EMAIL_VERIFICATION_URL"`
+	// メールアドレス確認リンクの有効期間（例: "24h"）。未設定の場合は24時間
+	EmailVerificationTTL time.Duration `yaml:"emailVerificationTTL" env:"EMAIL_VERIFICATION_TTL"`
+	// メンバーになれるメールアドレスのドメイン（例: ["example.com"]）。未設定の場合は制限しない
+	// それ以外のドメインのユーザーも登録はできるがゲストのままで、起動時に確認済みのユーザーも未確認に戻す
+	AllowedEmailDomains []string `yaml:"allowedEmailDomains" env:"ALLOWED_EMAIL_DOMAINS" envSeparator:","`
+}
+
+type MailConfig struct {
+	// 送信方式（smtp, file, log）。必須で、未設定・不明な値の場合は起動に失敗する
+	Driver string `yaml:"driver" env:"MAIL_DRIVER"`
+	// 送信元メールアドレス
+	From string `yaml:"from" env:"MAIL_FROM"`
+	// driverがfileの場合にメールを保存するディレクトリ。未設定の場合はtmp/mails
+	FileDir string     `yaml:"fileDir" env:"MAIL_FILE_DIR"`
+	SMTP    SMTPConfig `yaml:"smtp"`
+}
+
+type SMTPConfig struct {
+	Host     string `yaml:"host" env:"SMTP_HOST"`
+	Port     string `yaml:"port" env:"SMTP_PORT"`
+	Username string `yaml:"username" env:"SMTP_USERNAME"`
+	Password string `yaml:"password" env:"SMTP_PASSWORD"`
+}
+
+type StorageConfig struct {
+	// アップロードしたファイルの保存先（local, s3）。未設定の場合はlocal
+	Driver string `yaml:"driver" env:"STORAGE_DRIVER"`
+	// driverがlocalの場合にファイルを保存するディレクトリ。未設定の場合はuploads
+	LocalDir string `yaml:"localDir" env:"STORAGE_LOCAL_DIR"`
+	// 保存したファイルのURLの先頭部分（例: "https://blog.example.com/media"）。保存先に関わらず/mediaから配信します。未設定の場合は/media
+	PublicBaseURL string   `yaml:"publicBaseURL" env:"STORAGE_PUBLIC_BASE_URL"`
+	S3            S3Config `yaml:"s3"`
+}
+
+type S3Config struct {
+	// S3互換ストレージのエンドポイント（例: "s3.ap-northeast-1.amazonaws.com", "minio:9000"）
+	Endpoint        string `yaml:"endpoint" env:"S3_ENDPOINT"`
+	Region          string `yaml:"region" env:"S3_REGION"`
+	Bucket          string `yaml:"bucket" env:"S3_BUCKET"`
+	AccessKeyID     string `yaml:"accessKeyID" env:"S3_ACCESS_KEY_ID"`
+	SecretAccessKey string `yaml:"secretAccessKey" env:"S3_SECRET_ACCESS_KEY"`
+	UseSSL          bool   `yaml:"useSSL" env:"S3_USE_SSL"`
+	// バケット名をパスに含める形式でアクセスするか（MinIOなど）
+	UsePathStyle bool `yaml:"usePathStyle" env:"S3_USE_PATH_STYLE"`
+}
+
+type SiteConfig struct {
+	// RSS・Atomフィードに載せるブログの名前と説明
+	Title       string `yaml:"title" env:"SITE_TITLE"`
+	Description string `yaml:"description" env:"SITE_DESCRIPTION"`
+	// フロントエンドのURL（例: "https://blog.example.com"）。記事のリンクは<url>/detail/<slug>になります。未設定の場合はhttp://localhost:3000
+	URL string `yaml:"url" env:"SITE_URL"`
+}
+
+type LinkPreviewConfig struct {
+	// 外部記事のページを取得する際のタイムアウト（例: "5s"）。未設定の場合は5秒
+	Timeout time.Duration `yaml:"timeout" env:"LINK_PREVIEW_TIMEOUT"`
+	// 読み込むHTMLの最大バイト数。未設定の場合は1MB
+	MaxBodySize int64 `yaml:"maxBodySize" env:"LINK_PREVIEW_MAX_BODY_SIZE"`
+	// リクエストに付けるUser-Agent。未設定の場合は "Team1BlogBot/1.0"
+	UserAgent string `yaml:"userAgent" env:"LINK_PREVIEW_USER_AGENT"`
+	// trueにするとプライベートIPアドレスやlocalhostへのアクセスを許可します（ローカルでの動作確認用。本番では有効にしないでください）
+	AllowPrivateNetworks bool `yaml:"allowPrivateNetworks" env:"LINK_PREVIEW_ALLOW_PRIVATE_NETWORKS"`
+}
+
+type ViewsConfig struct {
+	// 同じ閲覧者（ログインユーザー、またはゲストのIPアドレスとUser-Agent）の閲覧を1回と数える期間（例: "30m"）。未設定の場合は30分
+	DedupWindow time.Duration `yaml:"dedupWindow" env:"VIEWS_DEDUP_WINDOW"`
+	// メモリに溜めた閲覧数をDBに書き込む間隔（例: "10s"）。未設定の場合は10秒
+	FlushInterval time.Duration `yaml:"flushInterval" env:"VIEWS_FLUSH_INTERVAL"`
+}
+
 func (c DatabaseConfig) GetDSN() string {
 	var password string
 	if c.Password != "" {
@@ -51,6 +169,14 @@ func (c DatabaseConfig) GetDSN() string {
 	return "postgres://" + c.User + ":" + password + "@" + c.Host + ":" + c.Port + "/" + c.Name + "?sslmode=disable"
 }
 
+// BaseURL はこのAPIサーバーの公開URL（末尾の/なし）を返します
+func (c ServerConfig) BaseURL() string {
+	if c.PublicBaseURL != "" {
+		return strings.TrimSuffix(c.PublicBaseURL, "/")
+	}
+	return "http://localhost:" + c.Port
+}
+
 func GetConfig() (*Config, error) {
 	var loadErr error
 	once.Do(func() {
@@ -1,11 +1,12 @@
 package controller
 
 import (
-
+	"errors"
 	"net/http"
-	"strconv"
 
 	"github.com/labstack/echo/v4"
+	"github.com/yamada-mikiya/team1-hackathon/config"
+	"github.com/yamada-mikiya/team1-hackathon/linkpreview"
 	"github.com/yamada-mikiya/team1-hackathon/models"
 	"github.com/yamada-mikiya/team1-hackathon/repositories"
 	"github.com/yamada-mikiya/team1-hackathon/services"
@@ -13,103 +14,436 @@ import (
 )
 
 type ArticleController struct {
-	service services.ArticleService
+	service        services.ArticleService
+	previewService services.PreviewService
+	viewService    services.ViewService
 }
 
-func NewArticleController(db *gorm.DB) *ArticleController {
+func NewArticleController(cfg *config.Config, db *gorm.DB, linkPreview linkpreview.Fetcher, views services.ViewRecorder) *ArticleController {
 	repo := repositories.NewArticleRepository(db)
-	service := services.NewArticleService(repo)
-	return &ArticleController{service: service}
+	tagRepo := repositories.NewTagRepository(db)
+	reviewRepo := repositories.NewReviewRepository(db)
+	userRepo := repositories.NewUserRepository(db)
+	previewRepo := repositories.NewPreviewTokenRepository(db)
+	return &ArticleController{
+		service:        services.NewArticleService(repo, tagRepo, reviewRepo, userRepo, linkPreview),
+		previewService: services.NewPreviewService(repo, userRepo, previewRepo, cfg.SecretKey, cfg.Preview.TokenTTL),
+		viewService:    services.NewViewService(views),
+	}
 }
 
 // @Summary      記事一覧を取得
-// @Description  公開されているブログ記事の一覧を取得します。ログイン済みの場合は内部公開記事も含まれます。ページネーション、部署フィルタ、ステータスフィルタをサポートしています。
+// @Description  公開されているブログ記事の一覧を取得します。メールアドレスを確認済みのメンバーの場合は内部公開記事も含まれます。ページ番号（またはpagination=cursorを指定した場合はカーソル）によるページネーション、部署・種類・ステータス・著者・作成日時での絞り込み、並べ替え、キーワード検索をサポートしています。不明な値を指定した場合は400を返します。
 // @Tags         記事 (Articles)
 // @Accept       json
 // @Produce      json
-// @Param        page query int false "ページ番号 (デフォルト: 1)" default(1)
+// @Param        pagination query string false "ページネーションの方式 (デフォルト: page)。cursorの場合はnext_cursor・prev_cursorでページを移動します" Enums(page, cursor)
+// @Param        cursor query string false "前回のレスポンスのnext_cursorまたはprev_cursor。指定した場合はカーソル方式になります"
+// @Param        include_total query bool false "カーソル方式の場合に総件数(total_count)も返すか (デフォルト: false)"
+// @Param        page query int false "ページ番号 (ページ番号方式の場合。デフォルト: 1)。総件数も返します" default(1)
 // @Param        limit query int false "1ページあたりの件数 (デフォルト: 10, 最大: 100)" default(10)
-// @Param        department query string false "部署でフィルタ (Dev, MKT, Ops)" Enums(Dev, MKT, Ops)
+// @Param        department query string false "部署でフィルタ (Dev, MKT, Ops。カンマ区切りで複数指定可)" example("Dev,MKT")
+// @Param        article_type query string false "記事の種類でフィルタ (markdown, external。カンマ区切りで複数指定可)"
 // @Param        status query string false "ステータスでフィルタ (internal, public, all)。ゲストの場合は常にpublicのみ" Enums(internal, public, all)
+// @Param        tag query string false "タグ名でフィルタ"
+// @Param        author_id query int false "著者のユーザーIDでフィルタ"
+// @Param        created_after query string false "この日時以降に作成された記事 (RFC 3339または日付。日付のみの場合はUTCの0時)" example("2026-01-01T00:00:00+09:00")
+// @Param        created_before query string false "この日時より前に作成された記事 (RFC 3339または日付。日付のみの場合はUTCの0時)" example("2026-02-01")
+// @Param        sort query string false "並び順 (デフォルト: created_at。qを指定した場合は省略すると関連度順)" Enums(created_at, updated_at, title, popularity)
+// @Param        order query string false "並びの向き (デフォルト: titleはasc、それ以外はdesc。sortと一緒に指定)" Enums(asc, desc)
+// @Param        q query string false "キーワード検索 (タイトル・本文、空白区切りでAND検索)。指定時は関連度順になり、snippetに一致箇所を<mark>で囲んだ抜粋が入ります"
+// @Param        include query string false "追加で返す項目 (content_html: サニタイズ済みの本文HTML)" Enums(content_html)
 // @Success      200 {object} models.ArticleListResponse "記事一覧"
 // @Failure      400 {object} models.ErrorResponse "リクエストパラメータが不正です"
 // @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
 // @Router       /api/articles [get]
 func (ac *ArticleController) GetArticles(c echo.Context) error {
-	// ページネーションパラメータを取得
-	page, _ := strconv.Atoi(c.QueryParam("page"))
-	if page < 1 {
-		page = 1
-	}
-
-	limit, _ := strconv.Atoi(c.QueryParam("limit"))
-	if limit < 1 || limit > 100 {
-		limit = 10
-	}
-
-	// ユーザーがログイン済みかチェック
-	isAuthenticated := false
-	if userID := c.Get("user_id"); userID != nil {
-		isAuthenticated = true
-	}
-
-	// フィルタパラメータを取得
-	filters := repositories.ArticleFilters{
-		Department:      c.QueryParam("department"),
-		Status:          c.QueryParam("status"),
-		IsAuthenticated: isAuthenticated,
+	filters, err := articleFiltersFromQuery(c)
+	if err != nil {
+		return articleErrorResponse(c, err, "記事の取得に失敗しました")
 	}
 
 	// サービスから記事一覧を取得
-	response, err := ac.service.GetArticles(filters, page, limit)
+	response, err := listArticles(c, ac.service, filters)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "記事の取得に失敗しました",
-			Message: err.Error(),
-		})
+		return articleErrorResponse(c, err, "記事の取得に失敗しました")
 	}
+	applyArticleListIncludes(c, response)
 
 	return c.JSON(http.StatusOK, response)
 }
 
 // GetArticleBySlug はslugを指定して記事を取得します
 // @Summary      記事詳細を取得
-// @Description  指定されたslugのブログ記事の詳細を取得します。内部公開記事の場合はログインが必要です。
+// @Description  指定されたslugのブログ記事の詳細を取得します。内部公開記事の場合はログインとメールアドレスの確認が必要です。下書きは著者本人・レビュアー・記事を管理できるユーザー、またはpreview_tokenを指定した場合のみ閲覧できます。
 // @Tags         記事 (Articles)
 // @Accept       json
 // @Produce      json
 // @Param        slug path string true "記事のスラグ" example("go-api-development")
+// @Param        preview_token query string false "下書きプレビュー用トークン (POST /api/articles/{slug}/preview-tokens で発行)"
+// @Param        include query string false "追加で返す項目 (content_html: サニタイズ済みの本文HTML)" Enums(content_html)
 // @Success      200 {object} models.ArticleResponse "記事詳細"
-// @Failure      403 {object} models.ErrorResponse "内部公開記事にアクセスするにはログインが必要です"
+// @Failure      403 {object} models.ErrorResponse "内部公開記事にアクセスするにはログインとメールアドレスの確認が必要です / プレビューリンクが無効です"
 // @Failure      404 {object} models.ErrorResponse "記事が見つかりません"
 // @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
 // @Router       /api/articles/{slug} [get]
 func (ac *ArticleController) GetArticleBySlug(c echo.Context) error {
 	slug := c.Param("slug")
 
-	// ユーザーがログイン済みかチェック
-	isAuthenticated := false
-	if userID := c.Get("user_id"); userID != nil {
-		isAuthenticated = true
+	// プレビューリンクの場合はアカウントがなくても記事のステータスに関わらず閲覧できる
+	if previewToken := c.QueryParam("preview_token"); previewToken != "" {
+		articleID, err := ac.previewService.VerifyPreviewToken(previewToken)
+		if err != nil {
+			return articleErrorResponse(c, err, "記事の取得に失敗しました")
+		}
+
+		response, err := ac.service.GetArticlePreview(slug, articleID)
+		if err != nil {
+			return articleErrorResponse(c, err, "記事の取得に失敗しました")
+		}
+		applyArticleIncludes(c, response)
+		return c.JSON(http.StatusOK, response)
 	}
 
-	response, err := ac.service.GetArticleBySlug(slug, isAuthenticated)
+	// 閲覧者のユーザーID（ゲストの場合は0）
+	userID, _ := currentUserID(c)
+
+	response, err := ac.service.GetArticleBySlug(slug, userID)
 	if err != nil {
-			if err.Error() == "article not found" {
-				return c.JSON(http.StatusNotFound, models.ErrorResponse{
-				Error: "記事が見つかりません",
-			})
-		}
-		if err.Error() == "内部公開記事にアクセスするにはログインが必要です" {
-			return c.JSON(http.StatusForbidden, models.ErrorResponse{
-				Error: err.Error(),
-			})
+		return articleErrorResponse(c, err, "記事の取得に失敗しました")
+	}
+	// 閲覧数はメモリ上で数え、まとめてDBに書き込む（プレビューリンクでの閲覧は数えない）
+	ac.viewService.RecordView(response, userID, c.RealIP(), c.Request().UserAgent())
+	applyArticleIncludes(c, response)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// GetPopularArticles は累計の閲覧数が多い記事を取得します
+// @Summary      人気記事を取得
+// @Description  累計の閲覧数が多い順に記事を取得します（閲覧されていない記事は含みません）。閲覧ルールは記事一覧と同じで、ゲストの場合はpublic記事のみです。閲覧数は記事詳細の取得で数え、同じ閲覧者の閲覧は一定時間内であれば1回と数えます。
+// @Tags         記事 (Articles)
+// @Produce      json
+// @Param        limit query int false "取得する件数 (デフォルト: 10, 最大: 100)" default(10)
+// @Param        department query string false "部署でフィルタ (Dev, MKT, Ops。カンマ区切りで複数指定可)" example("Dev,MKT")
+// @Param        article_type query string false "記事の種類でフィルタ (markdown, external。カンマ区切りで複数指定可)"
+// @Param        status query string false "ステータスでフィルタ (internal, public, all)。ゲストの場合は常にpublicのみ" Enums(internal, public, all)
+// @Param        tag query string false "タグ名でフィルタ"
+// @Param        include query string false "追加で返す項目 (content_html: サニタイズ済みの本文HTML)" Enums(content_html)
+// @Success      200 {object} models.ArticleListResponse "人気記事（ページネーションなし）"
+// @Failure      400 {object} models.ErrorResponse "リクエストパラメータが不正です"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/articles/popular [get]
+func (ac *ArticleController) GetPopularArticles(c echo.Context) error {
+	_, limit := paginationParams(c)
+	response, err := ac.service.GetPopularArticles(rankingFiltersFromQuery(c), limit)
+	if err != nil {
+		return articleErrorResponse(c, err, "人気記事の取得に失敗しました")
+	}
+	applyArticleListIncludes(c, response)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// GetTrendingArticles は部署ごとに最近よく読まれている記事を取得します
+// @Summary      トレンド記事を取得
+// @Description  直近7日間の閲覧数から、新しい閲覧ほど重くなるよう（24時間ごとに重みが半分）計算したスコアの高い順に、部署ごとの記事を取得します。閲覧ルールは記事一覧と同じで、ゲストの場合はpublic記事のみです。
+// @Tags         記事 (Articles)
+// @Produce      json
+// @Param        limit query int false "部署ごとに取得する件数 (デフォルト: 10, 最大: 100)" default(10)
+// @Param        department query string false "部署 (Dev, MKT, Ops。カンマ区切りで複数指定可)。省略した場合はすべての部署" example("Dev,MKT")
+// @Param        article_type query string false "記事の種類でフィルタ (markdown, external。カンマ区切りで複数指定可)"
+// @Param        status query string false "ステータスでフィルタ (internal, public, all)。ゲストの場合は常にpublicのみ" Enums(internal, public, all)
+// @Param        tag query string false "タグ名でフィルタ"
+// @Param        include query string false "追加で返す項目 (content_html: サニタイズ済みの本文HTML)" Enums(content_html)
+// @Success      200 {object} models.TrendingArticlesResponse "部署ごとのトレンド記事"
+// @Failure      400 {object} models.ErrorResponse "リクエストパラメータが不正です"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/articles/trending [get]
+func (ac *ArticleController) GetTrendingArticles(c echo.Context) error {
+	_, limit := paginationParams(c)
+	response, err := ac.service.GetTrendingArticles(rankingFiltersFromQuery(c), limit)
+	if err != nil {
+		return articleErrorResponse(c, err, "トレンド記事の取得に失敗しました")
+	}
+	for i := range response.Departments {
+		for j := range response.Departments[i].Articles {
+			applyArticleIncludes(c, &response.Departments[i].Articles[j])
 		}
-		return c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-			Error:   "記事の取得に失敗しました",
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// CreateArticle は記事を作成します
+// @Summary      記事を作成
+// @Description  ログインユーザーを著者として記事を作成します。markdown記事はcontent、external記事はexternal_urlが必須です。statusを省略した場合はdraftになります。
+// @Tags         記事 (Articles)
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        payload body models.CreateArticleRequest true "記事の内容"
+// @Success      201 {object} models.ArticleResponse "作成された記事"
+// @Failure      400 {object} models.ErrorResponse "リクエストボディが不正です"
+// @Failure      401 {object} models.ErrorResponse "認証されていません"
+// @Failure      403 {object} models.ErrorResponse "記事を作成する権限がありません（readerロール・メールアドレス未確認）"
+// @Failure      409 {object} models.ErrorResponse "スラグが既に使用されています / 公開にはレビューでの承認が必要です"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/articles [post]
+func (ac *ArticleController) CreateArticle(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "認証されていません",
+		})
+	}
+
+	req := models.CreateArticleRequest{}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "リクエストの形式が不正です",
+			Message: err.Error(),
+		})
+	}
+
+	response, err := ac.service.CreateArticle(c.Request().Context(), userID, req)
+	if err != nil {
+		return articleErrorResponse(c, err, "記事の作成に失敗しました")
+	}
+	applyArticleIncludes(c, response)
+
+	return c.JSON(http.StatusCreated, response)
+}
+
+// UpdateArticle は記事全体を置き換えます
+// @Summary      記事を更新 (全体置き換え)
+// @Description  指定されたslugの記事をリクエストの内容で置き換えます。公開中の記事のタイトル・本文・リンク先・サムネイル・記事の種類・スラグ・タグ・部署は、公開を取り下げない限り変更できません（レビューでの承認が必要です）。記事の著者・記事の部署の編集者(editor)・管理者(admin)のみ実行できます。
+// @Tags         記事 (Articles)
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        slug path string true "記事のスラグ" example("go-api-development")
+// @Param        payload body models.CreateArticleRequest true "記事の内容"
+// @Success      200 {object} models.ArticleResponse "更新された記事"
+// @Failure      400 {object} models.ErrorResponse "リクエストボディが不正です"
+// @Failure      401 {object} models.ErrorResponse "認証されていません"
+// @Failure      403 {object} models.ErrorResponse "この記事を変更する権限がありません"
+// @Failure      404 {object} models.ErrorResponse "記事が見つかりません"
+// @Failure      409 {object} models.ErrorResponse "スラグが既に使用されています / 公開にはレビューでの承認が必要です"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/articles/{slug} [put]
+func (ac *ArticleController) UpdateArticle(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "認証されていません",
+		})
+	}
+
+	req := models.CreateArticleRequest{}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "リクエストの形式が不正です",
+			Message: err.Error(),
+		})
+	}
+
+	response, err := ac.service.UpdateArticle(c.Request().Context(), c.Param("slug"), userID, req)
+	if err != nil {
+		return articleErrorResponse(c, err, "記事の更新に失敗しました")
+	}
+	applyArticleIncludes(c, response)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// PatchArticle は記事を部分更新します
+// @Summary      記事を更新 (部分更新)
+// @Description  指定されたslugの記事のうち、リクエストに含まれる項目のみ更新します。公開中の記事のタイトル・本文・リンク先・サムネイル・記事の種類・スラグ・タグ・部署は、公開を取り下げない限り変更できません（レビューでの承認が必要です）。記事の著者・記事の部署の編集者(editor)・管理者(admin)のみ実行できます。
+// @Tags         記事 (Articles)
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        slug path string true "記事のスラグ" example("go-api-development")
+// @Param        payload body models.PatchArticleRequest true "更新する項目"
+// @Success      200 {object} models.ArticleResponse "更新された記事"
+// @Failure      400 {object} models.ErrorResponse "リクエストボディが不正です"
+// @Failure      401 {object} models.ErrorResponse "認証されていません"
+// @Failure      403 {object} models.ErrorResponse "この記事を変更する権限がありません"
+// @Failure      404 {object} models.ErrorResponse "記事が見つかりません"
+// @Failure      409 {object} models.ErrorResponse "スラグが既に使用されています / 公開にはレビューでの承認が必要です"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/articles/{slug} [patch]
+func (ac *ArticleController) PatchArticle(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "認証されていません",
+		})
+	}
+
+	req := models.PatchArticleRequest{}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "リクエストの形式が不正です",
 			Message: err.Error(),
 		})
 	}
 
+	response, err := ac.service.PatchArticle(c.Request().Context(), c.Param("slug"), userID, req)
+	if err != nil {
+		return articleErrorResponse(c, err, "記事の更新に失敗しました")
+	}
+	applyArticleIncludes(c, response)
+
 	return c.JSON(http.StatusOK, response)
-}
\ No newline at end of file
+}
+
+// DeleteArticle は記事を削除します
+// @Summary      記事を削除
+// @Description  指定されたslugの記事を削除します。記事の著者・記事の部署の編集者(editor)・管理者(admin)のみ実行できます。
+// @Tags         記事 (Articles)
+// @Produce      json
+// @Security     Bearer
+// @Param        slug path string true "記事のスラグ" example("go-api-development")
+// @Success      204 "削除成功"
+// @Failure      401 {object} models.ErrorResponse "認証されていません"
+// @Failure      403 {object} models.ErrorResponse "この記事を変更する権限がありません"
+// @Failure      404 {object} models.ErrorResponse "記事が見つかりません"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/articles/{slug} [delete]
+func (ac *ArticleController) DeleteArticle(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "認証されていません",
+		})
+	}
+
+	if err := ac.service.DeleteArticle(c.Param("slug"), userID); err != nil {
+		return articleErrorResponse(c, err, "記事の削除に失敗しました")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// CreatePreviewToken は下書きプレビューリンクを発行します
+// @Summary      プレビューリンクを発行
+// @Description  記事をアカウントのないレビュアーにも見せられる署名付きプレビューリンクを発行します。リンクはこの記事にのみ有効で、一定時間で期限切れになります。記事の著者・記事の部署の編集者(editor)・管理者(admin)のみ実行できます。
+// @Tags         記事 (Articles)
+// @Produce      json
+// @Security     Bearer
+// @Param        slug path string true "記事のスラグ" example("go-api-development")
+// @Success      201 {object} models.PreviewTokenResponse "発行されたプレビューリンク"
+// @Failure      401 {object} models.ErrorResponse "認証されていません"
+// @Failure      403 {object} models.ErrorResponse "この記事を変更する権限がありません"
+// @Failure      404 {object} models.ErrorResponse "記事が見つかりません"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/articles/{slug}/preview-tokens [post]
+func (ac *ArticleController) CreatePreviewToken(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "認証されていません",
+		})
+	}
+
+	response, err := ac.previewService.CreatePreviewToken(c.Param("slug"), userID)
+	if err != nil {
+		return articleErrorResponse(c, err, "プレビューリンクの発行に失敗しました")
+	}
+
+	return c.JSON(http.StatusCreated, response)
+}
+
+// GetPreviewTokens は発行済みのプレビューリンク一覧を取得します
+// @Summary      プレビューリンク一覧を取得
+// @Description  記事に発行したプレビューリンクの一覧を取得します。トークン本体は含まれません。記事の著者・記事の部署の編集者(editor)・管理者(admin)のみ実行できます。
+// @Tags         記事 (Articles)
+// @Produce      json
+// @Security     Bearer
+// @Param        slug path string true "記事のスラグ" example("go-api-development")
+// @Success      200 {array} models.PreviewTokenResponse "プレビューリンク一覧"
+// @Failure      401 {object} models.ErrorResponse "認証されていません"
+// @Failure      403 {object} models.ErrorResponse "この記事を変更する権限がありません"
+// @Failure      404 {object} models.ErrorResponse "記事が見つかりません"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/articles/{slug}/preview-tokens [get]
+func (ac *ArticleController) GetPreviewTokens(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "認証されていません",
+		})
+	}
+
+	response, err := ac.previewService.GetPreviewTokens(c.Param("slug"), userID)
+	if err != nil {
+		return articleErrorResponse(c, err, "プレビューリンクの取得に失敗しました")
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// RevokePreviewToken はプレビューリンクを失効させます
+// @Summary      プレビューリンクを失効
+// @Description  発行済みのプレビューリンクを失効させ、以後そのリンクでは記事を閲覧できないようにします。記事の著者・記事の部署の編集者(editor)・管理者(admin)のみ実行できます。
+// @Tags         記事 (Articles)
+// @Produce      json
+// @Security     Bearer
+// @Param        slug path string true "記事のスラグ" example("go-api-development")
+// @Param        id path string true "プレビューリンクのID"
+// @Success      204 "失効成功"
+// @Failure      401 {object} models.ErrorResponse "認証されていません"
+// @Failure      403 {object} models.ErrorResponse "この記事を変更する権限がありません"
+// @Failure      404 {object} models.ErrorResponse "記事またはプレビューリンクが見つかりません"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/articles/{slug}/preview-tokens/{id} [delete]
+func (ac *ArticleController) RevokePreviewToken(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "認証されていません",
+		})
+	}
+
+	if err := ac.previewService.RevokePreviewToken(c.Param("slug"), userID, c.Param("id")); err != nil {
+		return articleErrorResponse(c, err, "プレビューリンクの失効に失敗しました")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// articleErrorResponse はサービス層のエラーをHTTPレスポンスに変換します
+func articleErrorResponse(c echo.Context, err error, message string) error {
+	var validationErr *services.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error: validationErr.Message,
+		})
+	case errors.Is(err, services.ErrArticleNotFound):
+		return c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error: "記事が見つかりません",
+		})
+	case errors.Is(err, services.ErrPreviewNotFound), errors.Is(err, services.ErrRevisionNotFound), errors.Is(err, services.ErrCommentNotFound):
+		return c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error: err.Error(),
+		})
+	case errors.Is(err, services.ErrLoginRequired), errors.Is(err, services.ErrNotArticleAuthor), errors.Is(err, services.ErrInvalidPreview),
+		errors.Is(err, services.ErrNotReviewer), errors.Is(err, services.ErrPermissionDenied), errors.Is(err, services.ErrEmailNotVerified),
+		errors.Is(err, services.ErrNotCommentAuthor):
+		return c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Error: err.Error(),
+		})
+	case errors.Is(err, services.ErrSlugAlreadyExists), errors.Is(err, services.ErrReviewApprovalRequired), errors.Is(err, services.ErrInvalidReviewState),
+		errors.Is(err, services.ErrRevisionOutdated):
+		return c.JSON(http.StatusConflict, models.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		Error:   message,
+		Message: err.Error(),
+	})
+}
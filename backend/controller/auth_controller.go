@@ -1,10 +1,14 @@
 package controller
 
 import (
+	"errors"
+	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/yamada-mikiya/team1-hackathon/config"
+	"github.com/yamada-mikiya/team1-hackathon/mailer"
 	"github.com/yamada-mikiya/team1-hackathon/models"
 	"github.com/yamada-mikiya/team1-hackathon/repositories"
 	"github.com/yamada-mikiya/team1-hackathon/services"
@@ -12,22 +16,31 @@ import (
 )
 
 type AuthController struct {
-	service services.AuthService
-	config  *config.Config
+	service                  services.AuthService
+	passwordResetService     services.PasswordResetService
+	emailVerificationService services.EmailVerificationService
+	config                   *config.Config
 }
 
-func NewAuthController(cfg *config.Config, db *gorm.DB) *AuthController {
+func NewAuthController(cfg *config.Config, db *gorm.DB, m mailer.Mailer) *AuthController {
 	userRepo := repositories.NewUserRepository(db)
-	service := services.NewAuthService(userRepo, db, cfg.SecretKey)
+	revokedTokenRepo := repositories.NewRevokedTokenRepository(db)
+	refreshTokenRepo := repositories.NewRefreshTokenRepository(db)
+	passwordResetRepo := repositories.NewPasswordResetTokenRepository(db)
+	service := services.NewAuthService(userRepo, revokedTokenRepo, refreshTokenRepo, db, cfg.SecretKey, cfg.Auth.AccessTokenTTL, cfg.Auth.RefreshTokenTTL)
+	passwordResetService := services.NewPasswordResetService(userRepo, passwordResetRepo, refreshTokenRepo, m, cfg.Auth.PasswordResetURL, cfg.Auth.PasswordResetTTL)
+	emailVerificationService := services.NewEmailVerificationService(userRepo, m, cfg.SecretKey, cfg.Auth.EmailVerificationURL, cfg.Auth.EmailVerificationTTL, cfg.Auth.AllowedEmailDomains)
 	return &AuthController{
-		service: service,
-		config:  cfg,
+		service:                  service,
+		passwordResetService:     passwordResetService,
+		emailVerificationService: emailVerificationService,
+		config:                   cfg,
 	}
 }
 
 // SignUpHandler は新しいユーザーアカウントを作成します
 // @Summary      新規ユーザー登録 (Sign Up)
-// @Description  新しいユーザーアカウントを作成し、認証トークンとユーザー情報を返します。
+// @Description  新しいユーザーアカウントを作成し、認証トークンとユーザー情報を返します。登録したメールアドレスに確認メールを送信し、確認が完了するまでは内部公開記事の閲覧や記事の作成はできません。許可するメールドメインが設定されている場合、それ以外のアドレスでも登録はできますが、メールアドレスを確認できないためゲストのままです（確認メールも送信しません）。
 // @Tags         認証 (Auth)
 // @Accept       json
 // @Produce      json
@@ -59,7 +72,7 @@ func (c *AuthController) SignUpHandler(ctx echo.Context) error {
 		})
 	}
 
-	userRes, tokenString, err := c.service.SignUp(ctx.Request().Context(), req)
+	userRes, tokens, err := c.service.SignUp(ctx.Request().Context(), req)
 	if err != nil {
 		statusCode := http.StatusInternalServerError
 		if err.Error() == "このメールアドレスは既に登録されています" {
@@ -71,23 +84,20 @@ func (c *AuthController) SignUpHandler(ctx echo.Context) error {
 		})
 	}
 
-	// CookieにJWTトークンを設定
-	isProduction := c.config.Server.Environment == "production"
-	cookie := &http.Cookie{
-		Name:     "token",
-		Value:    tokenString,
-		Path:     "/",
-		Domain:   c.config.Server.CookieDomain, // クロスドメインでのクッキー共有用
-		MaxAge:   259200,                       // 72時間
-		HttpOnly: true,
-		Secure:   isProduction, // 本番環境ではtrue
-		SameSite: http.SameSiteLaxMode,
+	// 確認メールの送信に失敗しても登録自体は成功とし、再送できるようにする
+	// 許可するドメイン以外のメールアドレスはメンバーになれないため、確認メールを送らずゲストとして登録する
+	if err := c.emailVerificationService.SendVerificationEmail(ctx.Request().Context(), userRes.ID); err != nil && !errors.Is(err, services.ErrEmailDomainNotAllowed) {
+		slog.Error("確認メールの送信に失敗しました", "error", err, "user_id", userRes.ID)
 	}
-	ctx.SetCookie(cookie)
+
+	// Cookieにアクセストークンとリフレッシュトークンを設定
+	c.setAuthCookies(ctx, tokens)
 
 	signUpRes := models.AuthResponse{
-		Token: tokenString,
-		User:  userRes,
+		Token:          tokens.AccessToken,
+		TokenExpiresAt: tokens.AccessTokenExpiresAt,
+		RefreshToken:   tokens.RefreshToken,
+		User:           userRes,
 	}
 
 	return ctx.JSON(http.StatusCreated, signUpRes)
@@ -121,7 +131,7 @@ func (c *AuthController) LogInHandler(ctx echo.Context) error {
 		})
 	}
 
-	userRes, tokenString, err := c.service.LogIn(ctx.Request().Context(), req)
+	userRes, tokens, err := c.service.LogIn(ctx.Request().Context(), req)
 	if err != nil {
 		statusCode := http.StatusInternalServerError
 		if err.Error() == "メールアドレスまたはパスワードが正しくありません" {
@@ -133,26 +143,330 @@ func (c *AuthController) LogInHandler(ctx echo.Context) error {
 		})
 	}
 
-	// CookieにJWTトークンを設定
+	// Cookieにアクセストークンとリフレッシュトークンを設定
+	c.setAuthCookies(ctx, tokens)
+
+	logInRes := models.AuthResponse{
+		Token:          tokens.AccessToken,
+		TokenExpiresAt: tokens.AccessTokenExpiresAt,
+		RefreshToken:   tokens.RefreshToken,
+		User:           userRes,
+	}
+
+	return ctx.JSON(http.StatusOK, logInRes)
+}
+
+// RefreshHandler はアクセストークンを再発行します
+// @Summary      トークンの再発行 (Refresh)
+// @Description  リフレッシュトークン（Cookieの`refresh_token`、またはボディの`refresh_token`）を使って新しいアクセストークンを発行します。リフレッシュトークンは使うたびに新しいものに置き換わり、使用済みのトークンが再利用された場合は同じログインから発行されたトークンがすべて無効になります。
+// @Tags         認証 (Auth)
+// @Accept       json
+// @Produce      json
+// @Param        payload body models.RefreshRequest false "リフレッシュトークン (Cookieを使う場合は不要)"
+// @Success      200 {object} models.AuthResponse "再発行成功。新しいアクセストークンとリフレッシュトークンを返します。"
+// @Failure      401 {object} models.ErrorResponse "リフレッシュトークンが無効か有効期限が切れています"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/auth/refresh [post]
+func (c *AuthController) RefreshHandler(ctx echo.Context) error {
+	userRes, tokens, err := c.service.Refresh(ctx.Request().Context(), refreshTokenFromRequest(ctx))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidRefreshToken) {
+			// 無効なリフレッシュトークンを送り続けないようCookieを削除する
+			c.clearAuthCookies(ctx)
+			return ctx.JSON(http.StatusUnauthorized, models.ErrorResponse{
+				Error: err.Error(),
+			})
+		}
+		return ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "トークンの再発行に失敗しました",
+			Message: err.Error(),
+		})
+	}
+
+	c.setAuthCookies(ctx, tokens)
+
+	return ctx.JSON(http.StatusOK, models.AuthResponse{
+		Token:          tokens.AccessToken,
+		TokenExpiresAt: tokens.AccessTokenExpiresAt,
+		RefreshToken:   tokens.RefreshToken,
+		User:           userRes,
+	})
+}
+
+// LogOutHandler はログアウトします
+// @Summary      ログアウト (Log Out)
+// @Description  現在のアクセストークンとリフレッシュトークンを失効させ、Cookieを削除します。失効したトークンはAuthorizationヘッダーで送っても認証に使えません。未ログインの場合もCookieを削除して成功を返します。
+// @Tags         認証 (Auth)
+// @Accept       json
+// @Param        payload body models.RefreshRequest false "リフレッシュトークン (Cookieを使う場合は不要)"
+// @Success      204 "ログアウト成功"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/auth/logout [post]
+func (c *AuthController) LogOutHandler(ctx echo.Context) error {
+	claims, _ := ctx.Get("user").(*models.JwtCustomClaims)
+	if err := c.service.LogOut(ctx.Request().Context(), claims, refreshTokenFromRequest(ctx)); err != nil {
+		return ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "ログアウトに失敗しました",
+			Message: err.Error(),
+		})
+	}
+
+	c.clearAuthCookies(ctx)
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// ForgotPasswordHandler はパスワードリセットメールを送信します
+// @Summary      パスワードリセットメールの送信
+// @Description  指定したメールアドレスにパスワード再設定用のリンクを送信します。リンクは一定時間で期限切れになり、一度だけ使用できます。登録されていないメールアドレスの場合もメールは送らずに同じレスポンスを返します。メールは送信の完了を待たずにバックグラウンドで送信し、送信に失敗した場合もレスポンスは変わりません。
+// @Tags         認証 (Auth)
+// @Accept       json
+// @Produce      json
+// @Param        payload body models.ForgotPasswordRequest true "メールアドレス"
+// @Success      202 "受け付けました"
+// @Failure      400 {object} models.ErrorResponse "リクエストボディが不正です"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/auth/password/forgot [post]
+func (c *AuthController) ForgotPasswordHandler(ctx echo.Context) error {
+	req := models.ForgotPasswordRequest{}
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "リクエストの形式が不正です",
+			Message: err.Error(),
+		})
+	}
+
+	if err := c.passwordResetService.RequestPasswordReset(ctx.Request().Context(), req); err != nil {
+		return passwordResetErrorResponse(ctx, err, "パスワードリセットメールの送信に失敗しました")
+	}
+
+	return ctx.NoContent(http.StatusAccepted)
+}
+
+// ResetPasswordHandler はパスワードを再設定します
+// @Summary      パスワードの再設定
+// @Description  メールで届いたリンクのトークンと新しいパスワードを指定して、パスワードを変更します。変更すると、すべての端末のリフレッシュトークンが無効になります。
+// @Tags         認証 (Auth)
+// @Accept       json
+// @Produce      json
+// @Param        payload body models.ResetPasswordRequest true "トークンと新しいパスワード"
+// @Success      204 "再設定成功"
+// @Failure      400 {object} models.ErrorResponse "リンクが無効か有効期限が切れています / パスワードが短すぎます"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/auth/password/reset [post]
+func (c *AuthController) ResetPasswordHandler(ctx echo.Context) error {
+	req := models.ResetPasswordRequest{}
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "リクエストの形式が不正です",
+			Message: err.Error(),
+		})
+	}
+
+	if err := c.passwordResetService.ResetPassword(ctx.Request().Context(), req); err != nil {
+		return passwordResetErrorResponse(ctx, err, "パスワードの再設定に失敗しました")
+	}
+
+	// 再設定後は改めてログインしてもらう
+	c.clearAuthCookies(ctx)
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// ChangePasswordHandler はログイン中のユーザーのパスワードを変更します
+// @Summary      パスワードの変更
+// @Description  現在のパスワードを確認してから新しいパスワードに変更します。変更すると他の端末のログインはすべて無効になり、この端末には新しい認証トークンを発行します。
+// @Tags         ユーザー (Users)
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        payload body models.ChangePasswordRequest true "現在のパスワードと新しいパスワード"
+// @Success      200 {object} models.AuthResponse "変更成功。新しい認証トークンを返します。"
+// @Failure      400 {object} models.ErrorResponse "現在のパスワードが正しくありません / パスワードが短すぎます"
+// @Failure      401 {object} models.ErrorResponse "認証されていません"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/users/me/password [put]
+func (c *AuthController) ChangePasswordHandler(ctx echo.Context) error {
+	claims, ok := ctx.Get("user").(*models.JwtCustomClaims)
+	if !ok {
+		return ctx.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "認証されていません",
+		})
+	}
+
+	req := models.ChangePasswordRequest{}
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "リクエストの形式が不正です",
+			Message: err.Error(),
+		})
+	}
+
+	userRes, tokens, err := c.service.ChangePassword(ctx.Request().Context(), claims, req)
+	if err != nil {
+		if errors.Is(err, services.ErrIncorrectPassword) {
+			return ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error: err.Error(),
+			})
+		}
+		return passwordResetErrorResponse(ctx, err, "パスワードの変更に失敗しました")
+	}
+
+	c.setAuthCookies(ctx, tokens)
+
+	return ctx.JSON(http.StatusOK, models.AuthResponse{
+		Token:          tokens.AccessToken,
+		TokenExpiresAt: tokens.AccessTokenExpiresAt,
+		RefreshToken:   tokens.RefreshToken,
+		User:           userRes,
+	})
+}
+
+// VerifyEmailHandler はメールアドレスを確認済みにします
+// @Summary      メールアドレスの確認
+// @Description  確認メールのリンクに含まれるトークンを検証し、メールアドレスを確認済みにします。確認後は内部公開記事の閲覧や記事の作成ができるようになります。
+// @Tags         認証 (Auth)
+// @Accept       json
+// @Produce      json
+// @Param        payload body models.VerifyEmailRequest true "確認メールのトークン"
+// @Success      200 {object} models.UserResponse "確認後のユーザー情報"
+// @Failure      400 {object} models.ErrorResponse "確認リンクが無効か有効期限が切れています"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/auth/email/verify [post]
+func (c *AuthController) VerifyEmailHandler(ctx echo.Context) error {
+	req := models.VerifyEmailRequest{}
+	if err := ctx.Bind(&req); err != nil {
+		return ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "リクエストの形式が不正です",
+			Message: err.Error(),
+		})
+	}
+
+	userRes, err := c.emailVerificationService.VerifyEmail(ctx.Request().Context(), req)
+	if err != nil {
+		return emailVerificationErrorResponse(ctx, err, "メールアドレスの確認に失敗しました")
+	}
+
+	return ctx.JSON(http.StatusOK, userRes)
+}
+
+// ResendVerificationEmailHandler は確認メールを再送します
+// @Summary      確認メールの再送
+// @Description  ログイン中のユーザーのメールアドレスに確認メールを再送します。
+// @Tags         認証 (Auth)
+// @Security     Bearer
+// @Success      202 "送信しました"
+// @Failure      400 {object} models.ErrorResponse "メールアドレスは既に確認済みです / 登録できないドメインです"
+// @Failure      401 {object} models.ErrorResponse "認証されていません"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/auth/email/resend [post]
+func (c *AuthController) ResendVerificationEmailHandler(ctx echo.Context) error {
+	userID, ok := currentUserID(ctx)
+	if !ok {
+		return ctx.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "認証されていません",
+		})
+	}
+
+	if err := c.emailVerificationService.SendVerificationEmail(ctx.Request().Context(), userID); err != nil {
+		return emailVerificationErrorResponse(ctx, err, "確認メールの送信に失敗しました")
+	}
+
+	return ctx.NoContent(http.StatusAccepted)
+}
+
+// emailVerificationErrorResponse はメールアドレス確認のエラーをHTTPレスポンスに変換します
+func emailVerificationErrorResponse(ctx echo.Context, err error, message string) error {
+	var validationErr *services.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		return ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error: validationErr.Message,
+		})
+	case errors.Is(err, services.ErrInvalidVerification), errors.Is(err, services.ErrEmailDomainNotAllowed):
+		return ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error: err.Error(),
+		})
+	case errors.Is(err, services.ErrUserNotFound):
+		return ctx.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+	return ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		Error:   message,
+		Message: err.Error(),
+	})
+}
+
+// passwordResetErrorResponse はパスワードリセットのエラーをHTTPレスポンスに変換します
+func passwordResetErrorResponse(ctx echo.Context, err error, message string) error {
+	var validationErr *services.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		return ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error: validationErr.Message,
+		})
+	case errors.Is(err, services.ErrInvalidResetToken):
+		return ctx.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+	return ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		Error:   message,
+		Message: err.Error(),
+	})
+}
+
+// refreshTokenFromRequest はCookie、なければリクエストボディからリフレッシュトークンを取得します
+func refreshTokenFromRequest(ctx echo.Context) string {
+	if cookie, err := ctx.Cookie(refreshTokenCookieName); err == nil && cookie.Value != "" {
+		return cookie.Value
+	}
+
+	req := models.RefreshRequest{}
+	if err := ctx.Bind(&req); err != nil {
+		return ""
+	}
+	return req.RefreshToken
+}
+
+const (
+	accessTokenCookieName  = "token"
+	refreshTokenCookieName = "refresh_token"
+	// リフレッシュトークンは認証エンドポイントにだけ送られるようにする
+	refreshTokenCookiePath = "/api/auth"
+)
+
+// setAuthCookies はアクセストークンとリフレッシュトークンのCookieを設定します
+func (c *AuthController) setAuthCookies(ctx echo.Context, tokens *services.AuthTokens) {
+	c.setCookie(ctx, accessTokenCookieName, tokens.AccessToken, "/", time.Until(tokens.AccessTokenExpiresAt))
+	c.setCookie(ctx, refreshTokenCookieName, tokens.RefreshToken, refreshTokenCookiePath, time.Until(tokens.RefreshTokenExpiresAt))
+}
+
+// clearAuthCookies は認証用のCookieを削除します
+// ログイン時と同じ属性（Domain・Path）で上書きしないとブラウザからCookieが消えない
+func (c *AuthController) clearAuthCookies(ctx echo.Context) {
+	c.setCookie(ctx, accessTokenCookieName, "", "/", -1)
+	c.setCookie(ctx, refreshTokenCookieName, "", refreshTokenCookiePath, -1)
+}
+
+// setCookie は認証用のCookieを設定します。maxAgeに負の値を指定するとCookieを削除します
+func (c *AuthController) setCookie(ctx echo.Context, name, value, path string, maxAge time.Duration) {
 	isProduction := c.config.Server.Environment == "production"
 	cookie := &http.Cookie{
-		Name:     "token",
-		Value:    tokenString,
-		Path:     "/",
+		Name:     name,
+		Value:    value,
+		Path:     path,
 		Domain:   c.config.Server.CookieDomain, // クロスドメインでのクッキー共有用
-		MaxAge:   259200,                       // 72時間
+		MaxAge:   int(maxAge.Seconds()),
 		HttpOnly: true,
 		Secure:   isProduction, // 本番環境ではtrue
 		SameSite: http.SameSiteLaxMode,
 	}
-	ctx.SetCookie(cookie)
-
-	logInRes := models.AuthResponse{
-		Token: tokenString,
-		User:  userRes,
+	if maxAge < 0 {
+		cookie.MaxAge = -1
 	}
-
-	return ctx.JSON(http.StatusOK, logInRes)
+	ctx.SetCookie(cookie)
 }
 
 // GetMeHandler は現在ログインしているユーザー情報を取得します
@@ -0,0 +1,234 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"github.com/yamada-mikiya/team1-hackathon/repositories"
+	"github.com/yamada-mikiya/team1-hackathon/services"
+	"gorm.io/gorm"
+)
+
+type CommentController struct {
+	service services.CommentService
+}
+
+func NewCommentController(db *gorm.DB) *CommentController {
+	articleRepo := repositories.NewArticleRepository(db)
+	commentRepo := repositories.NewCommentRepository(db)
+	userRepo := repositories.NewUserRepository(db)
+	return &CommentController{
+		service: services.NewCommentService(articleRepo, commentRepo, userRepo),
+	}
+}
+
+// GetComments は記事のコメント一覧を取得します
+// @Summary      コメント一覧を取得
+// @Description  記事のコメントを古い順に取得します。ページ分けは返信でないコメント単位で、それぞれの返信（古い順）をrepliesに含めます。記事を閲覧できるユーザーのみ取得でき、internal記事のコメントはメールアドレスを確認済みのメンバーのみ閲覧できます。削除・非表示のコメントは本文を伏せて返します。
+// @Tags         コメント (Comments)
+// @Produce      json
+// @Param        slug path string true "記事のスラグ" example("go-api-development")
+// @Param        page query int false "ページ番号 (デフォルト: 1)" default(1)
+// @Param        limit query int false "1ページあたりのコメント数 (デフォルト: 10, 最大: 100)" default(10)
+// @Success      200 {object} models.CommentListResponse "コメント一覧"
+// @Failure      403 {object} models.ErrorResponse "内部公開記事にアクセスするにはログインとメールアドレスの確認が必要です"
+// @Failure      404 {object} models.ErrorResponse "記事が見つかりません"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/articles/{slug}/comments [get]
+func (cc *CommentController) GetComments(c echo.Context) error {
+	viewerID, _ := currentUserID(c)
+	page, limit := paginationParams(c)
+
+	response, err := cc.service.GetComments(c.Param("slug"), viewerID, page, limit)
+	if err != nil {
+		return articleErrorResponse(c, err, "コメントの取得に失敗しました")
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// CreateComment は記事にコメントします
+// @Summary      コメントを投稿
+// @Description  記事にコメント、またはコメントへの返信（parent_idを指定）を投稿します。返信への返信はできません。本文はMarkdownで、記事本文と同じようにサニタイズしたHTMLをbody_htmlとして返します。メールアドレスを確認済みのメンバーが、閲覧できる公開中（internal・public）の記事にのみ投稿できます。
+// @Tags         コメント (Comments)
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        slug path string true "記事のスラグ" example("go-api-development")
+// @Param        payload body models.CreateCommentRequest true "コメント"
+// @Success      201 {object} models.CommentResponse "投稿したコメント"
+// @Failure      400 {object} models.ErrorResponse "リクエストボディが不正です"
+// @Failure      401 {object} models.ErrorResponse "認証されていません"
+// @Failure      403 {object} models.ErrorResponse "メールアドレスの確認が完了していません"
+// @Failure      404 {object} models.ErrorResponse "記事が見つかりません"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/articles/{slug}/comments [post]
+func (cc *CommentController) CreateComment(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "認証されていません",
+		})
+	}
+
+	req := models.CreateCommentRequest{}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "リクエストの形式が不正です",
+			Message: err.Error(),
+		})
+	}
+
+	response, err := cc.service.CreateComment(c.Param("slug"), userID, req)
+	if err != nil {
+		return articleErrorResponse(c, err, "コメントの投稿に失敗しました")
+	}
+
+	return c.JSON(http.StatusCreated, response)
+}
+
+// UpdateComment はコメントを編集します
+// @Summary      コメントを編集
+// @Description  自分のコメントの本文を編集します。削除したコメントは編集できません。
+// @Tags         コメント (Comments)
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        slug path string true "記事のスラグ" example("go-api-development")
+// @Param        id path int true "コメントのID" example(1)
+// @Param        payload body models.UpdateCommentRequest true "コメント"
+// @Success      200 {object} models.CommentResponse "編集したコメント"
+// @Failure      400 {object} models.ErrorResponse "リクエストボディが不正です"
+// @Failure      401 {object} models.ErrorResponse "認証されていません"
+// @Failure      403 {object} models.ErrorResponse "このコメントを変更する権限がありません"
+// @Failure      404 {object} models.ErrorResponse "コメントが見つかりません"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/articles/{slug}/comments/{id} [patch]
+func (cc *CommentController) UpdateComment(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "認証されていません",
+		})
+	}
+	commentID, err := commentIDParam(c)
+	if err != nil {
+		return articleErrorResponse(c, err, "コメントの編集に失敗しました")
+	}
+
+	req := models.UpdateCommentRequest{}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "リクエストの形式が不正です",
+			Message: err.Error(),
+		})
+	}
+
+	response, err := cc.service.UpdateComment(c.Param("slug"), commentID, userID, req)
+	if err != nil {
+		return articleErrorResponse(c, err, "コメントの編集に失敗しました")
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// DeleteComment はコメントを削除します
+// @Summary      コメントを削除
+// @Description  自分のコメントを削除します。返信のスレッドを保つため、一覧には本文を伏せたコメントとして残ります。
+// @Tags         コメント (Comments)
+// @Produce      json
+// @Security     Bearer
+// @Param        slug path string true "記事のスラグ" example("go-api-development")
+// @Param        id path int true "コメントのID" example(1)
+// @Success      204 "削除成功"
+// @Failure      401 {object} models.ErrorResponse "認証されていません"
+// @Failure      403 {object} models.ErrorResponse "このコメントを変更する権限がありません"
+// @Failure      404 {object} models.ErrorResponse "コメントが見つかりません"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/articles/{slug}/comments/{id} [delete]
+func (cc *CommentController) DeleteComment(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "認証されていません",
+		})
+	}
+	commentID, err := commentIDParam(c)
+	if err != nil {
+		return articleErrorResponse(c, err, "コメントの削除に失敗しました")
+	}
+
+	if err := cc.service.DeleteComment(c.Param("slug"), commentID, userID); err != nil {
+		return articleErrorResponse(c, err, "コメントの削除に失敗しました")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// HideComment はコメントを非表示にします
+// @Summary      コメントを非表示
+// @Description  コメントを非表示にします。非表示のコメントは記事の著者・管理者・投稿者本人以外には本文を伏せて返します。記事の著者と管理者のみ実行できます。
+// @Tags         コメント (Comments)
+// @Produce      json
+// @Security     Bearer
+// @Param        slug path string true "記事のスラグ" example("go-api-development")
+// @Param        id path int true "コメントのID" example(1)
+// @Success      200 {object} models.CommentResponse "非表示にしたコメント"
+// @Failure      401 {object} models.ErrorResponse "認証されていません"
+// @Failure      403 {object} models.ErrorResponse "この操作を行う権限がありません"
+// @Failure      404 {object} models.ErrorResponse "コメントが見つかりません"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/articles/{slug}/comments/{id}/hide [post]
+func (cc *CommentController) HideComment(c echo.Context) error {
+	return cc.moderate(c, cc.service.HideComment, "コメントの非表示に失敗しました")
+}
+
+// UnhideComment は非表示にしたコメントを再び表示します
+// @Summary      コメントの非表示を解除
+// @Description  非表示にしたコメントを再び表示します。記事の著者と管理者のみ実行できます。
+// @Tags         コメント (Comments)
+// @Produce      json
+// @Security     Bearer
+// @Param        slug path string true "記事のスラグ" example("go-api-development")
+// @Param        id path int true "コメントのID" example(1)
+// @Success      200 {object} models.CommentResponse "表示したコメント"
+// @Failure      401 {object} models.ErrorResponse "認証されていません"
+// @Failure      403 {object} models.ErrorResponse "この操作を行う権限がありません"
+// @Failure      404 {object} models.ErrorResponse "コメントが見つかりません"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/articles/{slug}/comments/{id}/unhide [post]
+func (cc *CommentController) UnhideComment(c echo.Context) error {
+	return cc.moderate(c, cc.service.UnhideComment, "コメントの非表示の解除に失敗しました")
+}
+
+// moderate はコメントの非表示・解除の共通処理です
+func (cc *CommentController) moderate(c echo.Context, action func(slug string, commentID, userID int) (*models.CommentResponse, error), message string) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "認証されていません",
+		})
+	}
+	commentID, err := commentIDParam(c)
+	if err != nil {
+		return articleErrorResponse(c, err, message)
+	}
+
+	response, err := action(c.Param("slug"), commentID, userID)
+	if err != nil {
+		return articleErrorResponse(c, err, message)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// commentIDParam はパスパラメータのコメントIDを取得します（数値でない場合は見つからない扱い）
+func commentIDParam(c echo.Context) (int, error) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id < 1 {
+		return 0, services.ErrCommentNotFound
+	}
+	return id, nil
+}
@@ -0,0 +1,162 @@
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"github.com/yamada-mikiya/team1-hackathon/repositories"
+	"github.com/yamada-mikiya/team1-hackathon/services"
+)
+
+// currentUserID はミドルウェアでContextにセットされたユーザーIDを取得します
+// ゲストの場合はfalseを返します
+func currentUserID(c echo.Context) (int, bool) {
+	userID, ok := c.Get("user_id").(int)
+	return userID, ok
+}
+
+// paginationParams はクエリパラメータからページ番号と1ページあたりの件数を取得します
+func paginationParams(c echo.Context) (page, limit int) {
+	page, _ = strconv.Atoi(c.QueryParam("page"))
+	if page < 1 {
+		page = 1
+	}
+
+	limit, _ = strconv.Atoi(c.QueryParam("limit"))
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	return page, limit
+}
+
+// articleFiltersFromQuery はクエリパラメータとログイン状態から記事一覧のフィルタを組み立てます
+// クエリパラメータの形式が不正な場合は*services.ValidationErrorを返します（値の検証はサービス層で行います）
+func articleFiltersFromQuery(c echo.Context) (repositories.ArticleFilters, error) {
+	viewerID, _ := currentUserID(c)
+
+	filters := repositories.ArticleFilters{
+		Departments:  queryList(c, "department"),
+		ArticleTypes: queryList(c, "article_type"),
+		Status:       c.QueryParam("status"),
+		Tag:          c.QueryParam("tag"),
+		Query:        c.QueryParam("q"),
+		Sort:         c.QueryParam("sort"),
+		Order:        c.QueryParam("order"),
+		ViewerID:     viewerID,
+	}
+
+	if authorID := c.QueryParam("author_id"); authorID != "" {
+		id, err := strconv.Atoi(authorID)
+		if err != nil || id < 1 {
+			return repositories.ArticleFilters{}, &services.ValidationError{Message: "author_idにはユーザーIDを指定してください"}
+		}
+		filters.AuthorID = id
+	}
+
+	var err error
+	if filters.CreatedAfter, err = queryTime(c, "created_after"); err != nil {
+		return repositories.ArticleFilters{}, err
+	}
+	if filters.CreatedBefore, err = queryTime(c, "created_before"); err != nil {
+		return repositories.ArticleFilters{}, err
+	}
+
+	return filters, nil
+}
+
+// rankingFiltersFromQuery はクエリパラメータとログイン状態から人気記事・トレンド記事のフィルタを組み立てます
+// 並び順はランキングで決まるため、sort・order・qは使いません
+func rankingFiltersFromQuery(c echo.Context) repositories.ArticleFilters {
+	viewerID, _ := currentUserID(c)
+
+	return repositories.ArticleFilters{
+		Departments:  queryList(c, "department"),
+		ArticleTypes: queryList(c, "article_type"),
+		Status:       c.QueryParam("status"),
+		Tag:          c.QueryParam("tag"),
+		ViewerID:     viewerID,
+	}
+}
+
+// queryList はカンマ区切りのクエリパラメータを分割します（空の要素は除きます）
+func queryList(c echo.Context, name string) []string {
+	var values []string
+	for _, v := range strings.Split(c.QueryParam(name), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// queryTime は日時のクエリパラメータをRFC 3339（例: 2026-01-06T09:00:00+09:00）または日付（例: 2026-01-06、UTCの0時）として読み取ります
+// 省略された場合はnilを返します
+func queryTime(c echo.Context, name string) (*time.Time, error) {
+	value := c.QueryParam(name)
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return &t, nil
+	}
+	return nil, &services.ValidationError{Message: fmt.Sprintf("%sには日時（例: 2026-01-06T09:00:00+09:00）または日付（例: 2026-01-06）を指定してください", name)}
+}
+
+// listArticles はクエリパラメータに応じたページネーション方法で記事一覧を取得します
+//   - 既定はページ番号方式（page・limit。総件数も返す）。既存のクライアントとの互換性のため
+//   - cursorまたはpagination=cursorを指定した場合はカーソル方式（include_total=trueの場合のみ総件数を数える）
+func listArticles(c echo.Context, service services.ArticleService, filters repositories.ArticleFilters) (*models.ArticleListResponse, error) {
+	page, limit := paginationParams(c)
+	cursor := c.QueryParam("cursor")
+
+	switch c.QueryParam("pagination") {
+	case "", "page":
+		if cursor == "" {
+			return service.GetArticles(filters, page, limit)
+		}
+		if c.QueryParam("pagination") == "page" {
+			return nil, &services.ValidationError{Message: "cursorはpagination=cursorの場合のみ指定できます"}
+		}
+	case "cursor":
+	default:
+		return nil, &services.ValidationError{Message: fmt.Sprintf("paginationに不明な値 %q が指定されています（page, cursorのいずれか）", c.QueryParam("pagination"))}
+	}
+	if c.QueryParam("page") != "" {
+		return nil, &services.ValidationError{Message: "カーソル方式（cursor・pagination=cursor）ではpageを指定できません"}
+	}
+
+	includeTotal, _ := strconv.ParseBool(c.QueryParam("include_total"))
+	return service.GetArticlesByCursor(filters, cursor, limit, includeTotal)
+}
+
+// includesField はクエリパラメータincludeにfieldが指定されているかを返します（カンマ区切りで複数指定できます）
+func includesField(c echo.Context, field string) bool {
+	return slices.Contains(strings.Split(c.QueryParam("include"), ","), field)
+}
+
+// applyArticleIncludes は ?include=content_html が指定されていない場合、レスポンスから本文のHTMLを取り除きます
+// HTMLは本文と同程度のサイズになるため、必要なクライアントだけが受け取るようにしています
+func applyArticleIncludes(c echo.Context, articles ...*models.ArticleResponse) {
+	if includesField(c, "content_html") {
+		return
+	}
+	for _, article := range articles {
+		article.ContentHTML = nil
+	}
+}
+
+// applyArticleListIncludes は記事一覧のレスポンスに applyArticleIncludes を適用します
+func applyArticleListIncludes(c echo.Context, list *models.ArticleListResponse) {
+	for i := range list.Articles {
+		applyArticleIncludes(c, &list.Articles[i])
+	}
+}
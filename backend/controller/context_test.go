@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"github.com/yamada-mikiya/team1-hackathon/repositories"
+	"github.com/yamada-mikiya/team1-hackathon/services"
+)
+
+// listArticleService はどちらのページネーション方式で記事一覧を取得したかを記録するArticleService
+type listArticleService struct {
+	services.ArticleService
+	called string
+}
+
+func (s *listArticleService) GetArticles(filters repositories.ArticleFilters, page, limit int) (*models.ArticleListResponse, error) {
+	s.called = "page"
+	return &models.ArticleListResponse{Page: page, Limit: limit}, nil
+}
+
+func (s *listArticleService) GetArticlesByCursor(filters repositories.ArticleFilters, cursor string, limit int, includeTotal bool) (*models.ArticleListResponse, error) {
+	s.called = "cursor"
+	return &models.ArticleListResponse{Limit: limit}, nil
+}
+
+func TestListArticlesPagination(t *testing.T) {
+	tests := []struct {
+		query   string
+		want    string
+		wantErr bool
+	}{
+		{"", "page", false},
+		{"limit=20", "page", false},
+		{"page=2&limit=20", "page", false},
+		{"pagination=page", "page", false},
+		{"q=go", "page", false},
+		{"pagination=cursor", "cursor", false},
+		{"pagination=cursor&include_total=true", "cursor", false},
+		{"cursor=abc", "cursor", false},
+		{"pagination=page&cursor=abc", "", true},
+		{"pagination=cursor&page=2", "", true},
+		{"pagination=offset", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/articles?"+tt.query, nil)
+			c := echo.New().NewContext(req, httptest.NewRecorder())
+			service := &listArticleService{}
+
+			_, err := listArticles(c, service, repositories.ArticleFilters{Query: c.QueryParam("q")})
+			if tt.wantErr {
+				var validationErr *services.ValidationError
+				if !errors.As(err, &validationErr) {
+					t.Errorf("err = %v, want *ValidationError", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("listArticles: %v", err)
+			}
+			if service.called != tt.want {
+				t.Errorf("方式 = %q, want %q", service.called, tt.want)
+			}
+		})
+	}
+}
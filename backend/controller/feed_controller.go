@@ -0,0 +1,112 @@
+package controller
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/feeds"
+	"github.com/labstack/echo/v4"
+	"github.com/yamada-mikiya/team1-hackathon/config"
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"github.com/yamada-mikiya/team1-hackathon/repositories"
+	"github.com/yamada-mikiya/team1-hackathon/services"
+	"gorm.io/gorm"
+)
+
+// feedCacheControl はフィードのCache-Control。フィードリーダーの定期取得は条件付きGETで304を返す
+const feedCacheControl = "public, max-age=300"
+
+type FeedController struct {
+	service services.FeedService
+}
+
+func NewFeedController(cfg *config.Config, db *gorm.DB) *FeedController {
+	repo := repositories.NewArticleRepository(db)
+	tagRepo := repositories.NewTagRepository(db)
+	reviewRepo := repositories.NewReviewRepository(db)
+	userRepo := repositories.NewUserRepository(db)
+	articleService := services.NewArticleService(repo, tagRepo, reviewRepo, userRepo, nil)
+	return &FeedController{
+		service: services.NewFeedService(articleService, userRepo, cfg.Site.Title, cfg.Site.Description, cfg.Site.URL),
+	}
+}
+
+// GetRSSFeed は公開記事のRSS 2.0フィードを返します
+// @Summary      RSSフィード
+// @Description  公開(public)記事の新着20件をRSS 2.0で返します。Markdownの記事は本文全体をHTML（content:encoded）で、外部記事はリンク先のURLを載せます。ETag・Last-Modifiedによる条件付きGETに対応しています。
+// @Tags         フィード (Feeds)
+// @Produce      xml
+// @Param        department query string false "部署で絞り込み (Dev, MKT, Ops。カンマ区切りで複数指定可)"
+// @Param        tag query string false "タグ名で絞り込み"
+// @Param        author_id query int false "著者のユーザーIDで絞り込み"
+// @Success      200 {string} string "RSS 2.0"
+// @Success      304 "更新なし"
+// @Failure      400 {object} models.ErrorResponse "リクエストパラメータが不正です"
+// @Failure      404 {object} models.ErrorResponse "ユーザーが見つかりません"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /feed.xml [get]
+func (fc *FeedController) GetRSSFeed(c echo.Context) error {
+	return fc.writeFeed(c, "application/rss+xml; charset=utf-8", (*feeds.Feed).WriteRss)
+}
+
+// GetAtomFeed は公開記事のAtomフィードを返します
+// @Summary      Atomフィード
+// @Description  公開(public)記事の新着20件をAtomで返します。内容と絞り込みはRSSフィードと同じです。
+// @Tags         フィード (Feeds)
+// @Produce      xml
+// @Param        department query string false "部署で絞り込み (Dev, MKT, Ops。カンマ区切りで複数指定可)"
+// @Param        tag query string false "タグ名で絞り込み"
+// @Param        author_id query int false "著者のユーザーIDで絞り込み"
+// @Success      200 {string} string "Atom"
+// @Success      304 "更新なし"
+// @Failure      400 {object} models.ErrorResponse "リクエストパラメータが不正です"
+// @Failure      404 {object} models.ErrorResponse "ユーザーが見つかりません"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /atom.xml [get]
+func (fc *FeedController) GetAtomFeed(c echo.Context) error {
+	return fc.writeFeed(c, "application/atom+xml; charset=utf-8", (*feeds.Feed).WriteAtom)
+}
+
+// writeFeed はフィードを組み立ててwriteの形式で書き出します
+// 内容のハッシュをETagに、最後に更新された記事の日時をLast-Modifiedにして、条件付きGETに304を返します
+func (fc *FeedController) writeFeed(c echo.Context, contentType string, write func(*feeds.Feed, io.Writer) error) error {
+	filters := repositories.ArticleFilters{
+		Departments: queryList(c, "department"),
+		Tag:         c.QueryParam("tag"),
+	}
+	if authorID := c.QueryParam("author_id"); authorID != "" {
+		id, err := strconv.Atoi(authorID)
+		if err != nil || id < 1 {
+			return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+				Error: "author_idにはユーザーIDを指定してください",
+			})
+		}
+		filters.AuthorID = id
+	}
+
+	feed, err := fc.service.GetFeed(c.Request().Context(), filters)
+	if err != nil {
+		return userErrorResponse(c, err, "フィードの生成に失敗しました")
+	}
+
+	var body bytes.Buffer
+	if err := write(feed, &body); err != nil {
+		return c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "フィードの生成に失敗しました",
+			Message: err.Error(),
+		})
+	}
+	sum := sha256.Sum256(body.Bytes())
+
+	header := c.Response().Header()
+	header.Set(echo.HeaderContentType, contentType)
+	header.Set(echo.HeaderCacheControl, feedCacheControl)
+	header.Set("ETag", `"`+hex.EncodeToString(sum[:16])+`"`)
+
+	http.ServeContent(c.Response(), c.Request(), "", feed.Updated, bytes.NewReader(body.Bytes()))
+	return nil
+}
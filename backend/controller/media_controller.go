@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"github.com/yamada-mikiya/team1-hackathon/repositories"
+	"github.com/yamada-mikiya/team1-hackathon/services"
+	"github.com/yamada-mikiya/team1-hackathon/storage"
+	"gorm.io/gorm"
+)
+
+// mediaCacheControl は/mediaから配信するファイルのCache-Control
+// 保存先のキーはアップロードのたびに新しくなる（または内容から決まる）ため、同じURLの内容は変わらない
+const mediaCacheControl = "public, max-age=31536000, immutable"
+
+type MediaController struct {
+	service services.MediaService
+	store   storage.BlobStore
+}
+
+func NewMediaController(db *gorm.DB, store storage.BlobStore) *MediaController {
+	userRepo := repositories.NewUserRepository(db)
+	return &MediaController{
+		service: services.NewMediaService(userRepo, store),
+		store:   store,
+	}
+}
+
+// UploadMedia は記事に使う画像をアップロードします
+// @Summary      画像をアップロード
+// @Description  記事のサムネイルや本文に使う画像（JPEG, PNG, GIF, WebP、10MBまで）をアップロードします。Exifなどのメタデータを取り除き、長辺2560px以内に縮小した元画像と、幅320・640・1280pxの縮小版（元画像より小さいもののみ）のURLを返します。同じ画像をアップロードすると同じURLを返します。記事を作成できるユーザー（admin, editor, writer）のみ実行できます。
+// @Tags         メディア (Media)
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     Bearer
+// @Param        file formData file true "画像ファイル"
+// @Success      201 {object} models.MediaResponse "アップロードした画像のURL"
+// @Failure      400 {object} models.ErrorResponse "画像が指定されていないか、形式・サイズが不正です"
+// @Failure      401 {object} models.ErrorResponse "認証されていません"
+// @Failure      403 {object} models.ErrorResponse "この操作を行う権限がありません"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/media [post]
+func (mc *MediaController) UploadMedia(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "認証されていません",
+		})
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "fileに画像ファイルを指定してください",
+			Message: err.Error(),
+		})
+	}
+	if fileHeader.Size > services.MaxMediaSize {
+		return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error: "画像のサイズが大きすぎます",
+		})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "画像の読み込みに失敗しました",
+			Message: err.Error(),
+		})
+	}
+	defer file.Close()
+
+	response, err := mc.service.Upload(c.Request().Context(), userID, file)
+	if err != nil {
+		return articleErrorResponse(c, err, "画像のアップロードに失敗しました")
+	}
+
+	return c.JSON(http.StatusCreated, response)
+}
+
+// ServeMedia は保存したファイルを配信します
+// 保存先（ローカルディスク・S3）に関わらず同じURLで配信し、長期間キャッシュできるヘッダーを付けます
+func (mc *MediaController) ServeMedia(c echo.Context) error {
+	obj, err := mc.store.Get(c.Request().Context(), c.Param("*"))
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) || errors.Is(err, storage.ErrInvalidKey) {
+			return echo.ErrNotFound
+		}
+		return err
+	}
+	defer obj.Body.Close()
+
+	header := c.Response().Header()
+	header.Set(echo.HeaderCacheControl, mediaCacheControl)
+	header.Set(echo.HeaderXContentTypeOptions, "nosniff")
+	if obj.ContentType != "" {
+		header.Set(echo.HeaderContentType, obj.ContentType)
+	}
+	if obj.ETag != "" {
+		header.Set("ETag", strconv.Quote(obj.ETag))
+	} else {
+		header.Set("ETag", fmt.Sprintf(`"%x-%x"`, obj.LastModified.UnixNano(), obj.Size))
+	}
+
+	// Range・If-None-Match・If-Modified-Sinceはhttp.ServeContentが処理する
+	http.ServeContent(c.Response(), c.Request(), "", obj.LastModified, obj.Body)
+	return nil
+}
@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"github.com/yamada-mikiya/team1-hackathon/repositories"
+	"github.com/yamada-mikiya/team1-hackathon/services"
+	"gorm.io/gorm"
+)
+
+type ReactionController struct {
+	service services.ReactionService
+}
+
+func NewReactionController(db *gorm.DB) *ReactionController {
+	articleRepo := repositories.NewArticleRepository(db)
+	reactionRepo := repositories.NewReactionRepository(db)
+	userRepo := repositories.NewUserRepository(db)
+	return &ReactionController{
+		service: services.NewReactionService(articleRepo, reactionRepo, userRepo),
+	}
+}
+
+// AddReaction は記事にリアクションを追加します
+// @Summary      リアクションを追加
+// @Description  記事に絵文字でリアクションします。同じ絵文字のリアクションは1人1回までで、既にリアクションしている場合も成功になります。メールアドレスを確認済みのメンバーが、閲覧できる公開中（internal・public）の記事にのみリアクションできます。絵文字はthumbs_up(👍)・heart(❤️)・tada(🎉)・laugh(😄)・bulb(💡)・eyes(👀)のいずれかです。
+// @Tags         リアクション (Reactions)
+// @Produce      json
+// @Security     Bearer
+// @Param        slug path string true "記事のスラグ" example("go-api-development")
+// @Param        emoji path string true "絵文字" Enums(thumbs_up, heart, tada, laugh, bulb, eyes)
+// @Success      200 {object} models.ReactionsResponse "記事のリアクション"
+// @Failure      400 {object} models.ErrorResponse "不明な絵文字か、公開中ではない記事です"
+// @Failure      401 {object} models.ErrorResponse "認証されていません"
+// @Failure      403 {object} models.ErrorResponse "メールアドレスの確認が完了していません"
+// @Failure      404 {object} models.ErrorResponse "記事が見つかりません"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/articles/{slug}/reactions/{emoji} [put]
+func (rc *ReactionController) AddReaction(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "認証されていません",
+		})
+	}
+
+	response, err := rc.service.AddReaction(c.Param("slug"), userID, c.Param("emoji"))
+	if err != nil {
+		return articleErrorResponse(c, err, "リアクションの追加に失敗しました")
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// RemoveReaction は記事へのリアクションを取り消します
+// @Summary      リアクションを取り消し
+// @Description  記事に付けた自分のリアクションを取り消します。リアクションしていない場合も成功になります。
+// @Tags         リアクション (Reactions)
+// @Produce      json
+// @Security     Bearer
+// @Param        slug path string true "記事のスラグ" example("go-api-development")
+// @Param        emoji path string true "絵文字" Enums(thumbs_up, heart, tada, laugh, bulb, eyes)
+// @Success      200 {object} models.ReactionsResponse "記事のリアクション"
+// @Failure      400 {object} models.ErrorResponse "不明な絵文字です"
+// @Failure      401 {object} models.ErrorResponse "認証されていません"
+// @Failure      403 {object} models.ErrorResponse "メールアドレスの確認が完了していません"
+// @Failure      404 {object} models.ErrorResponse "記事が見つかりません"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/articles/{slug}/reactions/{emoji} [delete]
+func (rc *ReactionController) RemoveReaction(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "認証されていません",
+		})
+	}
+
+	response, err := rc.service.RemoveReaction(c.Param("slug"), userID, c.Param("emoji"))
+	if err != nil {
+		return articleErrorResponse(c, err, "リアクションの取り消しに失敗しました")
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,201 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"github.com/yamada-mikiya/team1-hackathon/repositories"
+	"github.com/yamada-mikiya/team1-hackathon/services"
+	"gorm.io/gorm"
+)
+
+type ReviewController struct {
+	service services.ReviewService
+}
+
+func NewReviewController(db *gorm.DB) *ReviewController {
+	articleRepo := repositories.NewArticleRepository(db)
+	reviewRepo := repositories.NewReviewRepository(db)
+	userRepo := repositories.NewUserRepository(db)
+	return &ReviewController{
+		service: services.NewReviewService(articleRepo, reviewRepo, userRepo),
+	}
+}
+
+// SubmitForReview はレビューを依頼します
+// @Summary      レビューを依頼
+// @Description  記事にレビュアーを割り当て、ステータスをin_reviewにします。publicにするには記事の部署に所属するレビュアーの承認が必要なため、その部署のレビュアーを1人以上含めてください。著者とレビューを依頼するユーザー自身はレビュアーに指定できません。記事の著者（または記事を管理できるユーザー）のみ実行できます。
+// @Tags         レビュー (Reviews)
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        slug path string true "記事のスラグ" example("go-api-development")
+// @Param        payload body models.SubmitReviewRequest true "レビュアーとコメント"
+// @Success      200 {object} models.ReviewStatusResponse "レビュー状況"
+// @Failure      400 {object} models.ErrorResponse "リクエストボディが不正です"
+// @Failure      401 {object} models.ErrorResponse "認証されていません"
+// @Failure      403 {object} models.ErrorResponse "この記事を変更する権限がありません"
+// @Failure      404 {object} models.ErrorResponse "記事が見つかりません"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/articles/{slug}/reviews [post]
+func (rc *ReviewController) SubmitForReview(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "認証されていません",
+		})
+	}
+
+	req := models.SubmitReviewRequest{}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "リクエストの形式が不正です",
+			Message: err.Error(),
+		})
+	}
+
+	response, err := rc.service.SubmitForReview(c.Request().Context(), c.Param("slug"), userID, req)
+	if err != nil {
+		return articleErrorResponse(c, err, "レビューの依頼に失敗しました")
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// GetReviewStatus はレビュー状況を取得します
+// @Summary      レビュー状況を取得
+// @Description  記事のレビュアーごとの状態とレビューコメントを取得します。記事の著者・レビュアー・記事を管理できるユーザー（管理者・記事の部署の編集者）のみ閲覧できます。
+// @Tags         レビュー (Reviews)
+// @Produce      json
+// @Security     Bearer
+// @Param        slug path string true "記事のスラグ" example("go-api-development")
+// @Success      200 {object} models.ReviewStatusResponse "レビュー状況"
+// @Failure      401 {object} models.ErrorResponse "認証されていません"
+// @Failure      403 {object} models.ErrorResponse "この記事のレビュアーに割り当てられていません"
+// @Failure      404 {object} models.ErrorResponse "記事が見つかりません"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/articles/{slug}/reviews [get]
+func (rc *ReviewController) GetReviewStatus(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "認証されていません",
+		})
+	}
+
+	response, err := rc.service.GetReviewStatus(c.Request().Context(), c.Param("slug"), userID)
+	if err != nil {
+		return articleErrorResponse(c, err, "レビュー状況の取得に失敗しました")
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// Approve は記事を承認します
+// @Summary      記事を承認
+// @Description  レビュアーとしてレビュー中の記事を承認します。記事の部署に所属するレビュアーが承認すると、著者は記事をpublicにできます。確認した内容のリビジョン番号（レビュー状況のrevision_number）を指定してください。確認した後に記事が更新されている場合は承認できません（409）。
+// @Tags         レビュー (Reviews)
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        slug path string true "記事のスラグ" example("go-api-development")
+// @Param        payload body models.ApproveReviewRequest true "確認したリビジョン番号とコメント (任意)"
+// @Success      200 {object} models.ReviewStatusResponse "レビュー状況"
+// @Failure      400 {object} models.ErrorResponse "リビジョン番号がありません"
+// @Failure      401 {object} models.ErrorResponse "認証されていません"
+// @Failure      403 {object} models.ErrorResponse "この記事のレビュアーに割り当てられていません"
+// @Failure      404 {object} models.ErrorResponse "記事が見つかりません"
+// @Failure      409 {object} models.ErrorResponse "この記事はレビュー中ではないか、確認した後に更新されています"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/articles/{slug}/reviews/approve [post]
+func (rc *ReviewController) Approve(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "認証されていません",
+		})
+	}
+
+	req := models.ApproveReviewRequest{}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "リクエストの形式が不正です",
+			Message: err.Error(),
+		})
+	}
+
+	response, err := rc.service.Approve(c.Request().Context(), c.Param("slug"), userID, req)
+	if err != nil {
+		return articleErrorResponse(c, err, "承認に失敗しました")
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// RequestChanges は記事の修正を依頼します
+// @Summary      修正を依頼
+// @Description  レビュアーとしてレビュー中の記事に修正を依頼し、記事を下書き(draft)に戻します。コメントは必須です。
+// @Tags         レビュー (Reviews)
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        slug path string true "記事のスラグ" example("go-api-development")
+// @Param        payload body models.ReviewCommentRequest true "修正してほしい内容"
+// @Success      200 {object} models.ReviewStatusResponse "レビュー状況"
+// @Failure      400 {object} models.ErrorResponse "コメントがありません"
+// @Failure      401 {object} models.ErrorResponse "認証されていません"
+// @Failure      403 {object} models.ErrorResponse "この記事のレビュアーに割り当てられていません"
+// @Failure      404 {object} models.ErrorResponse "記事が見つかりません"
+// @Failure      409 {object} models.ErrorResponse "この記事はレビュー中ではありません"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/articles/{slug}/reviews/request-changes [post]
+func (rc *ReviewController) RequestChanges(c echo.Context) error {
+	return rc.reviewAction(c, rc.service.RequestChanges, "修正依頼に失敗しました")
+}
+
+// AddComment はレビューコメントを追加します
+// @Summary      レビューコメントを追加
+// @Description  記事の著者またはレビュアーとしてレビューコメントを追加します。
+// @Tags         レビュー (Reviews)
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        slug path string true "記事のスラグ" example("go-api-development")
+// @Param        payload body models.ReviewCommentRequest true "コメント"
+// @Success      200 {object} models.ReviewStatusResponse "レビュー状況"
+// @Failure      400 {object} models.ErrorResponse "コメントがありません"
+// @Failure      401 {object} models.ErrorResponse "認証されていません"
+// @Failure      403 {object} models.ErrorResponse "この記事のレビュアーに割り当てられていません"
+// @Failure      404 {object} models.ErrorResponse "記事が見つかりません"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/articles/{slug}/reviews/comments [post]
+func (rc *ReviewController) AddComment(c echo.Context) error {
+	return rc.reviewAction(c, rc.service.AddComment, "コメントの追加に失敗しました")
+}
+
+// reviewAction はコメントを伴うレビュー操作の共通処理です
+func (rc *ReviewController) reviewAction(c echo.Context, action func(ctx context.Context, slug string, userID int, comment string) (*models.ReviewStatusResponse, error), message string) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "認証されていません",
+		})
+	}
+
+	req := models.ReviewCommentRequest{}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "リクエストの形式が不正です",
+			Message: err.Error(),
+		})
+	}
+
+	response, err := action(c.Request().Context(), c.Param("slug"), userID, req.Comment)
+	if err != nil {
+		return articleErrorResponse(c, err, message)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,176 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yamada-mikiya/team1-hackathon/linkpreview"
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"github.com/yamada-mikiya/team1-hackathon/repositories"
+	"github.com/yamada-mikiya/team1-hackathon/services"
+	"gorm.io/gorm"
+)
+
+type RevisionController struct {
+	service services.RevisionService
+}
+
+func NewRevisionController(db *gorm.DB, linkPreview linkpreview.Fetcher) *RevisionController {
+	articleRepo := repositories.NewArticleRepository(db)
+	tagRepo := repositories.NewTagRepository(db)
+	reviewRepo := repositories.NewReviewRepository(db)
+	userRepo := repositories.NewUserRepository(db)
+	revisionRepo := repositories.NewRevisionRepository(db)
+	articleService := services.NewArticleService(articleRepo, tagRepo, reviewRepo, userRepo, linkPreview)
+	return &RevisionController{
+		service: services.NewRevisionService(articleRepo, userRepo, revisionRepo, articleService),
+	}
+}
+
+// GetRevisions はリビジョン一覧を取得します
+// @Summary      リビジョン一覧を取得
+// @Description  記事の保存履歴を新しい順に取得します。本文は含まれません。記事の著者・記事の部署の編集者(editor)・管理者(admin)のみ実行できます。
+// @Tags         リビジョン (Revisions)
+// @Produce      json
+// @Security     Bearer
+// @Param        slug path string true "記事のスラグ" example("go-api-development")
+// @Success      200 {array} models.RevisionResponse "リビジョン一覧"
+// @Failure      401 {object} models.ErrorResponse "認証されていません"
+// @Failure      403 {object} models.ErrorResponse "この記事を変更する権限がありません"
+// @Failure      404 {object} models.ErrorResponse "記事が見つかりません"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/articles/{slug}/revisions [get]
+func (rc *RevisionController) GetRevisions(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "認証されていません",
+		})
+	}
+
+	response, err := rc.service.GetRevisions(c.Param("slug"), userID)
+	if err != nil {
+		return articleErrorResponse(c, err, "リビジョンの取得に失敗しました")
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// GetRevision はリビジョンを取得します
+// @Summary      リビジョンを取得
+// @Description  指定した番号のリビジョンを本文付きで取得します。記事の著者・記事の部署の編集者(editor)・管理者(admin)のみ実行できます。
+// @Tags         リビジョン (Revisions)
+// @Produce      json
+// @Security     Bearer
+// @Param        slug path string true "記事のスラグ" example("go-api-development")
+// @Param        number path int true "リビジョン番号"
+// @Success      200 {object} models.RevisionResponse "リビジョン"
+// @Failure      400 {object} models.ErrorResponse "リビジョン番号が不正です"
+// @Failure      401 {object} models.ErrorResponse "認証されていません"
+// @Failure      403 {object} models.ErrorResponse "この記事を変更する権限がありません"
+// @Failure      404 {object} models.ErrorResponse "記事またはリビジョンが見つかりません"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/articles/{slug}/revisions/{number} [get]
+func (rc *RevisionController) GetRevision(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "認証されていません",
+		})
+	}
+
+	number, err := strconv.Atoi(c.Param("number"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error: "リビジョン番号が不正です",
+		})
+	}
+
+	response, err := rc.service.GetRevision(c.Param("slug"), userID, number)
+	if err != nil {
+		return articleErrorResponse(c, err, "リビジョンの取得に失敗しました")
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// DiffRevisions は2つのリビジョンの差分を取得します
+// @Summary      リビジョン間の差分を取得
+// @Description  2つのリビジョンの本文を行単位で比較した差分を取得します。記事の著者・記事の部署の編集者(editor)・管理者(admin)のみ実行できます。
+// @Tags         リビジョン (Revisions)
+// @Produce      json
+// @Security     Bearer
+// @Param        slug path string true "記事のスラグ" example("go-api-development")
+// @Param        from query int true "比較元のリビジョン番号"
+// @Param        to query int true "比較先のリビジョン番号"
+// @Success      200 {object} models.RevisionDiffResponse "差分"
+// @Failure      400 {object} models.ErrorResponse "リビジョン番号が不正です"
+// @Failure      401 {object} models.ErrorResponse "認証されていません"
+// @Failure      403 {object} models.ErrorResponse "この記事を変更する権限がありません"
+// @Failure      404 {object} models.ErrorResponse "記事またはリビジョンが見つかりません"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/articles/{slug}/revisions/diff [get]
+func (rc *RevisionController) DiffRevisions(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "認証されていません",
+		})
+	}
+
+	from, fromErr := strconv.Atoi(c.QueryParam("from"))
+	to, toErr := strconv.Atoi(c.QueryParam("to"))
+	if fromErr != nil || toErr != nil {
+		return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error: "fromとtoにリビジョン番号を指定してください",
+		})
+	}
+
+	response, err := rc.service.DiffRevisions(c.Param("slug"), userID, from, to)
+	if err != nil {
+		return articleErrorResponse(c, err, "差分の取得に失敗しました")
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// RestoreRevision は過去のリビジョンを復元します
+// @Summary      リビジョンを復元
+// @Description  指定したリビジョンのタイトルと本文を記事に書き戻し、新しいリビジョンとして保存します。ステータスは現在の値を維持します。公開中の記事は、公開を取り下げてから復元してください。記事の著者・記事の部署の編集者(editor)・管理者(admin)のみ実行できます。
+// @Tags         リビジョン (Revisions)
+// @Produce      json
+// @Security     Bearer
+// @Param        slug path string true "記事のスラグ" example("go-api-development")
+// @Param        number path int true "復元するリビジョン番号"
+// @Success      200 {object} models.ArticleResponse "復元後の記事"
+// @Failure      400 {object} models.ErrorResponse "リビジョン番号が不正です"
+// @Failure      401 {object} models.ErrorResponse "認証されていません"
+// @Failure      403 {object} models.ErrorResponse "この記事を変更する権限がありません"
+// @Failure      404 {object} models.ErrorResponse "記事またはリビジョンが見つかりません"
+// @Failure      409 {object} models.ErrorResponse "公開にはレビューでの承認が必要です"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/articles/{slug}/revisions/{number}/restore [post]
+func (rc *RevisionController) RestoreRevision(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "認証されていません",
+		})
+	}
+
+	number, err := strconv.Atoi(c.Param("number"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error: "リビジョン番号が不正です",
+		})
+	}
+
+	response, err := rc.service.RestoreRevision(c.Request().Context(), c.Param("slug"), userID, number)
+	if err != nil {
+		return articleErrorResponse(c, err, "リビジョンの復元に失敗しました")
+	}
+	applyArticleIncludes(c, response)
+
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yamada-mikiya/team1-hackathon/config"
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"github.com/yamada-mikiya/team1-hackathon/repositories"
+	"github.com/yamada-mikiya/team1-hackathon/services"
+	"gorm.io/gorm"
+)
+
+// sitemapCacheControl はsitemap.xml・robots.txtのCache-Control
+const sitemapCacheControl = "public, max-age=3600"
+
+type SitemapController struct {
+	service services.SitemapService
+}
+
+func NewSitemapController(cfg *config.Config, db *gorm.DB) *SitemapController {
+	repo := repositories.NewArticleRepository(db)
+	return &SitemapController{
+		service: services.NewSitemapService(repo, cfg.Site.URL, cfg.Server.BaseURL(), cfg.Server.Robots.Disallow, cfg.Server.Robots.DisallowAll),
+	}
+}
+
+// GetSitemap はsitemap.xmlを返します
+// @Summary      サイトマップ
+// @Description  公開(public)記事のページURLと更新日時(lastmod)を返します。公開記事が50,000件を超える場合はサイトマップインデックスを返し、記事は /sitemaps/{n}.xml に分割されます。
+// @Tags         サイトマップ (Sitemap)
+// @Produce      xml
+// @Success      200 {string} string "サイトマップまたはサイトマップインデックス"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /sitemap.xml [get]
+func (sc *SitemapController) GetSitemap(c echo.Context) error {
+	body, err := sc.service.GetSitemap()
+	if err != nil {
+		return sitemapErrorResponse(c, err)
+	}
+	return sitemapXML(c, body)
+}
+
+// GetSitemapPage は分割したサイトマップを返します
+// @Summary      分割したサイトマップ
+// @Description  公開記事をID順に50,000件ずつ区切ったn番目（1始まり）のサイトマップを返します。
+// @Tags         サイトマップ (Sitemap)
+// @Produce      xml
+// @Param        file path string true "ページ番号.xml" example("1.xml")
+// @Success      200 {string} string "サイトマップ"
+// @Failure      404 {object} models.ErrorResponse "サイトマップが見つかりません"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /sitemaps/{file} [get]
+func (sc *SitemapController) GetSitemapPage(c echo.Context) error {
+	page, err := strconv.Atoi(strings.TrimSuffix(c.Param("file"), ".xml"))
+	if err != nil || !strings.HasSuffix(c.Param("file"), ".xml") {
+		return sitemapErrorResponse(c, services.ErrSitemapNotFound)
+	}
+
+	body, err := sc.service.GetSitemapPage(page)
+	if err != nil {
+		return sitemapErrorResponse(c, err)
+	}
+	return sitemapXML(c, body)
+}
+
+// GetRobots はrobots.txtを返します
+// @Summary      robots.txt
+// @Description  クローラー向けのrobots.txtを返します。巡回を禁止するパスは設定（server.robots）で変更できます。
+// @Tags         サイトマップ (Sitemap)
+// @Produce      plain
+// @Success      200 {string} string "robots.txt"
+// @Router       /robots.txt [get]
+func (sc *SitemapController) GetRobots(c echo.Context) error {
+	c.Response().Header().Set(echo.HeaderCacheControl, sitemapCacheControl)
+	return c.String(http.StatusOK, sc.service.GetRobots())
+}
+
+func sitemapXML(c echo.Context, body []byte) error {
+	c.Response().Header().Set(echo.HeaderCacheControl, sitemapCacheControl)
+	return c.Blob(http.StatusOK, echo.MIMEApplicationXMLCharsetUTF8, body)
+}
+
+func sitemapErrorResponse(c echo.Context, err error) error {
+	if errors.Is(err, services.ErrSitemapNotFound) {
+		return c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		Error:   "サイトマップの生成に失敗しました",
+		Message: err.Error(),
+	})
+}
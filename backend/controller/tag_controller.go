@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"github.com/yamada-mikiya/team1-hackathon/repositories"
+	"github.com/yamada-mikiya/team1-hackathon/services"
+	"gorm.io/gorm"
+)
+
+type TagController struct {
+	service        services.TagService
+	articleService services.ArticleService
+}
+
+func NewTagController(db *gorm.DB) *TagController {
+	tagRepo := repositories.NewTagRepository(db)
+	reviewRepo := repositories.NewReviewRepository(db)
+	userRepo := repositories.NewUserRepository(db)
+	articleRepo := repositories.NewArticleRepository(db)
+	return &TagController{
+		service:        services.NewTagService(tagRepo),
+		articleService: services.NewArticleService(articleRepo, tagRepo, reviewRepo, userRepo, nil),
+	}
+}
+
+// GetTags はタグ一覧を取得します
+// @Summary      タグ一覧を取得
+// @Description  カテゴリ以外のタグの一覧を、閲覧者が見られる記事数付きで取得します。ゲストの場合はpublic記事のみ、メールアドレスを確認済みのメンバーの場合はinternal記事も数えます。閲覧者が見られる記事が1件もないタグ（下書きにだけ付いているタグなど）は含みません。
+// @Tags         タグ (Tags)
+// @Produce      json
+// @Success      200 {array} models.TagResponse "タグ一覧"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/tags [get]
+func (tc *TagController) GetTags(c echo.Context) error {
+	return tc.respondTags(c, false)
+}
+
+// GetCategories はカテゴリ一覧を取得します
+// @Summary      カテゴリ一覧を取得
+// @Description  カテゴリ（is_categoryがtrueのタグ）の一覧を、閲覧者が見られる記事数付きで取得します。閲覧者が見られる記事が1件もないカテゴリは含みません。
+// @Tags         タグ (Tags)
+// @Produce      json
+// @Success      200 {array} models.TagResponse "カテゴリ一覧"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/categories [get]
+func (tc *TagController) GetCategories(c echo.Context) error {
+	return tc.respondTags(c, true)
+}
+
+func (tc *TagController) respondTags(c echo.Context, isCategory bool) error {
+	viewerID, _ := currentUserID(c)
+
+	tags, err := tc.service.GetTags(isCategory, viewerID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "タグの取得に失敗しました",
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, tags)
+}
+
+// GetArticlesByTag はタグが付いた記事の一覧を取得します
+// @Summary      タグ別の記事一覧を取得
+// @Description  指定されたタグ（カテゴリ）が付いた記事の一覧を取得します。閲覧ルールとページネーションは記事一覧と同じです。
+// @Tags         タグ (Tags)
+// @Produce      json
+// @Param        name path string true "タグ名" example("Go")
+// @Param        pagination query string false "ページネーションの方式 (デフォルト: page)。cursorの場合はnext_cursor・prev_cursorでページを移動します" Enums(page, cursor)
+// @Param        cursor query string false "前回のレスポンスのnext_cursorまたはprev_cursor。指定した場合はカーソル方式になります"
+// @Param        include_total query bool false "カーソル方式の場合に総件数(total_count)も返すか (デフォルト: false)"
+// @Param        page query int false "ページ番号 (ページ番号方式の場合。デフォルト: 1)。総件数も返します" default(1)
+// @Param        limit query int false "1ページあたりの件数 (デフォルト: 10, 最大: 100)" default(10)
+// @Param        department query string false "部署でフィルタ (Dev, MKT, Ops。カンマ区切りで複数指定可)" example("Dev,MKT")
+// @Param        article_type query string false "記事の種類でフィルタ (markdown, external。カンマ区切りで複数指定可)"
+// @Param        status query string false "ステータスでフィルタ (internal, public, all)。ゲストの場合は常にpublicのみ" Enums(internal, public, all)
+// @Param        author_id query int false "著者のユーザーIDでフィルタ"
+// @Param        created_after query string false "この日時以降に作成された記事 (RFC 3339または日付。日付のみの場合はUTCの0時)" example("2026-01-01T00:00:00+09:00")
+// @Param        created_before query string false "この日時より前に作成された記事 (RFC 3339または日付。日付のみの場合はUTCの0時)" example("2026-02-01")
+// @Param        sort query string false "並び順 (デフォルト: created_at。qを指定した場合は省略すると関連度順)" Enums(created_at, updated_at, title, popularity)
+// @Param        order query string false "並びの向き (デフォルト: titleはasc、それ以外はdesc。sortと一緒に指定)" Enums(asc, desc)
+// @Param        include query string false "追加で返す項目 (content_html: サニタイズ済みの本文HTML)" Enums(content_html)
+// @Success      200 {object} models.ArticleListResponse "記事一覧"
+// @Failure      400 {object} models.ErrorResponse "リクエストパラメータが不正です"
+// @Failure      404 {object} models.ErrorResponse "タグが見つかりません"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/tags/{name}/articles [get]
+func (tc *TagController) GetArticlesByTag(c echo.Context) error {
+	tag, err := tc.service.GetTagByName(c.Param("name"))
+	if err != nil {
+		if errors.Is(err, services.ErrTagNotFound) {
+			return c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error: err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "タグの取得に失敗しました",
+			Message: err.Error(),
+		})
+	}
+
+	filters, err := articleFiltersFromQuery(c)
+	if err != nil {
+		return articleErrorResponse(c, err, "記事の取得に失敗しました")
+	}
+	filters.Tag = tag.Name
+
+	response, err := listArticles(c, tc.articleService, filters)
+	if err != nil {
+		return articleErrorResponse(c, err, "記事の取得に失敗しました")
+	}
+	applyArticleListIncludes(c, response)
+
+	return c.JSON(http.StatusOK, response)
+}
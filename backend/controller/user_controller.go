@@ -0,0 +1,249 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"github.com/yamada-mikiya/team1-hackathon/repositories"
+	"github.com/yamada-mikiya/team1-hackathon/services"
+	"github.com/yamada-mikiya/team1-hackathon/storage"
+	"gorm.io/gorm"
+)
+
+type UserController struct {
+	service services.UserService
+}
+
+func NewUserController(db *gorm.DB, store storage.BlobStore) *UserController {
+	userRepo := repositories.NewUserRepository(db)
+	articleRepo := repositories.NewArticleRepository(db)
+	tagRepo := repositories.NewTagRepository(db)
+	reviewRepo := repositories.NewReviewRepository(db)
+	articleService := services.NewArticleService(articleRepo, tagRepo, reviewRepo, userRepo, nil)
+	return &UserController{
+		service: services.NewUserService(userRepo, store, articleService),
+	}
+}
+
+// GetUsers はユーザー一覧を取得します
+// @Summary      ユーザー一覧を取得
+// @Description  ユーザーの一覧をロール・所属部署付きでID順に取得します。管理者(admin)のみ実行できます。
+// @Tags         ユーザー (Users)
+// @Produce      json
+// @Security     Bearer
+// @Param        page query int false "ページ番号" default(1) minimum(1)
+// @Param        limit query int false "1ページあたりの件数" default(10) minimum(1) maximum(100)
+// @Success      200 {object} models.UserListResponse "ユーザー一覧"
+// @Failure      401 {object} models.ErrorResponse "認証されていません"
+// @Failure      403 {object} models.ErrorResponse "この操作を行う権限がありません"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/users [get]
+func (uc *UserController) GetUsers(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "認証されていません",
+		})
+	}
+
+	page, limit := paginationParams(c)
+
+	response, err := uc.service.GetUsers(c.Request().Context(), userID, page, limit)
+	if err != nil {
+		return userErrorResponse(c, err, "ユーザー一覧の取得に失敗しました")
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// UpdateUserRole はユーザーのロールと所属部署を変更します
+// @Summary      ユーザーのロールを変更
+// @Description  ユーザーのロール（admin, editor, writer, reader）と所属部署を変更します。管理者(admin)のみ実行でき、自分自身のロールは変更できません。変更は次回ログイン時に発行されるトークンに反映されます。
+// @Tags         ユーザー (Users)
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        id path int true "ユーザーID" example(2)
+// @Param        payload body models.UpdateUserRoleRequest true "変更するロール・所属部署"
+// @Success      200 {object} models.UserResponse "変更後のユーザー情報"
+// @Failure      400 {object} models.ErrorResponse "リクエストボディが不正です"
+// @Failure      401 {object} models.ErrorResponse "認証されていません"
+// @Failure      403 {object} models.ErrorResponse "この操作を行う権限がありません"
+// @Failure      404 {object} models.ErrorResponse "ユーザーが見つかりません"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/users/{id}/role [patch]
+func (uc *UserController) UpdateUserRole(c echo.Context) error {
+	actorID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "認証されていません",
+		})
+	}
+
+	targetID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error: "ユーザーIDが不正です",
+		})
+	}
+
+	req := models.UpdateUserRoleRequest{}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "リクエストの形式が不正です",
+			Message: err.Error(),
+		})
+	}
+
+	response, err := uc.service.UpdateUserRole(c.Request().Context(), actorID, targetID, req)
+	if err != nil {
+		return userErrorResponse(c, err, "ユーザーのロール変更に失敗しました")
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// GetUserProfile はユーザーの公開プロフィールを取得します
+// @Summary      ユーザーの公開プロフィールを取得
+// @Description  ユーザーの名前・所属・アイコンと、記事数・最近の記事（5件）を取得します。記事は記事一覧と同じルールで絞り込まれ、ゲストの場合はpublicの記事のみが対象です。このユーザーの記事をさらに見る場合は GET /api/articles?author_id={id} を使います。
+// @Tags         ユーザー (Users)
+// @Produce      json
+// @Param        id path int true "ユーザーID" example(1)
+// @Success      200 {object} models.UserProfileResponse "公開プロフィール"
+// @Failure      400 {object} models.ErrorResponse "ユーザーIDが不正です"
+// @Failure      404 {object} models.ErrorResponse "ユーザーが見つかりません"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/users/{id} [get]
+func (uc *UserController) GetUserProfile(c echo.Context) error {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error: "ユーザーIDが不正です",
+		})
+	}
+	viewerID, _ := currentUserID(c)
+
+	response, err := uc.service.GetUserProfile(c.Request().Context(), userID, viewerID)
+	if err != nil {
+		return userErrorResponse(c, err, "プロフィールの取得に失敗しました")
+	}
+	for i := range response.RecentArticles {
+		applyArticleIncludes(c, &response.RecentArticles[i])
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// UpdateMe はログイン中のユーザーのプロフィールを更新します
+// @Summary      自分のプロフィールを更新
+// @Description  ログイン中のユーザーの名前と所属を更新します。省略した項目は変更せず、affiliationに空文字を指定すると所属を削除します。
+// @Tags         ユーザー (Users)
+// @Accept       json
+// @Produce      json
+// @Security     Bearer
+// @Param        payload body models.UpdateProfileRequest true "変更する名前・所属"
+// @Success      200 {object} models.UserResponse "変更後のユーザー情報"
+// @Failure      400 {object} models.ErrorResponse "リクエストボディが不正です"
+// @Failure      401 {object} models.ErrorResponse "認証されていません"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/users/me [patch]
+func (uc *UserController) UpdateMe(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "認証されていません",
+		})
+	}
+
+	req := models.UpdateProfileRequest{}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "リクエストの形式が不正です",
+			Message: err.Error(),
+		})
+	}
+
+	response, err := uc.service.UpdateProfile(c.Request().Context(), userID, req)
+	if err != nil {
+		return userErrorResponse(c, err, "プロフィールの更新に失敗しました")
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// UploadMyIcon はログイン中のユーザーのアイコン画像をアップロードします
+// @Summary      自分のアイコン画像をアップロード
+// @Description  画像（JPEG, PNG, GIF, WebP、5MBまで）をアップロードし、中央を正方形に切り抜いた256×256のサムネイルをアイコンとして設定します。
+// @Tags         ユーザー (Users)
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     Bearer
+// @Param        icon formData file true "アイコン画像"
+// @Success      200 {object} models.UserResponse "変更後のユーザー情報"
+// @Failure      400 {object} models.ErrorResponse "画像が指定されていないか、形式・サイズが不正です"
+// @Failure      401 {object} models.ErrorResponse "認証されていません"
+// @Failure      500 {object} models.ErrorResponse "サーバー内部でエラーが発生しました"
+// @Router       /api/users/me/icon [post]
+func (uc *UserController) UploadMyIcon(c echo.Context) error {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, models.ErrorResponse{
+			Error: "認証されていません",
+		})
+	}
+
+	fileHeader, err := c.FormFile("icon")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error:   "iconに画像ファイルを指定してください",
+			Message: err.Error(),
+		})
+	}
+	if fileHeader.Size > services.MaxIconSize {
+		return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error: "画像のサイズが大きすぎます",
+		})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+			Error:   "画像の読み込みに失敗しました",
+			Message: err.Error(),
+		})
+	}
+	defer file.Close()
+
+	response, err := uc.service.UploadIcon(c.Request().Context(), userID, file)
+	if err != nil {
+		return userErrorResponse(c, err, "アイコン画像のアップロードに失敗しました")
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// userErrorResponse はユーザー関連のサービス層のエラーをHTTPレスポンスに変換します
+func userErrorResponse(c echo.Context, err error, message string) error {
+	var validationErr *services.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		return c.JSON(http.StatusBadRequest, models.ErrorResponse{
+			Error: validationErr.Message,
+		})
+	case errors.Is(err, services.ErrUserNotFound):
+		return c.JSON(http.StatusNotFound, models.ErrorResponse{
+			Error: err.Error(),
+		})
+	case errors.Is(err, services.ErrPermissionDenied):
+		return c.JSON(http.StatusForbidden, models.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, models.ErrorResponse{
+		Error:   message,
+		Message: err.Error(),
+	})
+}
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_articles_content_trgm;
+DROP INDEX IF EXISTS idx_articles_title_trgm;
@@ -0,0 +1,6 @@
+-- 日本語を含む部分一致検索のためにpg_trgmを有効化
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- titleとcontentにトライグラムのGINインデックスを作成（ILIKE '%...%' 検索の高速化）
+CREATE INDEX IF NOT EXISTS idx_articles_title_trgm ON articles USING gin (title gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_articles_content_trgm ON articles USING gin (content gin_trgm_ops);
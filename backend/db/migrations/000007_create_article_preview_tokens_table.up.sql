@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS article_preview_tokens (
+    id VARCHAR(64) PRIMARY KEY NOT NULL,
+    article_id INTEGER NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    created_by INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- article_idにインデックスを作成（記事ごとのプレビューリンク一覧取得の高速化）
+CREATE INDEX idx_article_preview_tokens_article_id ON article_preview_tokens(article_id);
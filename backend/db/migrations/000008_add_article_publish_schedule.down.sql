@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_articles_publish_at_scheduled;
+
+ALTER TABLE articles
+    DROP COLUMN IF EXISTS scheduled_status,
+    DROP COLUMN IF EXISTS publish_at;
@@ -0,0 +1,7 @@
+-- 予約公開: publish_atになったらstatusをscheduled_statusに切り替える
+ALTER TABLE articles
+    ADD COLUMN publish_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN scheduled_status VARCHAR(50) CHECK (scheduled_status IN ('internal', 'public'));
+
+-- 予約中の記事のみを対象にした部分インデックス（公開ワーカーの検索の高速化）
+CREATE INDEX idx_articles_publish_at_scheduled ON articles(publish_at) WHERE scheduled_status IS NOT NULL;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS article_revisions (
+    id SERIAL PRIMARY KEY NOT NULL,
+    article_id INTEGER NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    revision_number INTEGER NOT NULL,
+    -- 保存したユーザー。予約公開ワーカーによる変更などシステムによる保存はNULL
+    editor_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    title VARCHAR(255) NOT NULL,
+    content TEXT,
+    status VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (article_id, revision_number)
+);
+
+-- editor_idにインデックスを作成（ユーザーの編集履歴検索の高速化）
+CREATE INDEX idx_article_revisions_editor_id ON article_revisions(editor_id);
+
+-- 既存の記事は現在の内容を最初のリビジョンとして登録
+INSERT INTO article_revisions (article_id, revision_number, editor_id, title, content, status, created_at)
+SELECT id, 1, author_id, title, content, status, updated_at FROM articles;
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS article_review_comments CASCADE;
+DROP TABLE IF EXISTS article_reviews CASCADE;
+
+ALTER TABLE users DROP COLUMN IF EXISTS department;
+
+UPDATE articles SET status = 'draft' WHERE status = 'in_review';
+ALTER TABLE articles DROP CONSTRAINT IF EXISTS articles_status_check;
+ALTER TABLE articles ADD CONSTRAINT articles_status_check CHECK (status IN ('draft', 'internal', 'public'));
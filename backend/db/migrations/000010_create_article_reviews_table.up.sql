@@ -0,0 +1,38 @@
+-- レビュー待ちのステータスを追加
+ALTER TABLE articles DROP CONSTRAINT IF EXISTS articles_status_check;
+ALTER TABLE articles ADD CONSTRAINT articles_status_check CHECK (status IN ('draft', 'in_review', 'internal', 'public'));
+
+-- ユーザーの所属部署（公開前レビューの承認者の判定に使用）
+ALTER TABLE users ADD COLUMN department VARCHAR(50) CHECK (department IN ('Dev', 'MKT', 'Ops'));
+
+CREATE TABLE IF NOT EXISTS article_reviews (
+    id SERIAL PRIMARY KEY NOT NULL,
+    article_id INTEGER NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    reviewer_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    state VARCHAR(50) NOT NULL CHECK (state IN ('pending', 'approved', 'changes_requested')) DEFAULT 'pending',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (article_id, reviewer_id)
+);
+
+-- reviewer_idにインデックスを作成（レビュアーの担当記事検索の高速化）
+CREATE INDEX idx_article_reviews_reviewer_id ON article_reviews(reviewer_id);
+
+-- updated_atの自動更新トリガーを設定
+CREATE TRIGGER update_article_reviews_updated_at
+BEFORE UPDATE ON article_reviews
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE IF NOT EXISTS article_review_comments (
+    id SERIAL PRIMARY KEY NOT NULL,
+    article_id INTEGER NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    -- コメントと同時に行ったレビュー操作（approved / changes_requested）。通常のコメントはNULL
+    action VARCHAR(50) CHECK (action IN ('approved', 'changes_requested')),
+    body TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- article_idにインデックスを作成（記事ごとのコメント取得の高速化）
+CREATE INDEX idx_article_review_comments_article_id ON article_review_comments(article_id);
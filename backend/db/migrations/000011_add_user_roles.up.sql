@@ -0,0 +1,6 @@
+-- ユーザーのロール（admin: 全体管理、editor: 所属部署の記事を管理、writer: 自分の記事を執筆、reader: 閲覧のみ）
+-- 既存ユーザーはこれまで通り記事を書けるようwriterにする
+ALTER TABLE users ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'writer' CHECK (role IN ('admin', 'editor', 'writer', 'reader'));
+
+-- roleにインデックスを作成（ロール別のユーザー一覧取得の高速化）
+CREATE INDEX idx_users_role ON users(role);
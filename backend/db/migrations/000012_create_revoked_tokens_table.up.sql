@@ -0,0 +1,10 @@
+-- ログアウト等で失効させたJWTのjti。トークンの有効期限を過ぎた行はワーカーが定期的に削除する
+CREATE TABLE IF NOT EXISTS revoked_tokens (
+    jti VARCHAR(64) PRIMARY KEY NOT NULL,
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- expires_atにインデックスを作成（期限切れの行の削除の高速化）
+CREATE INDEX idx_revoked_tokens_expires_at ON revoked_tokens(expires_at);
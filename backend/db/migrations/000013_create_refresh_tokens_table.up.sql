@@ -0,0 +1,18 @@
+-- リフレッシュトークン。トークン本体は保存せずSHA-256ハッシュのみを保存する
+-- 同じログインから発行されたトークンはfamily_idが同じで、使用済みトークンが再利用された場合はファミリーごと失効させる
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id SERIAL PRIMARY KEY NOT NULL,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    family_id VARCHAR(64) NOT NULL,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- family_idにインデックスを作成（ファミリー単位の失効の高速化）
+CREATE INDEX idx_refresh_tokens_family_id ON refresh_tokens(family_id);
+
+-- expires_atにインデックスを作成（期限切れの行の削除の高速化）
+CREATE INDEX idx_refresh_tokens_expires_at ON refresh_tokens(expires_at);
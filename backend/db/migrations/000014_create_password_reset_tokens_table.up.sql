@@ -0,0 +1,15 @@
+-- パスワードリセットトークン。トークン本体は保存せずSHA-256ハッシュのみを保存する
+CREATE TABLE IF NOT EXISTS password_reset_tokens (
+    id SERIAL PRIMARY KEY NOT NULL,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- user_idにインデックスを作成（ユーザーの未使用トークンの無効化の高速化）
+CREATE INDEX idx_password_reset_tokens_user_id ON password_reset_tokens(user_id);
+
+-- expires_atにインデックスを作成（期限切れの行の削除の高速化）
+CREATE INDEX idx_password_reset_tokens_expires_at ON password_reset_tokens(expires_at);
@@ -0,0 +1,6 @@
+-- メールアドレスの確認日時。NULLのユーザーはメンバーではなくゲストとして扱う
+ALTER TABLE users ADD COLUMN email_verified_at TIMESTAMP WITH TIME ZONE;
+
+-- 確認の仕組みを導入する前に登録した既存ユーザーは確認済みとみなす
+-- 許可するドメイン（auth.allowedEmailDomains）以外のユーザーは、APIの起動時に未確認に戻す
+UPDATE users SET email_verified_at = created_at;
@@ -0,0 +1,13 @@
+DROP TRIGGER IF EXISTS update_articles_updated_at ON articles;
+CREATE TRIGGER update_articles_updated_at
+BEFORE UPDATE ON articles
+FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+DROP FUNCTION IF EXISTS update_articles_updated_at_column();
+
+ALTER TABLE articles
+    DROP COLUMN IF EXISTS content_html,
+    DROP COLUMN IF EXISTS toc,
+    DROP COLUMN IF EXISTS reading_time_minutes,
+    DROP COLUMN IF EXISTS excerpt;
@@ -0,0 +1,28 @@
+-- Markdown記事の本文から保存時に作成するキャッシュ（サニタイズ済みHTML・目次・読了時間・抜粋）
+-- 既存の記事はNULLのままにしておき、起動時のワーカーが作成する
+ALTER TABLE articles
+    ADD COLUMN content_html TEXT,
+    ADD COLUMN toc JSONB,
+    ADD COLUMN reading_time_minutes INTEGER,
+    ADD COLUMN excerpt TEXT;
+
+-- 記事の内容ではない列だけを更新した場合は、記事の更新日時を変えないようにする
+-- 対象の列はトリガーの引数で指定し、それ以外の列が変わっていない場合はupdated_atを更新しない
+-- 本文から作成するキャッシュは、起動時のワーカーが既存の記事に作成しても更新扱いにならないよう対象にする
+CREATE OR REPLACE FUNCTION update_articles_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+   -- updated_atが明示的に変更されておらず、対象外の列が変わっている場合のみ、NOW()を設定
+   IF NEW.updated_at = OLD.updated_at
+      AND (to_jsonb(NEW) - TG_ARGV) IS DISTINCT FROM (to_jsonb(OLD) - TG_ARGV) THEN
+      NEW.updated_at = NOW();
+   END IF;
+   RETURN NEW;
+END;
+$$ LANGUAGE 'plpgsql';
+
+DROP TRIGGER IF EXISTS update_articles_updated_at ON articles;
+CREATE TRIGGER update_articles_updated_at
+BEFORE UPDATE ON articles
+FOR EACH ROW
+EXECUTE FUNCTION update_articles_updated_at_column('content_html', 'toc', 'reading_time_minutes', 'excerpt');
@@ -0,0 +1,2 @@
+-- 記事一覧のキーセットページネーション（created_at, idの新着順）用のインデックス
+CREATE INDEX idx_articles_created_at_id ON articles(created_at DESC, id DESC);
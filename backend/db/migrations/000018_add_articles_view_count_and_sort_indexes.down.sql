@@ -0,0 +1,14 @@
+DROP TRIGGER IF EXISTS update_articles_updated_at ON articles;
+CREATE TRIGGER update_articles_updated_at
+BEFORE UPDATE ON articles
+FOR EACH ROW
+EXECUTE FUNCTION update_articles_updated_at_column(
+    'content_html', 'toc', 'reading_time_minutes', 'excerpt'
+);
+
+DROP INDEX IF EXISTS idx_articles_article_type;
+DROP INDEX IF EXISTS idx_articles_view_count_id;
+DROP INDEX IF EXISTS idx_articles_title_id;
+DROP INDEX IF EXISTS idx_articles_updated_at_id;
+
+ALTER TABLE articles DROP COLUMN IF EXISTS view_count;
@@ -0,0 +1,17 @@
+-- 記事の閲覧数（人気順の並べ替えに使う）
+ALTER TABLE articles ADD COLUMN view_count BIGINT NOT NULL DEFAULT 0;
+
+-- 記事一覧の並べ替え（キーセットページネーションを含む）用のインデックス
+CREATE INDEX idx_articles_updated_at_id ON articles(updated_at DESC, id DESC);
+CREATE INDEX idx_articles_title_id ON articles(title, id);
+CREATE INDEX idx_articles_view_count_id ON articles(view_count DESC, id DESC);
+CREATE INDEX idx_articles_article_type ON articles(article_type);
+
+-- 閲覧数だけを更新した場合も、記事の更新日時を変えないようにする
+DROP TRIGGER IF EXISTS update_articles_updated_at ON articles;
+CREATE TRIGGER update_articles_updated_at
+BEFORE UPDATE ON articles
+FOR EACH ROW
+EXECUTE FUNCTION update_articles_updated_at_column(
+    'content_html', 'toc', 'reading_time_minutes', 'excerpt', 'view_count'
+);
@@ -0,0 +1,11 @@
+-- 記事の1時間ごとの閲覧数（トレンドの集計に使う）。累計はarticles.view_countに加算する
+-- 集計期間を過ぎた行は閲覧数ワーカーが削除する
+CREATE TABLE IF NOT EXISTS article_hourly_views (
+    article_id INTEGER NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    hour_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    views BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (article_id, hour_start)
+);
+
+-- hour_startにインデックスを作成（集計期間での絞り込みと古い行の削除の高速化）
+CREATE INDEX idx_article_hourly_views_hour_start ON article_hourly_views(hour_start);
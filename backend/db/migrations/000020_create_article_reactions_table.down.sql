@@ -0,0 +1,11 @@
+DROP TRIGGER IF EXISTS update_articles_updated_at ON articles;
+CREATE TRIGGER update_articles_updated_at
+BEFORE UPDATE ON articles
+FOR EACH ROW
+EXECUTE FUNCTION update_articles_updated_at_column(
+    'content_html', 'toc', 'reading_time_minutes', 'excerpt', 'view_count'
+);
+
+ALTER TABLE articles DROP COLUMN IF EXISTS reaction_counts;
+
+DROP TABLE IF EXISTS article_reactions;
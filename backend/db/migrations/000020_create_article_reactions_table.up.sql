@@ -0,0 +1,23 @@
+-- 記事へのリアクション。ユーザー・記事・絵文字の組み合わせごとに1つ
+CREATE TABLE IF NOT EXISTS article_reactions (
+    article_id INTEGER NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    emoji VARCHAR(32) NOT NULL CHECK (emoji IN ('thumbs_up', 'heart', 'tada', 'laugh', 'bulb', 'eyes')),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (article_id, user_id, emoji)
+);
+
+-- user_idにインデックスを作成（閲覧者自身のリアクションの取得の高速化）
+CREATE INDEX idx_article_reactions_user_id ON article_reactions(user_id, article_id);
+
+-- 絵文字ごとのリアクション数（記事一覧でリアクションを数えないよう、リアクションのたびに集計し直して保存する）
+ALTER TABLE articles ADD COLUMN reaction_counts JSONB NOT NULL DEFAULT '{}';
+
+-- リアクション数（reaction_counts）だけを更新した場合も、記事の更新日時を変えないようにする
+DROP TRIGGER IF EXISTS update_articles_updated_at ON articles;
+CREATE TRIGGER update_articles_updated_at
+BEFORE UPDATE ON articles
+FOR EACH ROW
+EXECUTE FUNCTION update_articles_updated_at_column(
+    'content_html', 'toc', 'reading_time_minutes', 'excerpt', 'view_count', 'reaction_counts'
+);
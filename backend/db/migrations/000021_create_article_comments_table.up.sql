@@ -0,0 +1,23 @@
+-- 記事へのコメント。parent_idがあるものは返信（返信への返信はできない）
+-- 本文はMarkdownで、保存時にサニタイズ済みのHTMLを作成してbody_htmlにキャッシュする
+-- 著者による削除はdeleted_at、記事の著者・管理者による非表示はhidden_atを設定する（行は残してスレッドを保つ）
+CREATE TABLE IF NOT EXISTS article_comments (
+    id SERIAL PRIMARY KEY NOT NULL,
+    article_id INTEGER NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    parent_id INTEGER REFERENCES article_comments(id) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    body_html TEXT NOT NULL,
+    edited_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    hidden_at TIMESTAMP WITH TIME ZONE,
+    hidden_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- 記事ごとのコメント（返信でないもの）を作成日時の順に取得するためのインデックス
+CREATE INDEX idx_article_comments_article_id_created_at ON article_comments(article_id, created_at, id) WHERE parent_id IS NULL;
+
+-- 返信を取得するためのインデックス
+CREATE INDEX idx_article_comments_parent_id_created_at ON article_comments(parent_id, created_at, id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_articles_bigrams;
+DROP FUNCTION IF EXISTS article_bigrams(TEXT);
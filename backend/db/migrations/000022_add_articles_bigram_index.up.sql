@@ -0,0 +1,16 @@
+-- pg_trgmのインデックスは3文字以上のキーワードにしか使えないため、2文字のキーワード（「検索」など）用にバイグラムのインデックスを作成する
+-- article_bigramsは小文字にした文字列の、空白を含まない連続する2文字の集合を返す
+-- 長い本文でも文字数に比例する時間で済むよう、文字に分割してから隣の文字とつなげる
+CREATE OR REPLACE FUNCTION article_bigrams(doc TEXT)
+RETURNS TEXT[] AS $$
+    SELECT COALESCE(array_agg(DISTINCT bigram), '{}')
+    FROM (
+        SELECT ch || lead(ch) OVER (ORDER BY n) AS bigram
+        FROM regexp_split_to_table(lower(doc), '') WITH ORDINALITY AS chars(ch, n)
+    ) AS bigrams
+    WHERE bigram !~ '[[:space:]]'
+$$ LANGUAGE sql IMMUTABLE STRICT PARALLEL SAFE;
+
+-- タイトルと本文のどちらかに含まれる記事を検索できるよう、空白でつないだ文字列に作成する
+-- 検索ではインデックスと同じ式（article_bigrams(title || ' ' || COALESCE(content, ''))）を使う
+CREATE INDEX IF NOT EXISTS idx_articles_bigrams ON articles USING gin (article_bigrams(title || ' ' || COALESCE(content, '')));
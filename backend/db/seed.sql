@@ -4,11 +4,11 @@
 TRUNCATE TABLE article_tags, articles, tags, users RESTART IDENTITY CASCADE;
 
 -- テストユーザーの挿入
-INSERT INTO users (id, name, email, affiliation, password_hash, icon_url) VALUES
-(1, '田中 太郎', 'tanaka@example.com', 'Dev部門', '$2a$10$dummyhash1111111111111111111111111111111111111111', 'https://i.pravatar.cc/150?img=1'),
-(2, '佐藤 花子', 'sato@example.com', 'MKT部門', '$2a$10$dummyhash2222222222222222222222222222222222222222', 'https://i.pravatar.cc/150?img=2'),
-(3, '鈴木 一郎', 'suzuki@example.com', 'Ops部門', '$2a$10$dummyhash3333333333333333333333333333333333333333', 'https://i.pravatar.cc/150?img=3'),
-(4, '高橋 美咲', 'takahashi@example.com', 'Dev部門', '$2a$10$dummyhash4444444444444444444444444444444444444444', 'https://i.pravatar.cc/150?img=4');
+INSERT INTO users (id, name, email, affiliation, department, role, password_hash, icon_url, email_verified_at) VALUES
+(1, '田中 太郎', 'tanaka@example.com', 'Dev部門', 'Dev', 'admin', '$2a$10$dummyhash1111111111111111111111111111111111111111', 'https://i.pravatar.cc/150?img=1', NOW()),
+(2, '佐藤 花子', 'sato@example.com', 'MKT部門', 'MKT', 'editor', '$2a$10$dummyhash2222222222222222222222222222222222222222', 'https://i.pravatar.cc/150?img=2', NOW()),
+(3, '鈴木 一郎', 'suzuki@example.com', 'Ops部門', 'Ops', 'writer', '$2a$10$dummyhash3333333333333333333333333333333333333333', 'https://i.pravatar.cc/150?img=3', NOW()),
+(4, '高橋 美咲', 'takahashi@example.com', 'Dev部門', 'Dev', 'writer', '$2a$10$dummyhash4444444444444444444444444444444444444444', 'https://i.pravatar.cc/150?img=4', NOW());
 
 -- ユーザーIDシーケンスをリセット
 SELECT setval('users_id_seq', (SELECT MAX(id) FROM users));
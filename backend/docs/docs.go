@@ -17,7 +17,7 @@ const docTemplate = `{
     "paths": {
         "/api/articles": {
             "get": {
-                "description": "公開されているブログ記事の一覧を取得します。ログイン済みの場合は内部公開記事も含まれます。ページネーション、部署フィルタ、ステータスフィルタをサポートしています。",
+                "description": "公開されているブログ記事の一覧を取得します。メールアドレスを確認済みのメンバーの場合は内部公開記事も含まれます。ページ番号（またはpagination=cursorを指定した場合はカーソル）によるページネーション、部署・種類・ステータス・著者・作成日時での絞り込み、並べ替え、キーワード検索をサポートしています。不明な値を指定した場合は400を返します。",
                 "consumes": [
                     "application/json"
                 ],
@@ -29,10 +29,32 @@ const docTemplate = `{
                 ],
                 "summary": "記事一覧を取得",
                 "parameters": [
+                    {
+                        "enum": [
+                            "page",
+                            "cursor"
+                        ],
+                        "type": "string",
+                        "description": "ページネーションの方式 (デフォルト: page)。cursorの場合はnext_cursor・prev_cursorでページを移動します",
+                        "name": "pagination",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "前回のレスポンスのnext_cursorまたはprev_cursor。指定した場合はカーソル方式になります",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "カーソル方式の場合に総件数(total_count)も返すか (デフォルト: false)",
+                        "name": "include_total",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "default": 1,
-                        "description": "ページ番号 (デフォルト: 1)",
+                        "description": "ページ番号 (ページ番号方式の場合。デフォルト: 1)。総件数も返します",
                         "name": "page",
                         "in": "query"
                     },
@@ -44,16 +66,18 @@ const docTemplate = `{
                         "in": "query"
                     },
                     {
-                        "enum": [
-                            "Dev",
-                            "MKT",
-                            "Ops"
-                        ],
                         "type": "string",
-                        "description": "部署でフィルタ (Dev, MKT, Ops)",
+                        "example": "\"Dev,MKT\"",
+                        "description": "部署でフィルタ (Dev, MKT, Ops。カンマ区切りで複数指定可)",
                         "name": "department",
                         "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "description": "記事の種類でフィルタ (markdown, external。カンマ区切りで複数指定可)",
+                        "name": "article_type",
+                        "in": "query"
+                    },
                     {
                         "enum": [
                             "internal",
@@ -64,6 +88,69 @@ const docTemplate = `{
                         "description": "ステータスでフィルタ (internal, public, all)。ゲストの場合は常にpublicのみ",
                         "name": "status",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "タグ名でフィルタ",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "著者のユーザーIDでフィルタ",
+                        "name": "author_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2026-01-01T00:00:00+09:00\"",
+                        "description": "この日時以降に作成された記事 (RFC 3339または日付。日付のみの場合はUTCの0時)",
+                        "name": "created_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2026-02-01\"",
+                        "description": "この日時より前に作成された記事 (RFC 3339または日付。日付のみの場合はUTCの0時)",
+                        "name": "created_before",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at",
+                            "updated_at",
+                            "title",
+                            "popularity"
+                        ],
+                        "type": "string",
+                        "description": "並び順 (デフォルト: created_at。qを指定した場合は省略すると関連度順)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "並びの向き (デフォルト: titleはasc、それ以外はdesc。sortと一緒に指定)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "キーワード検索 (タイトル・本文、空白区切りでAND検索)。指定時は関連度順になり、snippetに一致箇所を\u003cmark\u003eで囲んだ抜粋が入ります",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "content_html"
+                        ],
+                        "type": "string",
+                        "description": "追加で返す項目 (content_html: サニタイズ済みの本文HTML)",
+                        "name": "include",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -86,11 +173,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/articles/{slug}": {
-            "get": {
-                "description": "指定されたslugのブログ記事の詳細を取得します。内部公開記事の場合はログインが必要です。",
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "ログインユーザーを著者として記事を作成します。markdown記事はcontent、external記事はexternal_urlが必須です。statusを省略した場合はdraftになります。",
                 "consumes": [
                     "application/json"
                 ],
@@ -100,32 +190,45 @@ const docTemplate = `{
                 "tags": [
                     "記事 (Articles)"
                 ],
-                "summary": "記事詳細を取得",
+                "summary": "記事を作成",
                 "parameters": [
                     {
-                        "type": "string",
-                        "example": "\"go-api-development\"",
-                        "description": "記事のスラグ",
-                        "name": "slug",
-                        "in": "path",
-                        "required": true
+                        "description": "記事の内容",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateArticleRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "記事詳細",
+                    "201": {
+                        "description": "作成された記事",
                         "schema": {
                             "$ref": "#/definitions/ArticleResponse"
                         }
                     },
+                    "400": {
+                        "description": "リクエストボディが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証されていません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
                     "403": {
-                        "description": "内部公開記事にアクセスするにはログインが必要です",
+                        "description": "記事を作成する権限がありません（readerロール・メールアドレス未確認）",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "記事が見つかりません",
+                    "409": {
+                        "description": "スラグが既に使用されています / 公開にはレビューでの承認が必要です",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -139,45 +242,73 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/auth/login": {
-            "post": {
-                "description": "既存のユーザーを認証し、新しい認証トークンを発行します。",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/articles/popular": {
+            "get": {
+                "description": "累計の閲覧数が多い順に記事を取得します（閲覧されていない記事は含みません）。閲覧ルールは記事一覧と同じで、ゲストの場合はpublic記事のみです。閲覧数は記事詳細の取得で数え、同じ閲覧者の閲覧は一定時間内であれば1回と数えます。",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "認証 (Auth)"
+                    "記事 (Articles)"
                 ],
-                "summary": "ログイン (Log In)",
+                "summary": "人気記事を取得",
                 "parameters": [
                     {
-                        "description": "ユーザー情報 (メールアドレスとパスワード)",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/AuthenticateRequest"
-                        }
+                        "type": "integer",
+                        "default": 10,
+                        "description": "取得する件数 (デフォルト: 10, 最大: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"Dev,MKT\"",
+                        "description": "部署でフィルタ (Dev, MKT, Ops。カンマ区切りで複数指定可)",
+                        "name": "department",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "記事の種類でフィルタ (markdown, external。カンマ区切りで複数指定可)",
+                        "name": "article_type",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "internal",
+                            "public",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "ステータスでフィルタ (internal, public, all)。ゲストの場合は常にpublicのみ",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "タグ名でフィルタ",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "content_html"
+                        ],
+                        "type": "string",
+                        "description": "追加で返す項目 (content_html: サニタイズ済みの本文HTML)",
+                        "name": "include",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "認証成功。新しい認証トークンを返します。",
+                        "description": "人気記事（ページネーションなし）",
                         "schema": {
-                            "$ref": "#/definitions/AuthResponse"
+                            "$ref": "#/definitions/ArticleListResponse"
                         }
                     },
                     "400": {
-                        "description": "リクエストボディが不正です",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "認証に失敗しました (メールアドレスまたはパスワードが正しくありません)",
+                        "description": "リクエストパラメータが不正です",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -191,45 +322,73 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/auth/signup": {
-            "post": {
-                "description": "新しいユーザーアカウントを作成し、認証トークンとユーザー情報を返します。",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/articles/trending": {
+            "get": {
+                "description": "直近7日間の閲覧数から、新しい閲覧ほど重くなるよう（24時間ごとに重みが半分）計算したスコアの高い順に、部署ごとの記事を取得します。閲覧ルールは記事一覧と同じで、ゲストの場合はpublic記事のみです。",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "認証 (Auth)"
+                    "記事 (Articles)"
                 ],
-                "summary": "新規ユーザー登録 (Sign Up)",
+                "summary": "トレンド記事を取得",
                 "parameters": [
                     {
-                        "description": "ユーザー情報 (メールアドレス、パスワード、名前)",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/SignUpRequest"
-                        }
+                        "type": "integer",
+                        "default": 10,
+                        "description": "部署ごとに取得する件数 (デフォルト: 10, 最大: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"Dev,MKT\"",
+                        "description": "部署 (Dev, MKT, Ops。カンマ区切りで複数指定可)。省略した場合はすべての部署",
+                        "name": "department",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "記事の種類でフィルタ (markdown, external。カンマ区切りで複数指定可)",
+                        "name": "article_type",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "internal",
+                            "public",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "ステータスでフィルタ (internal, public, all)。ゲストの場合は常にpublicのみ",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "タグ名でフィルタ",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "content_html"
+                        ],
+                        "type": "string",
+                        "description": "追加で返す項目 (content_html: サニタイズ済みの本文HTML)",
+                        "name": "include",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "登録成功。ユーザー情報と認証トークンを返します。",
+                    "200": {
+                        "description": "部署ごとのトレンド記事",
                         "schema": {
-                            "$ref": "#/definitions/AuthResponse"
+                            "$ref": "#/definitions/TrendingArticlesResponse"
                         }
                     },
                     "400": {
-                        "description": "リクエストボディが不正です",
-                        "schema": {
-                            "$ref": "#/definitions/ErrorResponse"
-                        }
-                    },
-                    "409": {
-                        "description": "指定されたメールアドレスは既に使用されています",
+                        "description": "リクエストパラメータが不正です",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -242,58 +401,3712 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "ArticleListResponse": {
-            "type": "object",
-            "properties": {
-                "articles": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/ArticleResponse"
-                    }
-                },
-                "limit": {
-                    "type": "integer",
-                    "example": 10
-                },
-                "page": {
-                    "type": "integer",
-                    "example": 1
-                },
-                "total_count": {
-                    "type": "integer",
-                    "example": 100
-                },
-                "total_pages": {
-                    "type": "integer",
-                    "example": 10
-                }
-            }
         },
-        "ArticleResponse": {
-            "type": "object",
-            "properties": {
-                "article_type": {
-                    "type": "string",
-                    "enum": [
-                        "markdown",
-                        "external"
+        "/api/articles/{slug}": {
+            "get": {
+                "description": "指定されたslugのブログ記事の詳細を取得します。内部公開記事の場合はログインとメールアドレスの確認が必要です。下書きは著者本人・レビュアー・記事を管理できるユーザー、またはpreview_tokenを指定した場合のみ閲覧できます。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "記事 (Articles)"
+                ],
+                "summary": "記事詳細を取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"go-api-development\"",
+                        "description": "記事のスラグ",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "下書きプレビュー用トークン (POST /api/articles/{slug}/preview-tokens で発行)",
+                        "name": "preview_token",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "content_html"
+                        ],
+                        "type": "string",
+                        "description": "追加で返す項目 (content_html: サニタイズ済みの本文HTML)",
+                        "name": "include",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "記事詳細",
+                        "schema": {
+                            "$ref": "#/definitions/ArticleResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "内部公開記事にアクセスするにはログインとメールアドレスの確認が必要です / プレビューリンクが無効です",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "記事が見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "指定されたslugの記事をリクエストの内容で置き換えます。公開中の記事のタイトル・本文・リンク先・サムネイル・記事の種類・スラグ・タグ・部署は、公開を取り下げない限り変更できません（レビューでの承認が必要です）。記事の著者・記事の部署の編集者(editor)・管理者(admin)のみ実行できます。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "記事 (Articles)"
+                ],
+                "summary": "記事を更新 (全体置き換え)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"go-api-development\"",
+                        "description": "記事のスラグ",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "記事の内容",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateArticleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新された記事",
+                        "schema": {
+                            "$ref": "#/definitions/ArticleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストボディが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証されていません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "この記事を変更する権限がありません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "記事が見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "スラグが既に使用されています / 公開にはレビューでの承認が必要です",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "指定されたslugの記事を削除します。記事の著者・記事の部署の編集者(editor)・管理者(admin)のみ実行できます。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "記事 (Articles)"
+                ],
+                "summary": "記事を削除",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"go-api-development\"",
+                        "description": "記事のスラグ",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "削除成功"
+                    },
+                    "401": {
+                        "description": "認証されていません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "この記事を変更する権限がありません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "記事が見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "指定されたslugの記事のうち、リクエストに含まれる項目のみ更新します。公開中の記事のタイトル・本文・リンク先・サムネイル・記事の種類・スラグ・タグ・部署は、公開を取り下げない限り変更できません（レビューでの承認が必要です）。記事の著者・記事の部署の編集者(editor)・管理者(admin)のみ実行できます。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "記事 (Articles)"
+                ],
+                "summary": "記事を更新 (部分更新)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"go-api-development\"",
+                        "description": "記事のスラグ",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "更新する項目",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/PatchArticleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新された記事",
+                        "schema": {
+                            "$ref": "#/definitions/ArticleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストボディが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証されていません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "この記事を変更する権限がありません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "記事が見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "スラグが既に使用されています / 公開にはレビューでの承認が必要です",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/articles/{slug}/comments": {
+            "get": {
+                "description": "記事のコメントを古い順に取得します。ページ分けは返信でないコメント単位で、それぞれの返信（古い順）をrepliesに含めます。記事を閲覧できるユーザーのみ取得でき、internal記事のコメントはメールアドレスを確認済みのメンバーのみ閲覧できます。削除・非表示のコメントは本文を伏せて返します。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "コメント (Comments)"
+                ],
+                "summary": "コメント一覧を取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"go-api-development\"",
+                        "description": "記事のスラグ",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "ページ番号 (デフォルト: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "1ページあたりのコメント数 (デフォルト: 10, 最大: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "コメント一覧",
+                        "schema": {
+                            "$ref": "#/definitions/CommentListResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "内部公開記事にアクセスするにはログインとメールアドレスの確認が必要です",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "記事が見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "記事にコメント、またはコメントへの返信（parent_idを指定）を投稿します。返信への返信はできません。本文はMarkdownで、記事本文と同じようにサニタイズしたHTMLをbody_htmlとして返します。メールアドレスを確認済みのメンバーが、閲覧できる公開中（internal・public）の記事にのみ投稿できます。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "コメント (Comments)"
+                ],
+                "summary": "コメントを投稿",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"go-api-development\"",
+                        "description": "記事のスラグ",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "コメント",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateCommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "投稿したコメント",
+                        "schema": {
+                            "$ref": "#/definitions/CommentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストボディが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証されていません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "メールアドレスの確認が完了していません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "記事が見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/articles/{slug}/comments/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "自分のコメントを削除します。返信のスレッドを保つため、一覧には本文を伏せたコメントとして残ります。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "コメント (Comments)"
+                ],
+                "summary": "コメントを削除",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"go-api-development\"",
+                        "description": "記事のスラグ",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "コメントのID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "削除成功"
+                    },
+                    "401": {
+                        "description": "認証されていません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "このコメントを変更する権限がありません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "コメントが見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "自分のコメントの本文を編集します。削除したコメントは編集できません。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "コメント (Comments)"
+                ],
+                "summary": "コメントを編集",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"go-api-development\"",
+                        "description": "記事のスラグ",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "コメントのID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "コメント",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateCommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "編集したコメント",
+                        "schema": {
+                            "$ref": "#/definitions/CommentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストボディが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証されていません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "このコメントを変更する権限がありません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "コメントが見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/articles/{slug}/comments/{id}/hide": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "コメントを非表示にします。非表示のコメントは記事の著者・管理者・投稿者本人以外には本文を伏せて返します。記事の著者と管理者のみ実行できます。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "コメント (Comments)"
+                ],
+                "summary": "コメントを非表示",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"go-api-development\"",
+                        "description": "記事のスラグ",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "コメントのID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "非表示にしたコメント",
+                        "schema": {
+                            "$ref": "#/definitions/CommentResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証されていません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "この操作を行う権限がありません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "コメントが見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/articles/{slug}/comments/{id}/unhide": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "非表示にしたコメントを再び表示します。記事の著者と管理者のみ実行できます。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "コメント (Comments)"
+                ],
+                "summary": "コメントの非表示を解除",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"go-api-development\"",
+                        "description": "記事のスラグ",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "コメントのID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "表示したコメント",
+                        "schema": {
+                            "$ref": "#/definitions/CommentResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証されていません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "この操作を行う権限がありません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "コメントが見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/articles/{slug}/preview-tokens": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "記事に発行したプレビューリンクの一覧を取得します。トークン本体は含まれません。記事の著者・記事の部署の編集者(editor)・管理者(admin)のみ実行できます。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "記事 (Articles)"
+                ],
+                "summary": "プレビューリンク一覧を取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"go-api-development\"",
+                        "description": "記事のスラグ",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "プレビューリンク一覧",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/PreviewTokenResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "認証されていません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "この記事を変更する権限がありません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "記事が見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "記事をアカウントのないレビュアーにも見せられる署名付きプレビューリンクを発行します。リンクはこの記事にのみ有効で、一定時間で期限切れになります。記事の著者・記事の部署の編集者(editor)・管理者(admin)のみ実行できます。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "記事 (Articles)"
+                ],
+                "summary": "プレビューリンクを発行",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"go-api-development\"",
+                        "description": "記事のスラグ",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "発行されたプレビューリンク",
+                        "schema": {
+                            "$ref": "#/definitions/PreviewTokenResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証されていません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "この記事を変更する権限がありません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "記事が見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/articles/{slug}/preview-tokens/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "発行済みのプレビューリンクを失効させ、以後そのリンクでは記事を閲覧できないようにします。記事の著者・記事の部署の編集者(editor)・管理者(admin)のみ実行できます。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "記事 (Articles)"
+                ],
+                "summary": "プレビューリンクを失効",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"go-api-development\"",
+                        "description": "記事のスラグ",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "プレビューリンクのID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "失効成功"
+                    },
+                    "401": {
+                        "description": "認証されていません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "この記事を変更する権限がありません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "記事またはプレビューリンクが見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/articles/{slug}/reactions/{emoji}": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "記事に絵文字でリアクションします。同じ絵文字のリアクションは1人1回までで、既にリアクションしている場合も成功になります。メールアドレスを確認済みのメンバーが、閲覧できる公開中（internal・public）の記事にのみリアクションできます。絵文字はthumbs_up(👍)・heart(❤️)・tada(🎉)・laugh(😄)・bulb(💡)・eyes(👀)のいずれかです。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "リアクション (Reactions)"
+                ],
+                "summary": "リアクションを追加",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"go-api-development\"",
+                        "description": "記事のスラグ",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "thumbs_up",
+                            "heart",
+                            "tada",
+                            "laugh",
+                            "bulb",
+                            "eyes"
+                        ],
+                        "type": "string",
+                        "description": "絵文字",
+                        "name": "emoji",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "記事のリアクション",
+                        "schema": {
+                            "$ref": "#/definitions/ReactionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "不明な絵文字か、公開中ではない記事です",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証されていません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "メールアドレスの確認が完了していません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "記事が見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "記事に付けた自分のリアクションを取り消します。リアクションしていない場合も成功になります。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "リアクション (Reactions)"
+                ],
+                "summary": "リアクションを取り消し",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"go-api-development\"",
+                        "description": "記事のスラグ",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "thumbs_up",
+                            "heart",
+                            "tada",
+                            "laugh",
+                            "bulb",
+                            "eyes"
+                        ],
+                        "type": "string",
+                        "description": "絵文字",
+                        "name": "emoji",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "記事のリアクション",
+                        "schema": {
+                            "$ref": "#/definitions/ReactionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "不明な絵文字です",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証されていません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "メールアドレスの確認が完了していません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "記事が見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/articles/{slug}/reviews": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "記事のレビュアーごとの状態とレビューコメントを取得します。記事の著者・レビュアー・記事を管理できるユーザー（管理者・記事の部署の編集者）のみ閲覧できます。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "レビュー (Reviews)"
+                ],
+                "summary": "レビュー状況を取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"go-api-development\"",
+                        "description": "記事のスラグ",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "レビュー状況",
+                        "schema": {
+                            "$ref": "#/definitions/ReviewStatusResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証されていません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "この記事のレビュアーに割り当てられていません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "記事が見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "記事にレビュアーを割り当て、ステータスをin_reviewにします。publicにするには記事の部署に所属するレビュアーの承認が必要なため、その部署のレビュアーを1人以上含めてください。著者とレビューを依頼するユーザー自身はレビュアーに指定できません。記事の著者（または記事を管理できるユーザー）のみ実行できます。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "レビュー (Reviews)"
+                ],
+                "summary": "レビューを依頼",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"go-api-development\"",
+                        "description": "記事のスラグ",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "レビュアーとコメント",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SubmitReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "レビュー状況",
+                        "schema": {
+                            "$ref": "#/definitions/ReviewStatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストボディが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証されていません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "この記事を変更する権限がありません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "記事が見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/articles/{slug}/reviews/approve": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "レビュアーとしてレビュー中の記事を承認します。記事の部署に所属するレビュアーが承認すると、著者は記事をpublicにできます。確認した内容のリビジョン番号（レビュー状況のrevision_number）を指定してください。確認した後に記事が更新されている場合は承認できません（409）。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "レビュー (Reviews)"
+                ],
+                "summary": "記事を承認",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"go-api-development\"",
+                        "description": "記事のスラグ",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "確認したリビジョン番号とコメント (任意)",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ApproveReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "レビュー状況",
+                        "schema": {
+                            "$ref": "#/definitions/ReviewStatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リビジョン番号がありません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証されていません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "この記事のレビュアーに割り当てられていません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "記事が見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "この記事はレビュー中ではないか、確認した後に更新されています",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/articles/{slug}/reviews/comments": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "記事の著者またはレビュアーとしてレビューコメントを追加します。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "レビュー (Reviews)"
+                ],
+                "summary": "レビューコメントを追加",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"go-api-development\"",
+                        "description": "記事のスラグ",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "コメント",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ReviewCommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "レビュー状況",
+                        "schema": {
+                            "$ref": "#/definitions/ReviewStatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "コメントがありません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証されていません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "この記事のレビュアーに割り当てられていません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "記事が見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/articles/{slug}/reviews/request-changes": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "レビュアーとしてレビュー中の記事に修正を依頼し、記事を下書き(draft)に戻します。コメントは必須です。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "レビュー (Reviews)"
+                ],
+                "summary": "修正を依頼",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"go-api-development\"",
+                        "description": "記事のスラグ",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "修正してほしい内容",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ReviewCommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "レビュー状況",
+                        "schema": {
+                            "$ref": "#/definitions/ReviewStatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "コメントがありません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証されていません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "この記事のレビュアーに割り当てられていません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "記事が見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "この記事はレビュー中ではありません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/articles/{slug}/revisions": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "記事の保存履歴を新しい順に取得します。本文は含まれません。記事の著者・記事の部署の編集者(editor)・管理者(admin)のみ実行できます。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "リビジョン (Revisions)"
+                ],
+                "summary": "リビジョン一覧を取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"go-api-development\"",
+                        "description": "記事のスラグ",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "リビジョン一覧",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/RevisionResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "認証されていません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "この記事を変更する権限がありません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "記事が見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/articles/{slug}/revisions/diff": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "2つのリビジョンの本文を行単位で比較した差分を取得します。記事の著者・記事の部署の編集者(editor)・管理者(admin)のみ実行できます。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "リビジョン (Revisions)"
+                ],
+                "summary": "リビジョン間の差分を取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"go-api-development\"",
+                        "description": "記事のスラグ",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "比較元のリビジョン番号",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "比較先のリビジョン番号",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "差分",
+                        "schema": {
+                            "$ref": "#/definitions/RevisionDiffResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リビジョン番号が不正です",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証されていません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "この記事を変更する権限がありません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "記事またはリビジョンが見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/articles/{slug}/revisions/{number}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "指定した番号のリビジョンを本文付きで取得します。記事の著者・記事の部署の編集者(editor)・管理者(admin)のみ実行できます。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "リビジョン (Revisions)"
+                ],
+                "summary": "リビジョンを取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"go-api-development\"",
+                        "description": "記事のスラグ",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "リビジョン番号",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "リビジョン",
+                        "schema": {
+                            "$ref": "#/definitions/RevisionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リビジョン番号が不正です",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証されていません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "この記事を変更する権限がありません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "記事またはリビジョンが見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/articles/{slug}/revisions/{number}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "指定したリビジョンのタイトルと本文を記事に書き戻し、新しいリビジョンとして保存します。ステータスは現在の値を維持します。公開中の記事は、公開を取り下げてから復元してください。記事の著者・記事の部署の編集者(editor)・管理者(admin)のみ実行できます。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "リビジョン (Revisions)"
+                ],
+                "summary": "リビジョンを復元",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"go-api-development\"",
+                        "description": "記事のスラグ",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "復元するリビジョン番号",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "復元後の記事",
+                        "schema": {
+                            "$ref": "#/definitions/ArticleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リビジョン番号が不正です",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証されていません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "この記事を変更する権限がありません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "記事またはリビジョンが見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "公開にはレビューでの承認が必要です",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/email/resend": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "ログイン中のユーザーのメールアドレスに確認メールを再送します。",
+                "tags": [
+                    "認証 (Auth)"
+                ],
+                "summary": "確認メールの再送",
+                "responses": {
+                    "202": {
+                        "description": "送信しました"
+                    },
+                    "400": {
+                        "description": "メールアドレスは既に確認済みです / 登録できないドメインです",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証されていません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/email/verify": {
+            "post": {
+                "description": "確認メールのリンクに含まれるトークンを検証し、メールアドレスを確認済みにします。確認後は内部公開記事の閲覧や記事の作成ができるようになります。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "認証 (Auth)"
+                ],
+                "summary": "メールアドレスの確認",
+                "parameters": [
+                    {
+                        "description": "確認メールのトークン",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/VerifyEmailRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "確認後のユーザー情報",
+                        "schema": {
+                            "$ref": "#/definitions/UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "確認リンクが無効か有効期限が切れています",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/login": {
+            "post": {
+                "description": "既存のユーザーを認証し、新しい認証トークンを発行します。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "認証 (Auth)"
+                ],
+                "summary": "ログイン (Log In)",
+                "parameters": [
+                    {
+                        "description": "ユーザー情報 (メールアドレスとパスワード)",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/AuthenticateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "認証成功。新しい認証トークンを返します。",
+                        "schema": {
+                            "$ref": "#/definitions/AuthResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストボディが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証に失敗しました (メールアドレスまたはパスワードが正しくありません)",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/logout": {
+            "post": {
+                "description": "現在のアクセストークンとリフレッシュトークンを失効させ、Cookieを削除します。失効したトークンはAuthorizationヘッダーで送っても認証に使えません。未ログインの場合もCookieを削除して成功を返します。",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "認証 (Auth)"
+                ],
+                "summary": "ログアウト (Log Out)",
+                "parameters": [
+                    {
+                        "description": "リフレッシュトークン (Cookieを使う場合は不要)",
+                        "name": "payload",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/RefreshRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "ログアウト成功"
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/me": {
+            "get": {
+                "description": "Cookieからトークンを読み取り、現在ログイン中のユーザー情報を返します。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "認証 (Auth)"
+                ],
+                "summary": "現在のユーザー情報を取得",
+                "responses": {
+                    "200": {
+                        "description": "ユーザー情報",
+                        "schema": {
+                            "$ref": "#/definitions/UserResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証されていません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/password/forgot": {
+            "post": {
+                "description": "指定したメールアドレスにパスワード再設定用のリンクを送信します。リンクは一定時間で期限切れになり、一度だけ使用できます。登録されていないメールアドレスの場合もメールは送らずに同じレスポンスを返します。メールは送信の完了を待たずにバックグラウンドで送信し、送信に失敗した場合もレスポンスは変わりません。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "認証 (Auth)"
+                ],
+                "summary": "パスワードリセットメールの送信",
+                "parameters": [
+                    {
+                        "description": "メールアドレス",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ForgotPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "受け付けました"
+                    },
+                    "400": {
+                        "description": "リクエストボディが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/password/reset": {
+            "post": {
+                "description": "メールで届いたリンクのトークンと新しいパスワードを指定して、パスワードを変更します。変更すると、すべての端末のリフレッシュトークンが無効になります。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "認証 (Auth)"
+                ],
+                "summary": "パスワードの再設定",
+                "parameters": [
+                    {
+                        "description": "トークンと新しいパスワード",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "再設定成功"
+                    },
+                    "400": {
+                        "description": "リンクが無効か有効期限が切れています / パスワードが短すぎます",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/refresh": {
+            "post": {
+                "description": "リフレッシュトークン（Cookieの` + "`" + `refresh_token` + "`" + `、またはボディの` + "`" + `refresh_token` + "`" + `）を使って新しいアクセストークンを発行します。リフレッシュトークンは使うたびに新しいものに置き換わり、使用済みのトークンが再利用された場合は同じログインから発行されたトークンがすべて無効になります。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "認証 (Auth)"
+                ],
+                "summary": "トークンの再発行 (Refresh)",
+                "parameters": [
+                    {
+                        "description": "リフレッシュトークン (Cookieを使う場合は不要)",
+                        "name": "payload",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/RefreshRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "再発行成功。新しいアクセストークンとリフレッシュトークンを返します。",
+                        "schema": {
+                            "$ref": "#/definitions/AuthResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "リフレッシュトークンが無効か有効期限が切れています",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/signup": {
+            "post": {
+                "description": "新しいユーザーアカウントを作成し、認証トークンとユーザー情報を返します。登録したメールアドレスに確認メールを送信し、確認が完了するまでは内部公開記事の閲覧や記事の作成はできません。許可するメールドメインが設定されている場合、それ以外のアドレスでも登録はできますが、メールアドレスを確認できないためゲストのままです（確認メールも送信しません）。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "認証 (Auth)"
+                ],
+                "summary": "新規ユーザー登録 (Sign Up)",
+                "parameters": [
+                    {
+                        "description": "ユーザー情報 (メールアドレス、パスワード、名前)",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SignUpRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "登録成功。ユーザー情報と認証トークンを返します。",
+                        "schema": {
+                            "$ref": "#/definitions/AuthResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストボディが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "指定されたメールアドレスは既に使用されています",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/categories": {
+            "get": {
+                "description": "カテゴリ（is_categoryがtrueのタグ）の一覧を、閲覧者が見られる記事数付きで取得します。閲覧者が見られる記事が1件もないカテゴリは含みません。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "タグ (Tags)"
+                ],
+                "summary": "カテゴリ一覧を取得",
+                "responses": {
+                    "200": {
+                        "description": "カテゴリ一覧",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/TagResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/media": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "記事のサムネイルや本文に使う画像（JPEG, PNG, GIF, WebP、10MBまで）をアップロードします。Exifなどのメタデータを取り除き、長辺2560px以内に縮小した元画像と、幅320・640・1280pxの縮小版（元画像より小さいもののみ）のURLを返します。同じ画像をアップロードすると同じURLを返します。記事を作成できるユーザー（admin, editor, writer）のみ実行できます。",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "メディア (Media)"
+                ],
+                "summary": "画像をアップロード",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "画像ファイル",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "アップロードした画像のURL",
+                        "schema": {
+                            "$ref": "#/definitions/MediaResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "画像が指定されていないか、形式・サイズが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証されていません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "この操作を行う権限がありません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tags": {
+            "get": {
+                "description": "カテゴリ以外のタグの一覧を、閲覧者が見られる記事数付きで取得します。ゲストの場合はpublic記事のみ、メールアドレスを確認済みのメンバーの場合はinternal記事も数えます。閲覧者が見られる記事が1件もないタグ（下書きにだけ付いているタグなど）は含みません。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "タグ (Tags)"
+                ],
+                "summary": "タグ一覧を取得",
+                "responses": {
+                    "200": {
+                        "description": "タグ一覧",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/TagResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tags/{name}/articles": {
+            "get": {
+                "description": "指定されたタグ（カテゴリ）が付いた記事の一覧を取得します。閲覧ルールとページネーションは記事一覧と同じです。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "タグ (Tags)"
+                ],
+                "summary": "タグ別の記事一覧を取得",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"Go\"",
+                        "description": "タグ名",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "page",
+                            "cursor"
+                        ],
+                        "type": "string",
+                        "description": "ページネーションの方式 (デフォルト: page)。cursorの場合はnext_cursor・prev_cursorでページを移動します",
+                        "name": "pagination",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "前回のレスポンスのnext_cursorまたはprev_cursor。指定した場合はカーソル方式になります",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "カーソル方式の場合に総件数(total_count)も返すか (デフォルト: false)",
+                        "name": "include_total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "ページ番号 (ページ番号方式の場合。デフォルト: 1)。総件数も返します",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "1ページあたりの件数 (デフォルト: 10, 最大: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"Dev,MKT\"",
+                        "description": "部署でフィルタ (Dev, MKT, Ops。カンマ区切りで複数指定可)",
+                        "name": "department",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "記事の種類でフィルタ (markdown, external。カンマ区切りで複数指定可)",
+                        "name": "article_type",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "internal",
+                            "public",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "ステータスでフィルタ (internal, public, all)。ゲストの場合は常にpublicのみ",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "著者のユーザーIDでフィルタ",
+                        "name": "author_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2026-01-01T00:00:00+09:00\"",
+                        "description": "この日時以降に作成された記事 (RFC 3339または日付。日付のみの場合はUTCの0時)",
+                        "name": "created_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2026-02-01\"",
+                        "description": "この日時より前に作成された記事 (RFC 3339または日付。日付のみの場合はUTCの0時)",
+                        "name": "created_before",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at",
+                            "updated_at",
+                            "title",
+                            "popularity"
+                        ],
+                        "type": "string",
+                        "description": "並び順 (デフォルト: created_at。qを指定した場合は省略すると関連度順)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "並びの向き (デフォルト: titleはasc、それ以外はdesc。sortと一緒に指定)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "content_html"
+                        ],
+                        "type": "string",
+                        "description": "追加で返す項目 (content_html: サニタイズ済みの本文HTML)",
+                        "name": "include",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "記事一覧",
+                        "schema": {
+                            "$ref": "#/definitions/ArticleListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストパラメータが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "タグが見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "ユーザーの一覧をロール・所属部署付きでID順に取得します。管理者(admin)のみ実行できます。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ユーザー (Users)"
+                ],
+                "summary": "ユーザー一覧を取得",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "ページ番号",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "1ページあたりの件数",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ユーザー一覧",
+                        "schema": {
+                            "$ref": "#/definitions/UserListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証されていません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "この操作を行う権限がありません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/me": {
+            "patch": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "ログイン中のユーザーの名前と所属を更新します。省略した項目は変更せず、affiliationに空文字を指定すると所属を削除します。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ユーザー (Users)"
+                ],
+                "summary": "自分のプロフィールを更新",
+                "parameters": [
+                    {
+                        "description": "変更する名前・所属",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "変更後のユーザー情報",
+                        "schema": {
+                            "$ref": "#/definitions/UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストボディが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証されていません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/me/icon": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "画像（JPEG, PNG, GIF, WebP、5MBまで）をアップロードし、中央を正方形に切り抜いた256×256のサムネイルをアイコンとして設定します。",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ユーザー (Users)"
+                ],
+                "summary": "自分のアイコン画像をアップロード",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "アイコン画像",
+                        "name": "icon",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "変更後のユーザー情報",
+                        "schema": {
+                            "$ref": "#/definitions/UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "画像が指定されていないか、形式・サイズが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証されていません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/me/password": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "現在のパスワードを確認してから新しいパスワードに変更します。変更すると他の端末のログインはすべて無効になり、この端末には新しい認証トークンを発行します。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ユーザー (Users)"
+                ],
+                "summary": "パスワードの変更",
+                "parameters": [
+                    {
+                        "description": "現在のパスワードと新しいパスワード",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ChangePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "変更成功。新しい認証トークンを返します。",
+                        "schema": {
+                            "$ref": "#/definitions/AuthResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "現在のパスワードが正しくありません / パスワードが短すぎます",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証されていません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/{id}": {
+            "get": {
+                "description": "ユーザーの名前・所属・アイコンと、記事数・最近の記事（5件）を取得します。記事は記事一覧と同じルールで絞り込まれ、ゲストの場合はpublicの記事のみが対象です。このユーザーの記事をさらに見る場合は GET /api/articles?author_id={id} を使います。",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ユーザー (Users)"
+                ],
+                "summary": "ユーザーの公開プロフィールを取得",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "ユーザーID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "公開プロフィール",
+                        "schema": {
+                            "$ref": "#/definitions/UserProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "ユーザーIDが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "ユーザーが見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/{id}/role": {
+            "patch": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "ユーザーのロール（admin, editor, writer, reader）と所属部署を変更します。管理者(admin)のみ実行でき、自分自身のロールは変更できません。変更は次回ログイン時に発行されるトークンに反映されます。",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ユーザー (Users)"
+                ],
+                "summary": "ユーザーのロールを変更",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 2,
+                        "description": "ユーザーID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "変更するロール・所属部署",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateUserRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "変更後のユーザー情報",
+                        "schema": {
+                            "$ref": "#/definitions/UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "リクエストボディが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "認証されていません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "この操作を行う権限がありません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "ユーザーが見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/atom.xml": {
+            "get": {
+                "description": "公開(public)記事の新着20件をAtomで返します。内容と絞り込みはRSSフィードと同じです。",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "フィード (Feeds)"
+                ],
+                "summary": "Atomフィード",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "部署で絞り込み (Dev, MKT, Ops。カンマ区切りで複数指定可)",
+                        "name": "department",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "タグ名で絞り込み",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "著者のユーザーIDで絞り込み",
+                        "name": "author_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Atom",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "304": {
+                        "description": "更新なし"
+                    },
+                    "400": {
+                        "description": "リクエストパラメータが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "ユーザーが見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/feed.xml": {
+            "get": {
+                "description": "公開(public)記事の新着20件をRSS 2.0で返します。Markdownの記事は本文全体をHTML（content:encoded）で、外部記事はリンク先のURLを載せます。ETag・Last-Modifiedによる条件付きGETに対応しています。",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "フィード (Feeds)"
+                ],
+                "summary": "RSSフィード",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "部署で絞り込み (Dev, MKT, Ops。カンマ区切りで複数指定可)",
+                        "name": "department",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "タグ名で絞り込み",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "著者のユーザーIDで絞り込み",
+                        "name": "author_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "RSS 2.0",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "304": {
+                        "description": "更新なし"
+                    },
+                    "400": {
+                        "description": "リクエストパラメータが不正です",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "ユーザーが見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/robots.txt": {
+            "get": {
+                "description": "クローラー向けのrobots.txtを返します。巡回を禁止するパスは設定（server.robots）で変更できます。",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "サイトマップ (Sitemap)"
+                ],
+                "summary": "robots.txt",
+                "responses": {
+                    "200": {
+                        "description": "robots.txt",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/sitemap.xml": {
+            "get": {
+                "description": "公開(public)記事のページURLと更新日時(lastmod)を返します。公開記事が50,000件を超える場合はサイトマップインデックスを返し、記事は /sitemaps/{n}.xml に分割されます。",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "サイトマップ (Sitemap)"
+                ],
+                "summary": "サイトマップ",
+                "responses": {
+                    "200": {
+                        "description": "サイトマップまたはサイトマップインデックス",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sitemaps/{file}": {
+            "get": {
+                "description": "公開記事をID順に50,000件ずつ区切ったn番目（1始まり）のサイトマップを返します。",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "サイトマップ (Sitemap)"
+                ],
+                "summary": "分割したサイトマップ",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"1.xml\"",
+                        "description": "ページ番号.xml",
+                        "name": "file",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "サイトマップ",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "サイトマップが見つかりません",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "サーバー内部でエラーが発生しました",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "ApproveReviewRequest": {
+            "type": "object",
+            "required": [
+                "revision_number"
+            ],
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "example": "問題ありません"
+                },
+                "revision_number": {
+                    "description": "確認した内容のリビジョン番号。記事の最新のリビジョンでない場合は承認できません",
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "ArticleListResponse": {
+            "type": "object",
+            "properties": {
+                "articles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ArticleResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "next_cursor": {
+                    "description": "次のページ（新着順ではより古い記事）のカーソル。カーソル方式の場合のみ返し、次のページがない場合は省略します",
+                    "type": "string",
+                    "example": "eyJjIjoiMjAyNi0wMS0wNlQxMjowMDowMFoiLCJpIjo0Mn0"
+                },
+                "page": {
+                    "description": "ページ番号方式の場合のみ返します",
+                    "type": "integer",
+                    "example": 1
+                },
+                "prev_cursor": {
+                    "description": "前のページ（新着順ではより新しい記事）のカーソル。最初のページでは省略します",
+                    "type": "string",
+                    "example": "eyJjIjoiMjAyNi0wMS0wOFQxMjowMDowMFoiLCJpIjo1MCwiYiI6dHJ1ZX0"
+                },
+                "total_count": {
+                    "description": "総件数。ページ番号方式の場合か、カーソル方式でinclude_total=trueの場合のみ返します",
+                    "type": "integer",
+                    "example": 100
+                },
+                "total_pages": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "ArticleResponse": {
+            "type": "object",
+            "properties": {
+                "article_type": {
+                    "type": "string",
+                    "enum": [
+                        "markdown",
+                        "external"
+                    ],
+                    "example": "markdown"
+                },
+                "author": {
+                    "$ref": "#/definitions/AuthorResponse"
+                },
+                "content": {
+                    "type": "string",
+                    "example": "記事の本文です..."
+                },
+                "content_html": {
+                    "description": "サニタイズ済みの本文HTML。?include=content_html を指定した場合のみ返します",
+                    "type": "string",
+                    "example": "\u003ch2 id=\"はじめに\"\u003eはじめに\u003c/h2\u003e\n\u003cp\u003e記事の本文です...\u003c/p\u003e"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-01-06T12:00:00Z"
+                },
+                "department": {
+                    "type": "string",
+                    "enum": [
+                        "Dev",
+                        "MKT",
+                        "Ops"
+                    ],
+                    "example": "Dev"
+                },
+                "excerpt": {
+                    "description": "本文の先頭をプレーンテキストにした抜粋（最大120文字）",
+                    "type": "string",
+                    "example": "記事の本文です..."
+                },
+                "external_url": {
+                    "type": "string",
+                    "example": "https://example.com/article"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "my_reactions": {
+                    "description": "閲覧者自身が付けたリアクション。ログインしている場合のみ返します",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "thumbs_up",
+                        "heart"
+                    ]
+                },
+                "publish_at": {
+                    "type": "string",
+                    "example": "2026-01-10T09:00:00+09:00"
+                },
+                "reaction_counts": {
+                    "description": "絵文字ごとのリアクション数（リアクションがない絵文字は含みません）",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "reading_time_minutes": {
+                    "description": "読了時間の目安（分）",
+                    "type": "integer",
+                    "example": 3
+                },
+                "scheduled_status": {
+                    "type": "string",
+                    "enum": [
+                        "internal",
+                        "public"
+                    ],
+                    "example": "public"
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "go-api-development"
+                },
+                "snippet": {
+                    "type": "string",
+                    "example": "…\u003cmark\u003eReact\u003c/mark\u003e Hooksの基本から応用まで…"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "internal",
+                        "public"
+                    ],
+                    "example": "public"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Go",
+                        "Backend",
+                        "Echo"
+                    ]
+                },
+                "thumbnail_url": {
+                    "type": "string",
+                    "example": "https://example.com/thumbnail.jpg"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Go言語でのAPI開発入門"
+                },
+                "toc": {
+                    "description": "本文の見出し（h1〜h4）から作成した目次。idはcontent_htmlの見出しのidと一致します",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/TOCEntry"
+                    }
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2026-01-06T12:00:00Z"
+                },
+                "view_count": {
+                    "description": "累計の閲覧数。閲覧は数秒〜数十秒ごとにまとめて反映されます",
+                    "type": "integer",
+                    "example": 128
+                }
+            }
+        },
+        "AuthResponse": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "type": "string",
+                    "example": "3q2-7wEjRk..."
+                },
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "token_expires_at": {
+                    "type": "string",
+                    "example": "2026-01-01T09:15:00+09:00"
+                },
+                "user": {
+                    "$ref": "#/definitions/UserResponse"
+                }
+            }
+        },
+        "AuthenticateRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 8,
+                    "example": "password123"
+                }
+            }
+        },
+        "AuthorResponse": {
+            "type": "object",
+            "properties": {
+                "affiliation": {
+                    "type": "string",
+                    "example": "開発部"
+                },
+                "icon_url": {
+                    "type": "string",
+                    "example": "https://example.com/icon.jpg"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "山田太郎"
+                }
+            }
+        },
+        "ChangePasswordRequest": {
+            "type": "object",
+            "required": [
+                "current_password",
+                "new_password"
+            ],
+            "properties": {
+                "current_password": {
+                    "type": "string",
+                    "example": "password123"
+                },
+                "new_password": {
+                    "type": "string",
+                    "minLength": 8,
+                    "example": "newpassword123"
+                }
+            }
+        },
+        "CommentListResponse": {
+            "type": "object",
+            "properties": {
+                "comments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/CommentResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total_count": {
+                    "type": "integer",
+                    "example": 25
+                },
+                "total_pages": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "CommentResponse": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "description": "Markdownの本文",
+                    "type": "string",
+                    "example": "参考になりました。**sqlc** との比較も気になります"
+                },
+                "body_html": {
+                    "description": "サニタイズ済みの本文HTML",
+                    "type": "string",
+                    "example": "\u003cp\u003e参考になりました。\u003cstrong\u003esqlc\u003c/strong\u003e との比較も気になります\u003c/p\u003e"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-01-06T12:00:00Z"
+                },
+                "deleted": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "edited_at": {
+                    "type": "string",
+                    "example": "2026-01-06T13:00:00Z"
+                },
+                "hidden": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "parent_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "replies": {
+                    "description": "返信（古い順）。返信でないコメントのみ",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/CommentResponse"
+                    }
+                },
+                "user": {
+                    "$ref": "#/definitions/AuthorResponse"
+                }
+            }
+        },
+        "CreateArticleRequest": {
+            "type": "object",
+            "required": [
+                "article_type",
+                "department",
+                "slug"
+            ],
+            "properties": {
+                "article_type": {
+                    "type": "string",
+                    "enum": [
+                        "markdown",
+                        "external"
+                    ],
+                    "example": "markdown"
+                },
+                "content": {
+                    "type": "string",
+                    "example": "記事の本文です..."
+                },
+                "department": {
+                    "type": "string",
+                    "enum": [
+                        "Dev",
+                        "MKT",
+                        "Ops"
+                    ],
+                    "example": "Dev"
+                },
+                "external_url": {
+                    "type": "string",
+                    "example": "https://example.com/article"
+                },
+                "publish_at": {
+                    "description": "予約公開。publish_atになるとstatusがscheduled_statusに切り替わる（両方の指定が必要）",
+                    "type": "string",
+                    "example": "2026-01-10T09:00:00+09:00"
+                },
+                "scheduled_status": {
+                    "type": "string",
+                    "enum": [
+                        "internal",
+                        "public"
                     ],
-                    "example": "markdown"
+                    "example": "public"
                 },
-                "author": {
-                    "$ref": "#/definitions/AuthorResponse"
+                "slug": {
+                    "type": "string",
+                    "example": "go-api-development"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "internal",
+                        "public"
+                    ],
+                    "example": "draft"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Go",
+                        "Backend"
+                    ]
+                },
+                "thumbnail_url": {
+                    "type": "string",
+                    "example": "https://example.com/thumbnail.jpg"
+                },
+                "title": {
+                    "description": "external記事では省略でき、その場合はリンク先のページのタイトル（og:title）を使う",
+                    "type": "string",
+                    "example": "Go言語でのAPI開発入門"
+                }
+            }
+        },
+        "CreateCommentRequest": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "description": "Markdownで記述します（最大5000文字）",
+                    "type": "string",
+                    "example": "参考になりました。**sqlc** との比較も気になります"
+                },
+                "parent_id": {
+                    "description": "返信する場合は返信先のコメントのID（返信への返信はできません）",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "DepartmentTrendingArticles": {
+            "type": "object",
+            "properties": {
+                "articles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ArticleResponse"
+                    }
+                },
+                "department": {
+                    "type": "string",
+                    "enum": [
+                        "Dev",
+                        "MKT",
+                        "Ops"
+                    ],
+                    "example": "Dev"
+                }
+            }
+        },
+        "DiffLine": {
+            "type": "object",
+            "properties": {
+                "new_line": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "old_line": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "text": {
+                    "type": "string",
+                    "example": "## useState"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "equal",
+                        "insert",
+                        "delete"
+                    ],
+                    "example": "insert"
+                }
+            }
+        },
+        "ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "エラーメッセージ"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "詳細なエラー情報"
+                }
+            }
+        },
+        "ForgotPasswordRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                }
+            }
+        },
+        "MediaResponse": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string",
+                    "example": "image/jpeg"
+                },
+                "height": {
+                    "type": "integer",
+                    "example": 1080
+                },
+                "id": {
+                    "type": "string",
+                    "example": "3f2a9c0d1e4b5a6c7d8e9f0a1b2c3d4e"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "/media/images/3f2a9c0d1e4b5a6c7d8e9f0a1b2c3d4e/original.jpg"
+                },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/MediaVariantResponse"
+                    }
+                },
+                "width": {
+                    "type": "integer",
+                    "example": 1920
+                }
+            }
+        },
+        "MediaVariantResponse": {
+            "type": "object",
+            "properties": {
+                "height": {
+                    "type": "integer",
+                    "example": 360
+                },
+                "url": {
+                    "type": "string",
+                    "example": "/media/images/3f2a9c0d1e4b5a6c7d8e9f0a1b2c3d4e/w640.jpg"
+                },
+                "width": {
+                    "type": "integer",
+                    "example": 640
+                }
+            }
+        },
+        "PatchArticleRequest": {
+            "type": "object",
+            "properties": {
+                "article_type": {
+                    "type": "string",
+                    "enum": [
+                        "markdown",
+                        "external"
+                    ],
+                    "example": "markdown"
                 },
                 "content": {
                     "type": "string",
                     "example": "記事の本文です..."
                 },
+                "department": {
+                    "type": "string",
+                    "enum": [
+                        "Dev",
+                        "MKT",
+                        "Ops"
+                    ],
+                    "example": "Dev"
+                },
+                "external_url": {
+                    "type": "string",
+                    "example": "https://example.com/article"
+                },
+                "publish_at": {
+                    "description": "予約公開。scheduled_statusに空文字を指定すると予約を取り消す",
+                    "type": "string",
+                    "example": "2026-01-10T09:00:00+09:00"
+                },
+                "scheduled_status": {
+                    "type": "string",
+                    "enum": [
+                        "internal",
+                        "public"
+                    ],
+                    "example": "public"
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "go-api-development"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "internal",
+                        "public"
+                    ],
+                    "example": "public"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Go",
+                        "Backend"
+                    ]
+                },
+                "thumbnail_url": {
+                    "type": "string",
+                    "example": "https://example.com/thumbnail.jpg"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Go言語でのAPI開発入門"
+                }
+            }
+        },
+        "PreviewTokenResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-01-06T12:00:00Z"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2026-01-09T12:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "3f2a9c1e8b7d4a6f9e0c1b2a3d4e5f60"
+                },
+                "preview_path": {
+                    "type": "string",
+                    "example": "/api/articles/go-api-development?preview_token=eyJhbGciOi..."
+                },
+                "revoked_at": {
+                    "type": "string",
+                    "example": "2026-01-07T12:00:00Z"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                }
+            }
+        },
+        "ReactionsResponse": {
+            "type": "object",
+            "properties": {
+                "my_reactions": {
+                    "description": "ログイン中のユーザーが付けたリアクション",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "thumbs_up",
+                        "heart"
+                    ]
+                },
+                "reaction_counts": {
+                    "description": "絵文字ごとのリアクション数（リアクションがない絵文字は含みません）",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "RefreshRequest": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "type": "string",
+                    "example": "3q2-7wEjRk..."
+                }
+            }
+        },
+        "ResetPasswordRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "token"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "minLength": 8,
+                    "example": "newpassword123"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "3q2-7wEjRk..."
+                }
+            }
+        },
+        "ReviewCommentRequest": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "example": "サンプルコードの説明を追加してください"
+                }
+            }
+        },
+        "ReviewCommentResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "approved",
+                        "changes_requested"
+                    ],
+                    "example": "changes_requested"
+                },
+                "body": {
+                    "type": "string",
+                    "example": "サンプルコードの説明を追加してください"
+                },
                 "created_at": {
                     "type": "string",
                     "example": "2026-01-06T12:00:00Z"
                 },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "user": {
+                    "$ref": "#/definitions/AuthorResponse"
+                }
+            }
+        },
+        "ReviewStatusResponse": {
+            "type": "object",
+            "properties": {
+                "article_status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "in_review",
+                        "internal",
+                        "public"
+                    ],
+                    "example": "in_review"
+                },
+                "can_publish": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "comments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ReviewCommentResponse"
+                    }
+                },
                 "department": {
                     "type": "string",
                     "enum": [
@@ -303,57 +4116,114 @@ const docTemplate = `{
                     ],
                     "example": "Dev"
                 },
-                "external_url": {
-                    "type": "string",
-                    "example": "https://example.com/article"
+                "reviewers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ReviewerResponse"
+                    }
                 },
-                "id": {
+                "revision_number": {
+                    "description": "記事の最新のリビジョン番号。承認するときはこの番号（確認した内容のリビジョン番号）を指定します",
                     "type": "integer",
-                    "example": 1
-                },
-                "slug": {
+                    "example": 3
+                }
+            }
+        },
+        "ReviewerResponse": {
+            "type": "object",
+            "properties": {
+                "department": {
                     "type": "string",
-                    "example": "go-api-development"
+                    "enum": [
+                        "Dev",
+                        "MKT",
+                        "Ops"
+                    ],
+                    "example": "Dev"
                 },
-                "status": {
+                "reviewer": {
+                    "$ref": "#/definitions/AuthorResponse"
+                },
+                "state": {
                     "type": "string",
                     "enum": [
-                        "draft",
-                        "internal",
-                        "public"
+                        "pending",
+                        "approved",
+                        "changes_requested"
                     ],
-                    "example": "public"
+                    "example": "approved"
                 },
-                "thumbnail_url": {
+                "updated_at": {
                     "type": "string",
-                    "example": "https://example.com/thumbnail.jpg"
+                    "example": "2026-01-06T12:00:00Z"
+                }
+            }
+        },
+        "RevisionDiffResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "title": {
+                "from_title": {
                     "type": "string",
                     "example": "Go言語でのAPI開発入門"
                 },
-                "updated_at": {
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/DiffLine"
+                    }
+                },
+                "to": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "to_title": {
                     "type": "string",
-                    "example": "2026-01-06T12:00:00Z"
+                    "example": "Go言語でのAPI開発入門（改訂版）"
                 }
             }
         },
-        "AuthResponse": {
+        "RevisionResponse": {
             "type": "object",
             "properties": {
-                "token": {
+                "content": {
                     "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                    "example": "記事の本文です..."
                 },
-                "user": {
-                    "$ref": "#/definitions/UserResponse"
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-01-06T12:00:00Z"
+                },
+                "editor": {
+                    "$ref": "#/definitions/AuthorResponse"
+                },
+                "revision_number": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "internal",
+                        "public"
+                    ],
+                    "example": "draft"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Go言語でのAPI開発入門"
                 }
             }
         },
-        "AuthenticateRequest": {
+        "SignUpRequest": {
             "type": "object",
             "required": [
                 "email",
+                "name",
                 "password"
             ],
             "properties": {
@@ -361,6 +4231,10 @@ const docTemplate = `{
                     "type": "string",
                     "example": "user@example.com"
                 },
+                "name": {
+                    "type": "string",
+                    "example": "山田太郎"
+                },
                 "password": {
                     "type": "string",
                     "minLength": 8,
@@ -368,60 +4242,188 @@ const docTemplate = `{
                 }
             }
         },
-        "AuthorResponse": {
+        "SubmitReviewRequest": {
             "type": "object",
+            "required": [
+                "reviewer_ids"
+            ],
             "properties": {
-                "affiliation": {
+                "comment": {
                     "type": "string",
-                    "example": "開発部"
+                    "example": "公開前に確認をお願いします"
                 },
-                "icon_url": {
+                "reviewer_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        2,
+                        4
+                    ]
+                }
+            }
+        },
+        "TOCEntry": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "本文のHTML(content_html)の見出しに付いたid",
                     "type": "string",
-                    "example": "https://example.com/icon.jpg"
+                    "example": "はじめに"
+                },
+                "level": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "text": {
+                    "type": "string",
+                    "example": "はじめに"
+                }
+            }
+        },
+        "TagResponse": {
+            "type": "object",
+            "properties": {
+                "article_count": {
+                    "type": "integer",
+                    "example": 12
                 },
                 "id": {
                     "type": "integer",
                     "example": 1
                 },
+                "is_category": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Go"
+                }
+            }
+        },
+        "TrendingArticlesResponse": {
+            "type": "object",
+            "properties": {
+                "departments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/DepartmentTrendingArticles"
+                    }
+                }
+            }
+        },
+        "UpdateCommentRequest": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "example": "参考になりました。**sqlc** との比較も気になります（追記）"
+                }
+            }
+        },
+        "UpdateProfileRequest": {
+            "type": "object",
+            "properties": {
+                "affiliation": {
+                    "type": "string",
+                    "example": "開発部 バックエンドチーム"
+                },
                 "name": {
                     "type": "string",
                     "example": "山田太郎"
                 }
             }
         },
-        "ErrorResponse": {
+        "UpdateUserRoleRequest": {
             "type": "object",
             "properties": {
-                "error": {
+                "department": {
                     "type": "string",
-                    "example": "エラーメッセージ"
+                    "enum": [
+                        "Dev",
+                        "MKT",
+                        "Ops"
+                    ],
+                    "example": "Dev"
                 },
-                "message": {
+                "role": {
                     "type": "string",
-                    "example": "詳細なエラー情報"
+                    "enum": [
+                        "admin",
+                        "editor",
+                        "writer",
+                        "reader"
+                    ],
+                    "example": "editor"
                 }
             }
         },
-        "SignUpRequest": {
+        "UserListResponse": {
             "type": "object",
-            "required": [
-                "email",
-                "name",
-                "password"
-            ],
             "properties": {
-                "email": {
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total_count": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "total_pages": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/UserResponse"
+                    }
+                }
+            }
+        },
+        "UserProfileResponse": {
+            "type": "object",
+            "properties": {
+                "affiliation": {
                     "type": "string",
-                    "example": "user@example.com"
+                    "example": "開発部"
+                },
+                "article_count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "department": {
+                    "type": "string",
+                    "enum": [
+                        "Dev",
+                        "MKT",
+                        "Ops"
+                    ],
+                    "example": "Dev"
+                },
+                "icon_url": {
+                    "type": "string",
+                    "example": "https://example.com/icon.jpg"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
                 },
                 "name": {
                     "type": "string",
                     "example": "山田太郎"
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 8,
-                    "example": "password123"
+                "recent_articles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ArticleResponse"
+                    }
                 }
             }
         },
@@ -432,10 +4434,23 @@ const docTemplate = `{
                     "type": "string",
                     "example": "開発部"
                 },
+                "department": {
+                    "type": "string",
+                    "enum": [
+                        "Dev",
+                        "MKT",
+                        "Ops"
+                    ],
+                    "example": "Dev"
+                },
                 "email": {
                     "type": "string",
                     "example": "user@example.com"
                 },
+                "email_verified": {
+                    "type": "boolean",
+                    "example": true
+                },
                 "icon_url": {
                     "type": "string",
                     "example": "https://example.com/icon.jpg"
@@ -447,6 +4462,28 @@ const docTemplate = `{
                 "name": {
                     "type": "string",
                     "example": "山田太郎"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "editor",
+                        "writer",
+                        "reader"
+                    ],
+                    "example": "writer"
+                }
+            }
+        },
+        "VerifyEmailRequest": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
                 }
             }
         }
@@ -464,7 +4501,7 @@ const docTemplate = `{
 // SwaggerInfo holds exported Swagger Info so clients can modify it
 var SwaggerInfo = &swag.Spec{
 	Version:          "1.0",
-	Host:             "localhost:8080",
+	Host:             "",
 	BasePath:         "/",
 	Schemes:          []string{},
 	Title:            "Team1 Blog API",
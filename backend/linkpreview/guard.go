@@ -0,0 +1,47 @@
+package linkpreview
+
+import (
+	"net/netip"
+	"syscall"
+)
+
+// blockedPrefixes はnetipの判定メソッドでは分からない、外部から到達できないか内部向けのアドレス帯
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),      // 「このネットワーク」
+	netip.MustParsePrefix("100.64.0.0/10"),  // キャリアグレードNAT
+	netip.MustParsePrefix("192.0.0.0/24"),   // IETFプロトコル割り当て
+	netip.MustParsePrefix("198.18.0.0/15"),  // ベンチマーク用
+	netip.MustParsePrefix("240.0.0.0/4"),    // 予約済み（ブロードキャストを含む）
+	netip.MustParsePrefix("64:ff9b::/96"),   // NAT64（IPv4のプライベートアドレスに変換されうる）
+	netip.MustParsePrefix("64:ff9b:1::/48"), // ローカル用NAT64
+	netip.MustParsePrefix("2002::/16"),      // 6to4（IPv4のプライベートアドレスを含みうる）
+}
+
+// isPublicAddress はaddrがインターネット上のグローバルなアドレスかを返します
+// ループバック・プライベート・リンクローカル（クラウドのメタデータサービス 169.254.169.254 を含む）などはfalseです
+func isPublicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsUnspecified() || addr.IsLoopback() || addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return false
+	}
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// denyPrivateAddress はnet.Dialer.Controlに設定し、グローバルでないアドレスへの接続を拒否します
+// 名前解決後・接続直前に呼ばれるため、リダイレクト先やDNSリバインディングにも効きます
+func denyPrivateAddress(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return ErrBlockedAddress
+	}
+	if !isPublicAddress(addrPort.Addr()) {
+		return ErrBlockedAddress
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package linkpreview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/yamada-mikiya/team1-hackathon/config"
+	"golang.org/x/net/html/charset"
+)
+
+const (
+	defaultTimeout     = 5 * time.Second
+	defaultMaxBodySize = 1 << 20
+	defaultUserAgent   = "Team1BlogBot/1.0"
+	maxRedirects       = 5
+)
+
+var (
+	// ErrInvalidURL はhttp・https以外のURLが指定された場合のエラー
+	ErrInvalidURL = errors.New("http または https のURLを指定してください")
+	// ErrBlockedAddress は接続先がプライベートIPアドレスなど、アクセスを許可していないアドレスだった場合のエラー
+	ErrBlockedAddress = errors.New("アクセスが許可されていないアドレスです")
+	// ErrNotHTML はレスポンスがHTMLではなかった場合のエラー
+	ErrNotHTML = errors.New("HTMLのページではありません")
+)
+
+// Preview はページのOGP・Twitterカードなどから取得したリンクプレビュー
+type Preview struct {
+	Title       string
+	Description string
+	// 画像の絶対URL（http・httpsのみ）
+	ImageURL string
+	SiteName string
+	// リダイレクト後のページのURL
+	URL string
+}
+
+// Fetcher は外部ページのリンクプレビューを取得するインターフェース
+type Fetcher interface {
+	Fetch(ctx context.Context, rawURL string) (*Preview, error)
+}
+
+// Client はHTTPでページを取得し、<head>内のmetaタグからリンクプレビューを作成します
+// ユーザーが指定したURLにサーバーからアクセスするため、SSRF対策として接続先のIPアドレスを確認し、
+// タイムアウトと読み込むサイズの上限を設けています
+type Client struct {
+	httpClient  *http.Client
+	maxBodySize int64
+	userAgent   string
+}
+
+func NewClient(cfg config.LinkPreviewConfig) *Client {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	maxBodySize := cfg.MaxBodySize
+	if maxBodySize <= 0 {
+		maxBodySize = defaultMaxBodySize
+	}
+	userAgent := cfg.UserAgent
+	if userAgent == "" {
+		userAgent = defaultUserAgent
+	}
+
+	dialer := &net.Dialer{Timeout: timeout}
+	if !cfg.AllowPrivateNetworks {
+		// 名前解決後の実際の接続先を確認するため、DNSの応答を書き換えられても内部のアドレスには接続しない
+		dialer.Control = denyPrivateAddress
+	}
+	transport := &http.Transport{
+		// 環境変数のプロキシを経由すると接続先のIPアドレスを確認できないため使わない
+		Proxy:                 nil,
+		DialContext:           dialer.DialContext,
+		TLSHandshakeTimeout:   timeout,
+		ResponseHeaderTimeout: timeout,
+		MaxIdleConns:          10,
+		IdleConnTimeout:       30 * time.Second,
+	}
+
+	return &Client{
+		httpClient: &http.Client{
+			Transport: transport,
+			Timeout:   timeout,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) >= maxRedirects {
+					return errors.New("リダイレクトの回数が多すぎます")
+				}
+				if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+					return ErrInvalidURL
+				}
+				return nil
+			},
+		},
+		maxBodySize: maxBodySize,
+		userAgent:   userAgent,
+	}
+}
+
+// Fetch はrawURLのページを取得してリンクプレビューを返します
+// ページにOGPなどが設定されていない場合は、<title>とmeta descriptionを使います
+func (c *Client) Fetch(ctx context.Context, rawURL string) (*Preview, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, ErrInvalidURL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ページを取得できませんでした（ステータス: %d）", resp.StatusCode)
+	}
+	contentType := resp.Header.Get("Content-Type")
+	if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return nil, ErrNotHTML
+	}
+
+	// Shift_JISなどUTF-8以外のページもあるため、Content-Typeとmetaタグの文字コードに従って変換する
+	body, err := charset.NewReader(io.LimitReader(resp.Body, c.maxBodySize), contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	preview, err := parseHead(body, resp.Request.URL)
+	if err != nil {
+		return nil, err
+	}
+	preview.URL = resp.Request.URL.String()
+	return preview, nil
+}
@@ -0,0 +1,206 @@
+package linkpreview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yamada-mikiya/team1-hackathon/config"
+)
+
+// newTestClient はhttptestのサーバー（127.0.0.1）に接続できるクライアントを作成します
+func newTestClient(maxBodySize int64) *Client {
+	return NewClient(config.LinkPreviewConfig{
+		Timeout:              2 * time.Second,
+		MaxBodySize:          maxBodySize,
+		UserAgent:            "TestBot/1.0",
+		AllowPrivateNetworks: true,
+	})
+}
+
+func TestFetch(t *testing.T) {
+	var userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.UserAgent()
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, `<html><head><meta property="og:title" content="記事のタイトル"><meta property="og:image" content="/ogp.png"></head></html>`)
+	}))
+	defer server.Close()
+
+	preview, err := newTestClient(0).Fetch(context.Background(), server.URL+"/article")
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if preview.Title != "記事のタイトル" || preview.ImageURL != server.URL+"/ogp.png" || preview.URL != server.URL+"/article" {
+		t.Errorf("preview = %+v", *preview)
+	}
+	if userAgent != "TestBot/1.0" {
+		t.Errorf("User-Agent = %q, want %q", userAgent, "TestBot/1.0")
+	}
+}
+
+func TestFetchShiftJIS(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=Shift_JIS")
+		// 「日本語」をShift_JISで送る
+		w.Write([]byte("<head><title>\x93\xfa\x96\x7b\x8c\xea</title></head>"))
+	}))
+	defer server.Close()
+
+	preview, err := newTestClient(0).Fetch(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if preview.Title != "日本語" {
+		t.Errorf("title = %q, want %q", preview.Title, "日本語")
+	}
+}
+
+func TestFetchBodySizeLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<head><title>タイトル</title>`)
+		fmt.Fprint(w, `<meta name="padding" content="`+strings.Repeat("x", 4096)+`">`)
+		fmt.Fprint(w, `<meta property="og:description" content="上限より後ろの説明"></head>`)
+	}))
+	defer server.Close()
+
+	preview, err := newTestClient(1024).Fetch(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if preview.Title != "タイトル" {
+		t.Errorf("title = %q, want %q", preview.Title, "タイトル")
+	}
+	if preview.Description != "" {
+		t.Errorf("description = %q, want 空（上限を超えた部分は読まない）", preview.Description)
+	}
+}
+
+func TestFetchRedirects(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/posts/new", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/posts/new", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<head><title>移動後</title><meta property="og:image" content="image.png"></head>`)
+	})
+	mux.HandleFunc("/loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop", http.StatusFound)
+	})
+	mux.HandleFunc("/ftp", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "ftp://example.com/file", http.StatusFound)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	client := newTestClient(0)
+
+	t.Run("リダイレクト先のページを使う", func(t *testing.T) {
+		preview, err := client.Fetch(context.Background(), server.URL+"/old")
+		if err != nil {
+			t.Fatalf("Fetch: %v", err)
+		}
+		if preview.Title != "移動後" || preview.URL != server.URL+"/posts/new" {
+			t.Errorf("preview = %+v", *preview)
+		}
+		// 相対URLの画像はリダイレクト後のURLを基準に解決する
+		if preview.ImageURL != server.URL+"/posts/image.png" {
+			t.Errorf("image = %q, want %q", preview.ImageURL, server.URL+"/posts/image.png")
+		}
+	})
+	t.Run("リダイレクトが多すぎる", func(t *testing.T) {
+		if _, err := client.Fetch(context.Background(), server.URL+"/loop"); err == nil {
+			t.Error("リダイレクトのループでエラーになりません")
+		}
+	})
+	t.Run("http・https以外へのリダイレクト", func(t *testing.T) {
+		if _, err := client.Fetch(context.Background(), server.URL+"/ftp"); !errors.Is(err, ErrInvalidURL) {
+			t.Errorf("err = %v, want ErrInvalidURL", err)
+		}
+	})
+}
+
+func TestFetchErrors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{}`)
+	})
+	mux.HandleFunc("/missing", http.NotFound)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	client := newTestClient(0)
+
+	if _, err := client.Fetch(context.Background(), server.URL+"/json"); !errors.Is(err, ErrNotHTML) {
+		t.Errorf("JSON: err = %v, want ErrNotHTML", err)
+	}
+	if _, err := client.Fetch(context.Background(), server.URL+"/missing"); err == nil {
+		t.Error("404: エラーになりません")
+	}
+	for _, rawURL := range []string{"ftp://example.com/", "javascript:alert(1)", "/relative", "https://"} {
+		if _, err := client.Fetch(context.Background(), rawURL); !errors.Is(err, ErrInvalidURL) {
+			t.Errorf("%s: err = %v, want ErrInvalidURL", rawURL, err)
+		}
+	}
+}
+
+func TestFetchBlocksPrivateAddresses(t *testing.T) {
+	var requested bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = true
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<head><title>内部のページ</title></head>`)
+	}))
+	defer server.Close()
+
+	client := NewClient(config.LinkPreviewConfig{Timeout: 2 * time.Second})
+	localhostURL := strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+	for _, rawURL := range []string{server.URL, localhostURL} {
+		if _, err := client.Fetch(context.Background(), rawURL); !errors.Is(err, ErrBlockedAddress) {
+			t.Errorf("%s: err = %v, want ErrBlockedAddress", rawURL, err)
+		}
+	}
+	if requested {
+		t.Error("ループバックアドレスのサーバーに接続しています")
+	}
+}
+
+func TestIsPublicAddress(t *testing.T) {
+	tests := map[string]bool{
+		"8.8.8.8":                true,
+		"93.184.216.34":          true,
+		"2001:4860:4860::8888":   true,
+		"::ffff:93.184.216.34":   true,
+		"127.0.0.1":              false,
+		"10.1.2.3":               false,
+		"172.16.0.1":             false,
+		"192.168.1.1":            false,
+		"169.254.169.254":        false, // クラウドのメタデータサービス
+		"::ffff:169.254.169.254": false,
+		"100.64.0.1":             false,
+		"198.18.0.1":             false,
+		"240.0.0.1":              false,
+		"0.0.0.0":                false,
+		"224.0.0.1":              false,
+		"::":                     false,
+		"::1":                    false,
+		"::ffff:127.0.0.1":       false,
+		"fc00::1":                false,
+		"fe80::1":                false,
+		"ff02::1":                false,
+		"64:ff9b::a00:1":         false, // NAT64で10.0.0.1になるアドレス
+		"2002:a00:1::1":          false, // 6to4で10.0.0.1になるアドレス
+	}
+	for raw, want := range tests {
+		if got := isPublicAddress(netip.MustParseAddr(raw)); got != want {
+			t.Errorf("isPublicAddress(%s) = %v, want %v", raw, got, want)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package linkpreview
+
+import (
+	"errors"
+	"io"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	maxTitleLength       = 200
+	maxDescriptionLength = 300
+	maxSiteNameLength    = 100
+	maxImageURLLength    = 2048
+)
+
+// parseHead はHTMLの<head>からmetaタグと<title>を読み取ってリンクプレビューを作成します
+// <body>に入った時点で読み込みをやめます。相対URLの画像はbaseを基準に解決します
+func parseHead(r io.Reader, base *url.URL) (*Preview, error) {
+	meta := map[string]string{}
+	var title strings.Builder
+	inTitle := false
+
+	z := html.NewTokenizer(r)
+loop:
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			if errors.Is(z.Err(), io.EOF) {
+				break loop
+			}
+			return nil, z.Err()
+		case html.StartTagToken, html.SelfClosingTagToken:
+			tag := z.Token()
+			switch tag.DataAtom {
+			case atom.Body:
+				break loop
+			case atom.Title:
+				inTitle = true
+			case atom.Meta:
+				key, content := metaKeyAndContent(tag)
+				// 同じプロパティが複数ある場合（og:imageなど）は最初のものを使う
+				if _, ok := meta[key]; key != "" && !ok {
+					meta[key] = content
+				}
+			}
+		case html.EndTagToken:
+			switch z.Token().DataAtom {
+			case atom.Head:
+				break loop
+			case atom.Title:
+				inTitle = false
+			}
+		case html.TextToken:
+			if inTitle {
+				title.Write(z.Text())
+			}
+		}
+	}
+
+	return &Preview{
+		Title:       truncate(firstNonEmpty(meta["og:title"], meta["twitter:title"], title.String()), maxTitleLength),
+		Description: truncate(firstNonEmpty(meta["og:description"], meta["twitter:description"], meta["description"]), maxDescriptionLength),
+		ImageURL:    resolveImageURL(base, firstNonEmpty(meta["og:image:secure_url"], meta["og:image"], meta["og:image:url"], meta["twitter:image"], meta["twitter:image:src"])),
+		SiteName:    truncate(meta["og:site_name"], maxSiteNameLength),
+	}, nil
+}
+
+// metaKeyAndContent は<meta property="og:..." content="...">・<meta name="twitter:..." content="...">から
+// 小文字にしたプロパティ名と値を取り出します
+func metaKeyAndContent(tag html.Token) (string, string) {
+	var key, content string
+	for _, attr := range tag.Attr {
+		switch strings.ToLower(attr.Key) {
+		case "property", "name":
+			if key == "" {
+				key = strings.ToLower(strings.TrimSpace(attr.Val))
+			}
+		case "content":
+			content = attr.Val
+		}
+	}
+	return key, content
+}
+
+// resolveImageURL は画像のURLを絶対URLにします。http・https以外のURL（data:など）は使いません
+func resolveImageURL(base *url.URL, raw string) string {
+	if raw == "" || len(raw) > maxImageURLLength {
+		return ""
+	}
+	ref, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	u := base.ResolveReference(ref)
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ""
+	}
+	return u.String()
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// truncate は連続する空白を1つにまとめ、limit文字を超える場合は切り詰めて「…」を付けます
+func truncate(s string, limit int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+	return strings.TrimSpace(string([]rune(s)[:limit-1])) + "…"
+}
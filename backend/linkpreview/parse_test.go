@@ -0,0 +1,85 @@
+package linkpreview
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestParseHead(t *testing.T) {
+	base, _ := url.Parse("https://example.com/posts/1")
+
+	tests := []struct {
+		name string
+		html string
+		want Preview
+	}{
+		{
+			name: "OGP",
+			html: `<html><head>
+				<title>ページのタイトル</title>
+				<meta property="og:title" content="OGPのタイトル">
+				<meta property="og:description" content="OGPの説明">
+				<meta property="og:image" content="/images/ogp.png">
+				<meta property="og:image" content="/images/second.png">
+				<meta property="og:site_name" content="Example">
+				<meta name="twitter:title" content="Twitterのタイトル">
+			</head><body></body></html>`,
+			want: Preview{Title: "OGPのタイトル", Description: "OGPの説明", ImageURL: "https://example.com/images/ogp.png", SiteName: "Example"},
+		},
+		{
+			name: "Twitterカード",
+			html: `<head>
+				<meta name="twitter:title" content="Twitterのタイトル">
+				<meta name="twitter:description" content="Twitterの説明">
+				<meta name="twitter:image" content="https://cdn.example.com/card.png">
+			</head>`,
+			want: Preview{Title: "Twitterのタイトル", Description: "Twitterの説明", ImageURL: "https://cdn.example.com/card.png"},
+		},
+		{
+			name: "OGPがない場合はtitleとmeta description",
+			html: `<head><title>  ページの
+				タイトル </title><meta name="description" content="ページの説明"></head>`,
+			want: Preview{Title: "ページの タイトル", Description: "ページの説明"},
+		},
+		{
+			name: "プロパティ名の大文字小文字は区別しない",
+			html: `<head><META PROPERTY="OG:TITLE" CONTENT="大文字のタイトル"></head>`,
+			want: Preview{Title: "大文字のタイトル"},
+		},
+		{
+			name: "http・https以外の画像は使わない",
+			html: `<head><meta property="og:image" content="data:image/png;base64,AAAA"><meta property="og:image:secure_url" content="javascript:alert(1)"></head>`,
+			want: Preview{},
+		},
+		{
+			name: "body以降のmetaタグは読まない",
+			html: `<head><title>タイトル</title></head><body><meta property="og:description" content="本文中のmeta"></body>`,
+			want: Preview{Title: "タイトル"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseHead(strings.NewReader(tt.html), base)
+			if err != nil {
+				t.Fatalf("parseHead: %v", err)
+			}
+			if *got != tt.want {
+				t.Errorf("preview = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseHeadTruncatesLongValues(t *testing.T) {
+	base, _ := url.Parse("https://example.com/")
+	long := strings.Repeat("あ", maxDescriptionLength+10)
+
+	got, err := parseHead(strings.NewReader(`<head><meta property="og:description" content="`+long+`"></head>`), base)
+	if err != nil {
+		t.Fatalf("parseHead: %v", err)
+	}
+	if runes := []rune(got.Description); len(runes) != maxDescriptionLength || runes[len(runes)-1] != '…' {
+		t.Errorf("description = %d文字, want %d文字（末尾に…）", len(runes), maxDescriptionLength)
+	}
+}
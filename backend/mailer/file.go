@@ -0,0 +1,64 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileMailer はメールを送信せず、.emlファイルとしてディレクトリに保存します（ローカル開発用）
+type FileMailer struct {
+	dir  string
+	from string
+}
+
+func NewFileMailer(dir, from string) *FileMailer {
+	if dir == "" {
+		dir = "tmp/mails"
+	}
+	return &FileMailer{dir: dir, from: from}
+}
+
+// Send はメールを<dir>/<日時>-<宛先>.emlに書き出します
+func (m *FileMailer) Send(ctx context.Context, msg Message) error {
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s-%s.eml", time.Now().Format("20060102-150405.000000000"), sanitizeFileName(msg.To))
+	path := filepath.Join(m.dir, name)
+	if err := os.WriteFile(path, buildMessage(m.from, msg), 0o600); err != nil {
+		return err
+	}
+
+	slog.Info("メールをファイルに保存しました", "to", msg.To, "subject", msg.Subject, "path", path)
+	return nil
+}
+
+// LogMailer はメールを送信せず、宛先と件名だけをログに出力します（ローカル開発用）
+// 本文にはパスワード再設定などのトークンが含まれるため、ログには出力しません。本文を確認する場合はFileMailerを使います
+type LogMailer struct{}
+
+func NewLogMailer() *LogMailer {
+	return &LogMailer{}
+}
+
+func (m *LogMailer) Send(ctx context.Context, msg Message) error {
+	slog.Info("メールを送信しました（ログ出力のみ）", "to", msg.To, "subject", msg.Subject)
+	return nil
+}
+
+// sanitizeFileName はメールアドレスをファイル名に使える文字だけに置き換えます
+func sanitizeFileName(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		isAlnum := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+		if !isAlnum && c != '.' && c != '-' && c != '_' && c != '@' {
+			b[i] = '_'
+		}
+	}
+	return string(b)
+}
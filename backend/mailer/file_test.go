@@ -0,0 +1,87 @@
+package mailer
+
+import (
+	"context"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileMailerSend(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mails")
+	m := NewFileMailer(dir, "wiki@example.com")
+
+	err := m.Send(context.Background(), Message{
+		To:      "yamada+test@example.com",
+		Subject: "パスワード再設定のご案内",
+		Body:    "1行目\n2行目\n",
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("保存されたファイル = %v, err = %v", files, err)
+	}
+	if name := filepath.Base(files[0]); !strings.HasSuffix(name, "-yamada_test@example.com.eml") {
+		t.Errorf("ファイル名 = %q", name)
+	}
+	info, err := os.Stat(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("パーミッション = %o, want 600", perm)
+	}
+
+	data, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	header, body, ok := strings.Cut(string(data), "\r\n\r\n")
+	if !ok {
+		t.Fatalf("ヘッダーと本文の区切りがありません: %q", data)
+	}
+	for _, want := range []string{
+		"From: wiki@example.com",
+		"To: yamada+test@example.com",
+		"Content-Type: text/plain; charset=UTF-8",
+	} {
+		if !strings.Contains(header, want+"\r\n") {
+			t.Errorf("ヘッダーに %q がありません:\n%s", want, header)
+		}
+	}
+	var subject string
+	for _, line := range strings.Split(header, "\r\n") {
+		if encoded, ok := strings.CutPrefix(line, "Subject: "); ok {
+			subject, err = new(mime.WordDecoder).DecodeHeader(encoded)
+			if err != nil {
+				t.Fatalf("件名のデコードに失敗しました: %v", err)
+			}
+		}
+	}
+	if subject != "パスワード再設定のご案内" {
+		t.Errorf("件名 = %q", subject)
+	}
+	if body != "1行目\r\n2行目\r\n" {
+		t.Errorf("本文 = %q, want 改行をCRLFにした本文", body)
+	}
+}
+
+func TestFileMailerSendKeepsEveryMessage(t *testing.T) {
+	dir := t.TempDir()
+	m := NewFileMailer(dir, "wiki@example.com")
+
+	for i := 0; i < 3; i++ {
+		if err := m.Send(context.Background(), Message{To: "yamada@example.com", Subject: "件名", Body: "本文"}); err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if len(files) != 3 {
+		t.Errorf("保存されたファイル数 = %d, want 3", len(files))
+	}
+}
@@ -0,0 +1,38 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/yamada-mikiya/team1-hackathon/config"
+)
+
+// Message は送信するメール。本文はプレーンテキスト
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer はメールを送信するインターフェース。設定に応じてSMTP・ファイル・ログの実装を切り替えます
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// New は設定のdriver（smtp, file, log）に応じたMailerを返します
+// 設定の誤りでメールが届かないまま動き続けないよう、未設定・不明なdriverの場合はエラーを返します
+func New(cfg config.MailConfig) (Mailer, error) {
+	switch cfg.Driver {
+	case "smtp":
+		return NewSMTPMailer(cfg.SMTP, cfg.From), nil
+	case "file":
+		return NewFileMailer(cfg.FileDir, cfg.From), nil
+	case "log":
+		return NewLogMailer(), nil
+	case "":
+		return nil, errors.New("メールの送信方式（mail.driver）が設定されていません。smtp, file, log のいずれかを指定してください")
+	default:
+		return nil, fmt.Errorf("不明なメールの送信方式です: %q（smtp, file, log のいずれかを指定してください）", cfg.Driver)
+	}
+}
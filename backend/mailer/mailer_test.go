@@ -0,0 +1,52 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/yamada-mikiya/team1-hackathon/config"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		driver  string
+		wantErr bool
+	}{
+		{"smtp", false},
+		{"file", false},
+		{"log", false},
+		{"", true},
+		{"smpt", true},
+	}
+	for _, tt := range tests {
+		m, err := New(config.MailConfig{Driver: tt.driver})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("New(%q): err = %v, wantErr %v", tt.driver, err, tt.wantErr)
+		}
+		if err == nil && m == nil {
+			t.Errorf("New(%q): Mailerがnilです", tt.driver)
+		}
+	}
+}
+
+func TestLogMailerDoesNotLogBody(t *testing.T) {
+	var buf bytes.Buffer
+	defaultLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, nil)))
+	t.Cleanup(func() { slog.SetDefault(defaultLogger) })
+
+	err := NewLogMailer().Send(context.Background(), Message{
+		To:      "yamada@example.com",
+		Subject: "パスワード再設定のご案内",
+		Body:    "https://example.com/reset-password?token=secret-token",
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if out := buf.String(); strings.Contains(out, "secret-token") || !strings.Contains(out, "yamada@example.com") {
+		t.Errorf("ログの出力 = %q（宛先のみを含み、本文を含まないこと）", out)
+	}
+}
@@ -1,6 +1,9 @@
 package models
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -8,35 +11,263 @@ import (
 
 // Article はブログ記事のモデル
 type Article struct {
-	ID           int       `json:"id" gorm:"primaryKey;autoIncrement"`
-	AuthorID     int       `json:"author_id" gorm:"not null"`
-	ArticleType  string    `json:"article_type" gorm:"type:varchar(50);not null"`
-	Title        string    `json:"title" gorm:"type:varchar(255);not null"`
-	Content      *string   `json:"content" gorm:"type:text"`
-	ExternalURL  *string   `json:"external_url" gorm:"type:text"`
-	ThumbnailURL *string   `json:"thumbnail_url" gorm:"type:text"`
-	Slug         string    `json:"slug" gorm:"type:varchar(255);unique;not null"`
-	Department   string    `json:"department" gorm:"type:varchar(50);not null"`
-	Status       string    `json:"status" gorm:"type:varchar(50);not null;default:draft"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
-	Author       *User     `json:"author,omitempty" gorm:"foreignKey:AuthorID"`
+	ID           int     `json:"id" gorm:"primaryKey;autoIncrement"`
+	AuthorID     int     `json:"author_id" gorm:"not null"`
+	ArticleType  string  `json:"article_type" gorm:"type:varchar(50);not null"`
+	Title        string  `json:"title" gorm:"type:varchar(255);not null"`
+	Content      *string `json:"content" gorm:"type:text"`
+	ExternalURL  *string `json:"external_url" gorm:"type:text"`
+	ThumbnailURL *string `json:"thumbnail_url" gorm:"type:text"`
+	Slug         string  `json:"slug" gorm:"type:varchar(255);unique;not null"`
+	Department   string  `json:"department" gorm:"type:varchar(50);not null"`
+	Status       string  `json:"status" gorm:"type:varchar(50);not null;default:draft"`
+	// PublishAtになったら公開ワーカーがStatusをScheduledStatusに切り替える
+	PublishAt       *time.Time `json:"publish_at" gorm:"type:timestamptz"`
+	ScheduledStatus *string    `json:"scheduled_status" gorm:"type:varchar(50)"`
+	// Markdown記事の本文から保存時に作成するキャッシュ。外部記事ではExcerpt（リンク先のページの概要）以外はNULL
+	ContentHTML        *string `json:"content_html" gorm:"type:text"`
+	TOC                TOC     `json:"toc" gorm:"type:jsonb"`
+	ReadingTimeMinutes *int    `json:"reading_time_minutes"`
+	Excerpt            *string `json:"excerpt" gorm:"type:text"`
+	// 累計の閲覧数（人気順の並べ替えに使う）。閲覧数ワーカーがまとめて加算する
+	ViewCount int64 `json:"view_count" gorm:"not null;default:0"`
+	// 絵文字ごとのリアクション数。リアクションのたびにarticle_reactionsから集計し直す
+	ReactionCounts ReactionCounts `json:"reaction_counts" gorm:"type:jsonb;not null;default:'{}'"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	Author         *User          `json:"author,omitempty" gorm:"foreignKey:AuthorID"`
+	Tags           []Tag          `json:"tags,omitempty" gorm:"many2many:article_tags"`
+	// 閲覧者自身のリアクション。閲覧者を指定して記事を取得した場合のみ読み込む
+	ViewerReactions []ArticleReaction `json:"-" gorm:"foreignKey:ArticleID"`
+	// キーワード検索の関連度。関連度順のカーソル方式で取得した場合のみ読み込む（カーソルの作成に使う）
+	Relevance float64 `json:"-" gorm:"->;-:migration"`
+}
+
+// TOCEntry は記事の目次の項目
+type TOCEntry struct {
+	Level int    `json:"level" example:"2"`
+	Text  string `json:"text" example:"はじめに"`
+	// 本文のHTML(content_html)の見出しに付いたid
+	ID string `json:"id" example:"はじめに"`
+} // @name TOCEntry
+
+// TOC は記事の目次。DBにはJSONで保存します
+type TOC []TOCEntry
+
+// Value はTOCをJSONに変換します（nilの場合はNULL）
+func (t TOC) Value() (driver.Value, error) {
+	if t == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan はDBのJSONをTOCに変換します
+func (t *TOC) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*t = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, t)
+	case string:
+		return json.Unmarshal([]byte(v), t)
+	}
+	return fmt.Errorf("TOCに変換できない型です: %T", value)
+}
+
+// ReactionCounts は絵文字ごとのリアクション数。DBにはJSONで保存します
+type ReactionCounts map[string]int
+
+// Value はReactionCountsをJSONに変換します（nilの場合は空のオブジェクト）
+func (r ReactionCounts) Value() (driver.Value, error) {
+	if r == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan はDBのJSONをReactionCountsに変換します
+func (r *ReactionCounts) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*r = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, r)
+	case string:
+		return json.Unmarshal([]byte(v), r)
+	}
+	return fmt.Errorf("ReactionCountsに変換できない型です: %T", value)
+}
+
+// ArticleReaction は記事へのリアクション。ユーザー・記事・絵文字の組み合わせごとに1つ
+type ArticleReaction struct {
+	ArticleID int       `json:"article_id" gorm:"primaryKey"`
+	UserID    int       `json:"user_id" gorm:"primaryKey"`
+	Emoji     string    `json:"emoji" gorm:"type:varchar(32);primaryKey"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Tag は記事に付けるタグのモデル。IsCategoryがtrueのものはカテゴリとして扱う
+type Tag struct {
+	ID         int       `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name       string    `json:"name" gorm:"type:varchar(255);unique;not null"`
+	IsCategory bool      `json:"is_category" gorm:"not null;default:false"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
 }
 
 // User はユーザーのモデル
 type User struct {
-	ID           int       `json:"id" gorm:"primaryKey;autoIncrement"`
-	Name         string    `json:"name" gorm:"type:varchar(255);not null"`
-	Email        string    `json:"email" gorm:"type:varchar(255);unique;not null"`
-	Affiliation  *string   `json:"affiliation" gorm:"type:varchar(255)"`
-	PasswordHash string    `json:"-" gorm:"type:varchar(255);not null"`
-	IconURL      *string   `json:"icon_url" gorm:"type:text"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID              int        `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name            string     `json:"name" gorm:"type:varchar(255);not null"`
+	Email           string     `json:"email" gorm:"type:varchar(255);unique;not null"`
+	Affiliation     *string    `json:"affiliation" gorm:"type:varchar(255)"`
+	Department      *string    `json:"department" gorm:"type:varchar(50)"`
+	Role            string     `json:"role" gorm:"type:varchar(20);not null;default:writer"`
+	PasswordHash    string     `json:"-" gorm:"type:varchar(255);not null"`
+	IconURL         *string    `json:"icon_url" gorm:"type:text"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// ArticleRevision は記事の保存履歴。記事を保存するたびに1件追加される
+type ArticleRevision struct {
+	ID             int       `json:"id" gorm:"primaryKey;autoIncrement"`
+	ArticleID      int       `json:"article_id" gorm:"not null"`
+	RevisionNumber int       `json:"revision_number" gorm:"not null"`
+	EditorID       *int      `json:"editor_id"`
+	Title          string    `json:"title" gorm:"type:varchar(255);not null"`
+	Content        *string   `json:"content" gorm:"type:text"`
+	Status         string    `json:"status" gorm:"type:varchar(50);not null"`
+	CreatedAt      time.Time `json:"created_at"`
+	Editor         *User     `json:"editor,omitempty" gorm:"foreignKey:EditorID"`
+}
+
+// ArticleReview は記事に割り当てられたレビュアーと、そのレビュー状態
+type ArticleReview struct {
+	ID         int       `json:"id" gorm:"primaryKey;autoIncrement"`
+	ArticleID  int       `json:"article_id" gorm:"not null"`
+	ReviewerID int       `json:"reviewer_id" gorm:"not null"`
+	State      string    `json:"state" gorm:"type:varchar(50);not null;default:pending"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	Reviewer   *User     `json:"reviewer,omitempty" gorm:"foreignKey:ReviewerID"`
+}
+
+// ArticleReviewComment はレビュー中のコメント。承認・修正依頼と同時に書かれたものはActionを持つ
+type ArticleReviewComment struct {
+	ID        int       `json:"id" gorm:"primaryKey;autoIncrement"`
+	ArticleID int       `json:"article_id" gorm:"not null"`
+	UserID    int       `json:"user_id" gorm:"not null"`
+	Action    *string   `json:"action" gorm:"type:varchar(50)"`
+	Body      string    `json:"body" gorm:"type:text;not null"`
+	CreatedAt time.Time `json:"created_at"`
+	User      *User     `json:"user,omitempty" gorm:"foreignKey:UserID"`
 }
 
+// ArticleComment は記事へのコメント。ParentIDがあるものは返信（返信への返信はできない）
+// 削除・非表示にしたコメントも、返信のスレッドを保つため行は残す
+type ArticleComment struct {
+	ID        int    `json:"id" gorm:"primaryKey;autoIncrement"`
+	ArticleID int    `json:"article_id" gorm:"not null"`
+	UserID    int    `json:"user_id" gorm:"not null"`
+	ParentID  *int   `json:"parent_id"`
+	Body      string `json:"body" gorm:"type:text;not null"`
+	// 本文のMarkdownから保存時に作成するサニタイズ済みのHTML
+	BodyHTML  string     `json:"body_html" gorm:"column:body_html;type:text;not null"`
+	EditedAt  *time.Time `json:"edited_at"`
+	DeletedAt *time.Time `json:"deleted_at"` // 著者が削除した日時
+	HiddenAt  *time.Time `json:"hidden_at"`  // 記事の著者・管理者が非表示にした日時
+	HiddenBy  *int       `json:"hidden_by"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	User      *User      `json:"user,omitempty" gorm:"foreignKey:UserID"`
+}
+
+// ArticlePreviewToken は下書きプレビュー用リンクの発行履歴
+// トークン本体(JWT)は保存せず、jtiをIDとして失効状態のみを管理する
+type ArticlePreviewToken struct {
+	ID        string     `json:"id" gorm:"type:varchar(64);primaryKey"`
+	ArticleID int        `json:"article_id" gorm:"not null"`
+	CreatedBy int        `json:"created_by" gorm:"not null"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// RevokedToken はログアウト等で失効させたJWT。トークンの有効期限まで保持する
+type RevokedToken struct {
+	JTI       string    `json:"jti" gorm:"column:jti;type:varchar(64);primaryKey"`
+	UserID    *int      `json:"user_id"`
+	ExpiresAt time.Time `json:"expires_at" gorm:"not null"`
+	RevokedAt time.Time `json:"revoked_at" gorm:"autoCreateTime"`
+}
+
+// RefreshToken はアクセストークンの再発行に使うリフレッシュトークン。トークン本体はハッシュ化して保存する
+type RefreshToken struct {
+	ID        int        `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID    int        `json:"user_id" gorm:"not null"`
+	FamilyID  string     `json:"family_id" gorm:"type:varchar(64);not null"`
+	TokenHash string     `json:"-" gorm:"type:varchar(64);unique;not null"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null"`
+	UsedAt    *time.Time `json:"used_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// PasswordResetToken はパスワード再設定用の一度きりのトークン。トークン本体はハッシュ化して保存する
+type PasswordResetToken struct {
+	ID        int        `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID    int        `json:"user_id" gorm:"not null"`
+	TokenHash string     `json:"-" gorm:"type:varchar(64);unique;not null"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// ArticleHourlyView は記事の1時間ごとの閲覧数。トレンドの集計に使い、集計期間を過ぎた行は削除する
+type ArticleHourlyView struct {
+	ArticleID int       `json:"article_id" gorm:"primaryKey"`
+	HourStart time.Time `json:"hour_start" gorm:"primaryKey"`
+	Views     int64     `json:"views" gorm:"not null;default:0"`
+}
+
+// ユーザーのロール
+const (
+	RoleAdmin  = "admin"  // すべての記事とユーザーを管理できる
+	RoleEditor = "editor" // 所属部署の記事を管理できる
+	RoleWriter = "writer" // 自分の記事を執筆・編集できる
+	RoleReader = "reader" // 記事の閲覧のみ
+)
+
 // JwtCustomClaims はJWTのカスタムクレーム
 type JwtCustomClaims struct {
-	UserID int `json:"user_id"`
+	UserID     int     `json:"user_id"`
+	Role       string  `json:"role"`
+	Department *string `json:"department,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// EmailVerificationClaims はメールアドレス確認用JWTのクレーム。発行時のメールアドレスに対してのみ有効
+type EmailVerificationClaims struct {
+	UserID int    `json:"user_id"`
+	Email  string `json:"email"`
+	jwt.RegisteredClaims
+}
+
+// PreviewClaims は下書きプレビュー用JWTのクレーム。1つの記事にのみ有効
+type PreviewClaims struct {
+	ArticleID int `json:"article_id"`
 	jwt.RegisteredClaims
 }
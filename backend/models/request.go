@@ -1,14 +1,121 @@
 package models
 
+import "time"
+
 // AuthenticateRequest はログインリクエスト
 type AuthenticateRequest struct {
-        Email    string `json:"email" validate:"required,email" example:"user@example.com"`
-        Password string `json:"password" validate:"required,min=8" example:"password123"`
+	Email    string `json:"email" validate:"required,email" example:"user@example.com"`
+	Password string `json:"password" validate:"required,min=8" example:"password123"`
 } // @name AuthenticateRequest
 
 // SignUpRequest はサインアップリクエスト
 type SignUpRequest struct {
-        Email    string `json:"email" validate:"required,email" example:"user@example.com"`
-        Password string `json:"password" validate:"required,min=8" example:"password123"`
-        Name     string `json:"name" validate:"required" example:"山田太郎"`
+	Email    string `json:"email" validate:"required,email" example:"user@example.com"`
+	Password string `json:"password" validate:"required,min=8" example:"password123"`
+	Name     string `json:"name" validate:"required" example:"山田太郎"`
 } // @name SignUpRequest
+
+// RefreshRequest はアクセストークンの再発行リクエスト
+// ブラウザからはCookieのリフレッシュトークンが使われるため、ボディは省略できます
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token,omitempty" example:"3q2-7wEjRk..."`
+} // @name RefreshRequest
+
+// ForgotPasswordRequest はパスワードリセットメールの送信リクエスト
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email" example:"user@example.com"`
+} // @name ForgotPasswordRequest
+
+// ResetPasswordRequest はパスワードの再設定リクエスト
+type ResetPasswordRequest struct {
+	Token    string `json:"token" validate:"required" example:"3q2-7wEjRk..."`
+	Password string `json:"password" validate:"required,min=8" example:"newpassword123"`
+} // @name ResetPasswordRequest
+
+// UpdateProfileRequest はプロフィール更新のリクエスト（指定した項目だけを更新します）
+type UpdateProfileRequest struct {
+	Name        *string `json:"name,omitempty" example:"山田太郎"`
+	Affiliation *string `json:"affiliation,omitempty" example:"開発部 バックエンドチーム"`
+} // @name UpdateProfileRequest
+
+// ChangePasswordRequest はパスワード変更のリクエスト
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required" example:"password123"`
+	NewPassword     string `json:"new_password" validate:"required,min=8" example:"newpassword123"`
+} // @name ChangePasswordRequest
+
+// VerifyEmailRequest はメールアドレス確認リクエスト
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+} // @name VerifyEmailRequest
+
+// CreateArticleRequest は記事作成・置き換え(PUT)リクエスト
+type CreateArticleRequest struct {
+	// external記事では省略でき、その場合はリンク先のページのタイトル（og:title）を使う
+	Title        string   `json:"title" example:"Go言語でのAPI開発入門"`
+	ArticleType  string   `json:"article_type" validate:"required" example:"markdown" enums:"markdown,external"`
+	Content      *string  `json:"content,omitempty" example:"記事の本文です..."`
+	ExternalURL  *string  `json:"external_url,omitempty" example:"https://example.com/article"`
+	ThumbnailURL *string  `json:"thumbnail_url,omitempty" example:"https://example.com/thumbnail.jpg"`
+	Slug         string   `json:"slug" validate:"required" example:"go-api-development"`
+	Department   string   `json:"department" validate:"required" example:"Dev" enums:"Dev,MKT,Ops"`
+	Status       string   `json:"status,omitempty" example:"draft" enums:"draft,internal,public"`
+	Tags         []string `json:"tags,omitempty" example:"Go,Backend"`
+	// 予約公開。publish_atになるとstatusがscheduled_statusに切り替わる（両方の指定が必要）
+	PublishAt       *time.Time `json:"publish_at,omitempty" example:"2026-01-10T09:00:00+09:00"`
+	ScheduledStatus *string    `json:"scheduled_status,omitempty" example:"public" enums:"internal,public"`
+} // @name CreateArticleRequest
+
+// PatchArticleRequest は記事の部分更新(PATCH)リクエスト。指定された項目のみ更新します
+type PatchArticleRequest struct {
+	Title        *string   `json:"title,omitempty" example:"Go言語でのAPI開発入門"`
+	ArticleType  *string   `json:"article_type,omitempty" example:"markdown" enums:"markdown,external"`
+	Content      *string   `json:"content,omitempty" example:"記事の本文です..."`
+	ExternalURL  *string   `json:"external_url,omitempty" example:"https://example.com/article"`
+	ThumbnailURL *string   `json:"thumbnail_url,omitempty" example:"https://example.com/thumbnail.jpg"`
+	Slug         *string   `json:"slug,omitempty" example:"go-api-development"`
+	Department   *string   `json:"department,omitempty" example:"Dev" enums:"Dev,MKT,Ops"`
+	Status       *string   `json:"status,omitempty" example:"public" enums:"draft,internal,public"`
+	Tags         *[]string `json:"tags,omitempty" example:"Go,Backend"`
+	// 予約公開。scheduled_statusに空文字を指定すると予約を取り消す
+	PublishAt       *time.Time `json:"publish_at,omitempty" example:"2026-01-10T09:00:00+09:00"`
+	ScheduledStatus *string    `json:"scheduled_status,omitempty" example:"public" enums:"internal,public"`
+} // @name PatchArticleRequest
+
+// SubmitReviewRequest はレビュー依頼リクエスト
+type SubmitReviewRequest struct {
+	ReviewerIDs []int   `json:"reviewer_ids" validate:"required" example:"2,4"`
+	Comment     *string `json:"comment,omitempty" example:"公開前に確認をお願いします"`
+} // @name SubmitReviewRequest
+
+// ReviewCommentRequest はレビューコメント・修正依頼のリクエスト
+type ReviewCommentRequest struct {
+	Comment string `json:"comment" example:"サンプルコードの説明を追加してください"`
+} // @name ReviewCommentRequest
+
+// ApproveReviewRequest は承認リクエスト
+type ApproveReviewRequest struct {
+	// 確認した内容のリビジョン番号。記事の最新のリビジョンでない場合は承認できません
+	RevisionNumber int    `json:"revision_number" validate:"required" example:"3"`
+	Comment        string `json:"comment" example:"問題ありません"`
+} // @name ApproveReviewRequest
+
+// CreateCommentRequest は記事へのコメント・返信のリクエスト
+type CreateCommentRequest struct {
+	// Markdownで記述します（最大5000文字）
+	Body string `json:"body" example:"参考になりました。**sqlc** との比較も気になります"`
+	// 返信する場合は返信先のコメントのID（返信への返信はできません）
+	ParentID *int `json:"parent_id,omitempty" example:"1"`
+} // @name CreateCommentRequest
+
+// UpdateCommentRequest はコメントの編集リクエスト
+type UpdateCommentRequest struct {
+	Body string `json:"body" example:"参考になりました。**sqlc** との比較も気になります（追記）"`
+} // @name UpdateCommentRequest
+
+// UpdateUserRoleRequest はユーザーのロール・所属部署の変更リクエスト（管理者用）
+// 省略した項目は変更しません。departmentに空文字を指定すると所属部署を解除します
+type UpdateUserRoleRequest struct {
+	Role       *string `json:"role,omitempty" example:"editor" enums:"admin,editor,writer,reader"`
+	Department *string `json:"department,omitempty" example:"Dev" enums:"Dev,MKT,Ops"`
+} // @name UpdateUserRoleRequest
@@ -3,31 +3,74 @@ package models
 import "time"
 
 // ArticleListResponse は記事一覧取得のレスポンス
+// pageを指定した場合はページ番号、省略した場合はカーソル（next_cursor/prev_cursor）でページを移動します
 type ArticleListResponse struct {
-	Articles   []ArticleResponse `json:"articles"`
-	TotalCount int               `json:"total_count" example:"100"`
-	Page       int               `json:"page" example:"1"`
-	Limit      int               `json:"limit" example:"10"`
-	TotalPages int               `json:"total_pages" example:"10"`
+	Articles []ArticleResponse `json:"articles"`
+	// 総件数。ページ番号方式の場合か、カーソル方式でinclude_total=trueの場合のみ返します
+	TotalCount *int `json:"total_count,omitempty" example:"100"`
+	// ページ番号方式の場合のみ返します
+	Page       int  `json:"page,omitempty" example:"1"`
+	Limit      int  `json:"limit" example:"10"`
+	TotalPages *int `json:"total_pages,omitempty" example:"10"`
+	// 次のページ（新着順ではより古い記事）のカーソル。カーソル方式の場合のみ返し、次のページがない場合は省略します
+	NextCursor *string `json:"next_cursor,omitempty" example:"eyJjIjoiMjAyNi0wMS0wNlQxMjowMDowMFoiLCJpIjo0Mn0"`
+	// 前のページ（新着順ではより新しい記事）のカーソル。最初のページでは省略します
+	PrevCursor *string `json:"prev_cursor,omitempty" example:"eyJjIjoiMjAyNi0wMS0wOFQxMjowMDowMFoiLCJpIjo1MCwiYiI6dHJ1ZX0"`
 } // @name ArticleListResponse
 
+// TrendingArticlesResponse は部署ごとのトレンド記事のレスポンス
+type TrendingArticlesResponse struct {
+	Departments []DepartmentTrendingArticles `json:"departments"`
+} // @name TrendingArticlesResponse
+
+// DepartmentTrendingArticles は1つの部署のトレンド記事（スコアの高い順）
+type DepartmentTrendingArticles struct {
+	Department string            `json:"department" example:"Dev" enums:"Dev,MKT,Ops"`
+	Articles   []ArticleResponse `json:"articles"`
+} // @name DepartmentTrendingArticles
+
 // ArticleResponse は記事の詳細レスポンス
 type ArticleResponse struct {
-	ID           int            `json:"id" example:"1"`
-	Title        string         `json:"title" example:"Go言語でのAPI開発入門"`
-	ArticleType  string         `json:"article_type" example:"markdown" enums:"markdown,external"`
-	Content      *string        `json:"content,omitempty" example:"記事の本文です..."`
-	ExternalURL  *string        `json:"external_url,omitempty" example:"https://example.com/article"`
-	ThumbnailURL *string        `json:"thumbnail_url,omitempty" example:"https://example.com/thumbnail.jpg"`
-	Slug         string         `json:"slug" example:"go-api-development"`
-	Department   string         `json:"department" example:"Dev" enums:"Dev,MKT,Ops"`
-	Status       string         `json:"status" example:"public" enums:"draft,internal,public"`
-	Author       AuthorResponse `json:"author"`
-	CreatedAt    time.Time      `json:"created_at" example:"2026-01-06T12:00:00Z"`
-	UpdatedAt    time.Time      `json:"updated_at" example:"2026-01-06T12:00:00Z"`
-	Tags         []string       `json:"tags" example:"Go,Backend,Echo"`
+	ID              int            `json:"id" example:"1"`
+	Title           string         `json:"title" example:"Go言語でのAPI開発入門"`
+	ArticleType     string         `json:"article_type" example:"markdown" enums:"markdown,external"`
+	Content         *string        `json:"content,omitempty" example:"記事の本文です..."`
+	ExternalURL     *string        `json:"external_url,omitempty" example:"https://example.com/article"`
+	ThumbnailURL    *string        `json:"thumbnail_url,omitempty" example:"https://example.com/thumbnail.jpg"`
+	Slug            string         `json:"slug" example:"go-api-development"`
+	Department      string         `json:"department" example:"Dev" enums:"Dev,MKT,Ops"`
+	Status          string         `json:"status" example:"public" enums:"draft,internal,public"`
+	PublishAt       *time.Time     `json:"publish_at,omitempty" example:"2026-01-10T09:00:00+09:00"`
+	ScheduledStatus *string        `json:"scheduled_status,omitempty" example:"public" enums:"internal,public"`
+	Author          AuthorResponse `json:"author"`
+	CreatedAt       time.Time      `json:"created_at" example:"2026-01-06T12:00:00Z"`
+	UpdatedAt       time.Time      `json:"updated_at" example:"2026-01-06T12:00:00Z"`
+	Tags            []string       `json:"tags" example:"Go,Backend,Echo"`
+	Snippet         string         `json:"snippet,omitempty" example:"…<mark>React</mark> Hooksの基本から応用まで…"`
+	// サニタイズ済みの本文HTML。?include=content_html を指定した場合のみ返します
+	ContentHTML *string `json:"content_html,omitempty" example:"<h2 id=\"はじめに\">はじめに</h2>\n<p>記事の本文です...</p>"`
+	// 本文の見出し（h1〜h4）から作成した目次。idはcontent_htmlの見出しのidと一致します
+	TOC []TOCEntry `json:"toc,omitempty"`
+	// 読了時間の目安（分）
+	ReadingTimeMinutes *int `json:"reading_time_minutes,omitempty" example:"3"`
+	// 本文の先頭をプレーンテキストにした抜粋（最大120文字）
+	Excerpt *string `json:"excerpt,omitempty" example:"記事の本文です..."`
+	// 累計の閲覧数。閲覧は数秒〜数十秒ごとにまとめて反映されます
+	ViewCount int64 `json:"view_count" example:"128"`
+	// 絵文字ごとのリアクション数（リアクションがない絵文字は含みません）
+	ReactionCounts map[string]int `json:"reaction_counts"`
+	// 閲覧者自身が付けたリアクション。ログインしている場合のみ返します
+	MyReactions []string `json:"my_reactions,omitempty" example:"thumbs_up,heart"`
 } // @name ArticleResponse
 
+// ReactionsResponse はリアクションを追加・取り消した後の記事のリアクション
+type ReactionsResponse struct {
+	// 絵文字ごとのリアクション数（リアクションがない絵文字は含みません）
+	ReactionCounts map[string]int `json:"reaction_counts"`
+	// ログイン中のユーザーが付けたリアクション
+	MyReactions []string `json:"my_reactions" example:"thumbs_up,heart"`
+} // @name ReactionsResponse
+
 // AuthorResponse は記事の著者情報
 type AuthorResponse struct {
 	ID          int     `json:"id" example:"1"`
@@ -44,15 +87,160 @@ type ErrorResponse struct {
 
 // UserResponse は認証後のユーザー情報
 type UserResponse struct {
-	ID          int     `json:"id" example:"1"`
-	Name        string  `json:"name" example:"山田太郎"`
-	Email       string  `json:"email" example:"user@example.com"`
-	Affiliation *string `json:"affiliation,omitempty" example:"開発部"`
-	IconURL     *string `json:"icon_url,omitempty" example:"https://example.com/icon.jpg"`
+	ID            int     `json:"id" example:"1"`
+	Name          string  `json:"name" example:"山田太郎"`
+	Email         string  `json:"email" example:"user@example.com"`
+	Affiliation   *string `json:"affiliation,omitempty" example:"開発部"`
+	Department    *string `json:"department,omitempty" example:"Dev" enums:"Dev,MKT,Ops"`
+	Role          string  `json:"role" example:"writer" enums:"admin,editor,writer,reader"`
+	IconURL       *string `json:"icon_url,omitempty" example:"https://example.com/icon.jpg"`
+	EmailVerified bool    `json:"email_verified" example:"true"`
 } // @name UserResponse
 
+// UserProfileResponse はユーザーの公開プロフィール
+// 記事数と最近の記事は閲覧者が見られる記事（ゲストはpublicのみ）だけを数えます
+type UserProfileResponse struct {
+	ID             int               `json:"id" example:"1"`
+	Name           string            `json:"name" example:"山田太郎"`
+	Affiliation    *string           `json:"affiliation,omitempty" example:"開発部"`
+	Department     *string           `json:"department,omitempty" example:"Dev" enums:"Dev,MKT,Ops"`
+	IconURL        *string           `json:"icon_url,omitempty" example:"https://example.com/icon.jpg"`
+	ArticleCount   int               `json:"article_count" example:"12"`
+	RecentArticles []ArticleResponse `json:"recent_articles"`
+} // @name UserProfileResponse
+
+// UserListResponse はユーザー一覧取得のレスポンス（管理者用）
+type UserListResponse struct {
+	Users      []UserResponse `json:"users"`
+	TotalCount int            `json:"total_count" example:"100"`
+	Page       int            `json:"page" example:"1"`
+	Limit      int            `json:"limit" example:"10"`
+	TotalPages int            `json:"total_pages" example:"10"`
+} // @name UserListResponse
+
 // AuthResponse はサインアップ・ログインレスポンス
 type AuthResponse struct {
-	Token string       `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
-	User  UserResponse `json:"user"`
+	Token          string       `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	TokenExpiresAt time.Time    `json:"token_expires_at" example:"2026-01-01T09:15:00+09:00"`
+	RefreshToken   string       `json:"refresh_token" example:"3q2-7wEjRk..."`
+	User           UserResponse `json:"user"`
 } // @name AuthResponse
+
+// TagResponse はタグ（カテゴリ）と閲覧可能な記事数
+type TagResponse struct {
+	ID           int    `json:"id" example:"1"`
+	Name         string `json:"name" example:"Go"`
+	IsCategory   bool   `json:"is_category" example:"false"`
+	ArticleCount int    `json:"article_count" example:"12"`
+} // @name TagResponse
+
+// PreviewTokenResponse は下書きプレビューリンクの情報
+// tokenとpreview_pathは発行時のレスポンスにのみ含まれます
+type PreviewTokenResponse struct {
+	ID          string     `json:"id" example:"3f2a9c1e8b7d4a6f9e0c1b2a3d4e5f60"`
+	Token       string     `json:"token,omitempty" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	PreviewPath string     `json:"preview_path,omitempty" example:"/api/articles/go-api-development?preview_token=eyJhbGciOi..."`
+	ExpiresAt   time.Time  `json:"expires_at" example:"2026-01-09T12:00:00Z"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty" example:"2026-01-07T12:00:00Z"`
+	CreatedAt   time.Time  `json:"created_at" example:"2026-01-06T12:00:00Z"`
+} // @name PreviewTokenResponse
+
+// RevisionResponse は記事のリビジョン
+// contentは単一リビジョンの取得時のみ含まれます
+type RevisionResponse struct {
+	RevisionNumber int             `json:"revision_number" example:"3"`
+	Title          string          `json:"title" example:"Go言語でのAPI開発入門"`
+	Content        *string         `json:"content,omitempty" example:"記事の本文です..."`
+	Status         string          `json:"status" example:"draft" enums:"draft,internal,public"`
+	Editor         *AuthorResponse `json:"editor,omitempty"`
+	CreatedAt      time.Time       `json:"created_at" example:"2026-01-06T12:00:00Z"`
+} // @name RevisionResponse
+
+// RevisionDiffResponse は2つのリビジョンの行単位の差分
+type RevisionDiffResponse struct {
+	From      int        `json:"from" example:"1"`
+	To        int        `json:"to" example:"3"`
+	FromTitle string     `json:"from_title" example:"Go言語でのAPI開発入門"`
+	ToTitle   string     `json:"to_title" example:"Go言語でのAPI開発入門（改訂版）"`
+	Lines     []DiffLine `json:"lines"`
+} // @name RevisionDiffResponse
+
+// DiffLine は差分の1行。old_line/new_lineは該当しない場合0になります
+type DiffLine struct {
+	Type    string `json:"type" example:"insert" enums:"equal,insert,delete"`
+	Text    string `json:"text" example:"## useState"`
+	OldLine int    `json:"old_line" example:"0"`
+	NewLine int    `json:"new_line" example:"12"`
+} // @name DiffLine
+
+// ReviewStatusResponse は記事のレビュー状況
+type ReviewStatusResponse struct {
+	ArticleStatus string `json:"article_status" example:"in_review" enums:"draft,in_review,internal,public"`
+	Department    string `json:"department" example:"Dev" enums:"Dev,MKT,Ops"`
+	CanPublish    bool   `json:"can_publish" example:"false"`
+	// 記事の最新のリビジョン番号。承認するときはこの番号（確認した内容のリビジョン番号）を指定します
+	RevisionNumber int                     `json:"revision_number" example:"3"`
+	Reviewers      []ReviewerResponse      `json:"reviewers"`
+	Comments       []ReviewCommentResponse `json:"comments"`
+} // @name ReviewStatusResponse
+
+// ReviewerResponse はレビュアーとそのレビュー状態
+type ReviewerResponse struct {
+	Reviewer   AuthorResponse `json:"reviewer"`
+	Department *string        `json:"department,omitempty" example:"Dev" enums:"Dev,MKT,Ops"`
+	State      string         `json:"state" example:"approved" enums:"pending,approved,changes_requested"`
+	UpdatedAt  time.Time      `json:"updated_at" example:"2026-01-06T12:00:00Z"`
+} // @name ReviewerResponse
+
+// ReviewCommentResponse はレビューコメント
+type ReviewCommentResponse struct {
+	ID        int            `json:"id" example:"1"`
+	User      AuthorResponse `json:"user"`
+	Action    *string        `json:"action,omitempty" example:"changes_requested" enums:"approved,changes_requested"`
+	Body      string         `json:"body" example:"サンプルコードの説明を追加してください"`
+	CreatedAt time.Time      `json:"created_at" example:"2026-01-06T12:00:00Z"`
+} // @name ReviewCommentResponse
+
+// CommentListResponse は記事のコメント一覧（返信でないコメントを古い順にページ分けし、それぞれの返信を含めます）
+type CommentListResponse struct {
+	Comments   []CommentResponse `json:"comments"`
+	TotalCount int               `json:"total_count" example:"25"`
+	Page       int               `json:"page" example:"1"`
+	Limit      int               `json:"limit" example:"20"`
+	TotalPages int               `json:"total_pages" example:"2"`
+} // @name CommentListResponse
+
+// CommentResponse は記事へのコメント
+// 削除・非表示のコメントはスレッドを保つために返しますが、本文と投稿者は含めません（非表示のコメントは記事の著者・管理者・投稿者本人には本文も返します）
+type CommentResponse struct {
+	ID       int             `json:"id" example:"1"`
+	ParentID *int            `json:"parent_id,omitempty" example:"1"`
+	User     *AuthorResponse `json:"user,omitempty"`
+	// Markdownの本文
+	Body *string `json:"body,omitempty" example:"参考になりました。**sqlc** との比較も気になります"`
+	// サニタイズ済みの本文HTML
+	BodyHTML  *string    `json:"body_html,omitempty" example:"<p>参考になりました。<strong>sqlc</strong> との比較も気になります</p>"`
+	Deleted   bool       `json:"deleted" example:"false"`
+	Hidden    bool       `json:"hidden" example:"false"`
+	EditedAt  *time.Time `json:"edited_at,omitempty" example:"2026-01-06T13:00:00Z"`
+	CreatedAt time.Time  `json:"created_at" example:"2026-01-06T12:00:00Z"`
+	// 返信（古い順）。返信でないコメントのみ
+	Replies []CommentResponse `json:"replies,omitempty"`
+} // @name CommentResponse
+
+// MediaResponse はアップロードした画像のURL。variantsは幅の小さい順に並びます
+type MediaResponse struct {
+	ID          string                 `json:"id" example:"3f2a9c0d1e4b5a6c7d8e9f0a1b2c3d4e"`
+	URL         string                 `json:"url" example:"/media/images/3f2a9c0d1e4b5a6c7d8e9f0a1b2c3d4e/original.jpg"`
+	ContentType string                 `json:"content_type" example:"image/jpeg"`
+	Width       int                    `json:"width" example:"1920"`
+	Height      int                    `json:"height" example:"1080"`
+	Variants    []MediaVariantResponse `json:"variants"`
+} // @name MediaResponse
+
+// MediaVariantResponse は縮小した画像のURL
+type MediaVariantResponse struct {
+	URL    string `json:"url" example:"/media/images/3f2a9c0d1e4b5a6c7d8e9f0a1b2c3d4e/w640.jpg"`
+	Width  int    `json:"width" example:"640"`
+	Height int    `json:"height" example:"360"`
+} // @name MediaVariantResponse
@@ -1,21 +1,102 @@
 package repositories
 
 import (
-	"fmt"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/yamada-mikiya/team1-hackathon/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrLoginRequired はゲスト（メールアドレス未確認のユーザーを含む）が内部公開記事にアクセスしようとした場合のエラー
+var ErrLoginRequired = errors.New("内部公開記事にアクセスするにはログインとメールアドレスの確認が必要です")
+
 type ArticleRepository interface {
 	FindAll(filters ArticleFilters, page, limit int) ([]models.Article, int64, error)
-	FindBySlug(slug string, isAuthenticated bool) (*models.Article, error)
+	FindAllByCursor(filters ArticleFilters, cursor *ArticleCursor, limit int) ([]models.Article, bool, error)
+	Count(filters ArticleFilters) (int64, error)
+	FindPopular(filters ArticleFilters, limit int) ([]models.Article, error)
+	FindTrending(filters ArticleFilters, now time.Time, halfLife time.Duration, limitPerDepartment int) ([]models.Article, error)
+	FindBySlug(slug string, userID int) (*models.Article, error)
+	FindBySlugAnyStatus(slug string) (*models.Article, error)
+	Create(article *models.Article, editorID int) error
+	Update(article *models.Article, editorID int) error
+	UpdateAndResetApprovals(article *models.Article, editorID int) error
+	FindUnrendered(afterID, limit int) ([]models.Article, error)
+	UpdateRenderedContent(article *models.Article) error
+	Delete(id int) error
+	PublishDueArticles(now time.Time, limit int) ([]models.Article, error)
+	CountPublic() (int64, error)
+	FindPublicSitemapEntries(offset, limit int) ([]models.Article, error)
 }
 
 type ArticleFilters struct {
-	Department      string
-	Status          string
-	IsAuthenticated bool
+	Departments   []string // 部署（いずれかに一致する記事。空の場合は絞り込まない）
+	ArticleTypes  []string // 記事の種類（いずれかに一致する記事。空の場合は絞り込まない）
+	Status        string   // internal, public, all（空の場合はall）
+	Tag           string
+	AuthorID      int        // 著者のユーザーID（0の場合は絞り込まない）
+	CreatedAfter  *time.Time // この日時以降に作成された記事
+	CreatedBefore *time.Time // この日時より前に作成された記事
+	Query         string     // 検索文字列（サービス層でKeywordsに分割する）
+	Keywords      []string   // タイトル・本文に対するキーワード検索（すべてを含む記事のみ）
+	Sort          string     // 並び順のキー（created_at, updated_at, title, popularity）。空の場合はキーワード検索時は関連度順、それ以外は新着順
+	Order         string     // asc または desc（空の場合はtitleは昇順、それ以外は降順）
+	ViewerID      int        // 閲覧者のユーザーID（ゲストの場合は0）
+}
+
+// articleSortColumns は並び順のキーと並べ替えに使うカラム
+var articleSortColumns = map[string]string{
+	"created_at": "articles.created_at",
+	"updated_at": "articles.updated_at",
+	"title":      "articles.title",
+	"popularity": "articles.view_count",
+}
+
+// bigramCondition はタイトルか本文に2文字のキーワードを含む（大文字・小文字を区別しない）ことを表す条件です
+// idx_articles_bigramsを使えるよう、インデックスと同じ式で比較します
+const bigramCondition = "article_bigrams(articles.title || ' ' || COALESCE(articles.content, '')) @> ARRAY[lower(?)]"
+
+// relevanceSQL はキーワード検索の関連度（タイトルの一致を本文より重視）。2つの?にはどちらも空白区切りのキーワードを渡します
+// カーソルに保存した値と比較できるよう、double precisionで計算します
+const relevanceSQL = "(word_similarity(?, articles.title) * 2 + word_similarity(?, COALESCE(articles.content, '')))::float8"
+
+// rankedByRelevance は並び順の指定がないキーワード検索（関連度順）かを返します
+func (f ArticleFilters) rankedByRelevance() bool {
+	return len(f.Keywords) > 0 && f.Sort == ""
+}
+
+// relevanceVars はrelevanceSQLに渡す値を返します
+func (f ArticleFilters) relevanceVars() []any {
+	keywords := strings.Join(f.Keywords, " ")
+	return []any{keywords, keywords}
+}
+
+// sortColumn は並べ替えに使うカラムと降順かどうかを返します（不明なキーの場合は新着順）
+// 値が同じ記事の順序が変わらないよう、呼び出し側でIDも同じ向きに並べます
+func (f ArticleFilters) sortColumn() (string, bool) {
+	column, ok := articleSortColumns[f.Sort]
+	if !ok {
+		return "articles.created_at", true
+	}
+	switch f.Order {
+	case "asc":
+		return column, false
+	case "desc":
+		return column, true
+	}
+	return column, f.Sort != "title"
+}
+
+// ArticleCursor はキーセットページネーションの位置（直前に取得した記事の並べ替えに使うカラムの値とID）
+type ArticleCursor struct {
+	Value  any
+	ID     int
+	Before bool // trueの場合はこの位置より前のページを取得する
 }
 
 type articleRepository struct {
@@ -26,60 +107,238 @@ func NewArticleRepository(db *gorm.DB) ArticleRepository {
 	return &articleRepository{db: db}
 }
 
-// FindAll はフィルタとページネーションを適用して記事一覧を取得します
-func (r *articleRepository) FindAll(filters ArticleFilters, page, limit int) ([]models.Article, int64, error) {
-	var articles []models.Article
-	var totalCount int64
-
-	// クエリを構築
-	query := r.db.Model(&models.Article{}).Preload("Author")
+// memberCondition は閲覧者がメンバー（メールアドレス確認済みのユーザー）であることを表す条件です
+// メールアドレスを確認していないユーザーはゲストと同じ扱いになります
+const memberCondition = "EXISTS (SELECT 1 FROM users WHERE users.id = ? AND users.email_verified_at IS NOT NULL)"
 
-	// フィルタを適用
-	if filters.Department != "" {
-		query = query.Where("department = ?", filters.Department)
-	}
+// visibleArticles は閲覧者がメンバーかどうかに応じてarticlesのステータスを絞り込むスコープです
+// 記事一覧だけでなく、タグごとの記事数など記事を数える処理でも同じルールを使います
+func visibleArticles(filters ArticleFilters) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		// ゲストの場合：強制的にpublicのみ
+		if filters.ViewerID == 0 {
+			return db.Where("articles.status = ?", "public")
+		}
 
-	// ゲストの場合：強制的にpublicのみ
-	if !filters.IsAuthenticated {
-		query = query.Where("status = ?", "public")
-	} else {
-		// メンバー（ログイン済み）の場合：クエリパラメータに応じて制御
+		// ログイン済みの場合：メンバーであればクエリパラメータに応じて制御（未確認のユーザーはpublicのみ）
 		switch filters.Status {
 		case "internal":
 			// internalのみ
-			query = query.Where("status = ?", "internal")
+			return db.Where("articles.status = ? AND "+memberCondition, "internal", filters.ViewerID)
 		case "public":
 			// publicのみ
-			query = query.Where("status = ?", "public")
-		case "all", "":
-			// 両方（デフォルト）
-			query = query.Where("status IN ?", []string{"public", "internal"})
+			return db.Where("articles.status = ?", "public")
 		default:
-			// 指定されたステータスで検索（draft等）
-			query = query.Where("status = ?", filters.Status)
+			// 両方（デフォルト。不明な値はサービス層で弾くが、ここでも下書きなどは返さない）
+			return db.Where("(articles.status = ? OR (articles.status = ? AND "+memberCondition+"))", "public", "internal", filters.ViewerID)
+		}
+	}
+}
+
+// preloadViewerReactions は閲覧者自身のリアクションをまとめて読み込むスコープです（ゲストの場合は読み込みません）
+func preloadViewerReactions(viewerID int) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if viewerID == 0 {
+			return db
+		}
+		return db.Preload("ViewerReactions", func(db *gorm.DB) *gorm.DB {
+			return db.Where("user_id = ?", viewerID).Order("created_at, emoji")
+		})
+	}
+}
+
+// isMember は閲覧者がメンバー（メールアドレス確認済みのユーザー）かどうかを返します
+func (r *articleRepository) isMember(viewerID int) (bool, error) {
+	if viewerID == 0 {
+		return false, nil
+	}
+	var count int64
+	if err := r.db.Model(&models.User{}).Where("id = ? AND email_verified_at IS NOT NULL", viewerID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// filteredArticles はフィルタと閲覧権限を適用した記事一覧のクエリを組み立てます
+func (r *articleRepository) filteredArticles(filters ArticleFilters) *gorm.DB {
+	query := r.db.Model(&models.Article{})
+
+	// フィルタを適用
+	if len(filters.Departments) > 0 {
+		query = query.Where("articles.department IN ?", filters.Departments)
+	}
+	if len(filters.ArticleTypes) > 0 {
+		query = query.Where("articles.article_type IN ?", filters.ArticleTypes)
+	}
+	if filters.CreatedAfter != nil {
+		query = query.Where("articles.created_at >= ?", *filters.CreatedAfter)
+	}
+	if filters.CreatedBefore != nil {
+		query = query.Where("articles.created_at < ?", *filters.CreatedBefore)
+	}
+	if filters.Tag != "" {
+		query = query.Where("EXISTS (?)", r.db.Table("article_tags").
+			Select("1").
+			Joins("JOIN tags ON tags.id = article_tags.tag_id").
+			Where("article_tags.article_id = articles.id AND tags.name = ?", filters.Tag))
+	}
+	if filters.AuthorID != 0 {
+		query = query.Where("articles.author_id = ?", filters.AuthorID)
+	}
+
+	for _, keyword := range filters.Keywords {
+		pattern := "%" + escapeLike(keyword) + "%"
+		query = query.Where("(articles.title ILIKE ? OR articles.content ILIKE ?)", pattern, pattern)
+		// 2文字のキーワードはトライグラムのインデックスを使えないため、バイグラムのインデックス（idx_articles_bigrams）でも絞り込む
+		if utf8.RuneCountInString(keyword) == 2 {
+			query = query.Where(bigramCondition, keyword)
 		}
 	}
 
+	// 閲覧権限に応じてステータスを絞り込む
+	return query.Scopes(visibleArticles(filters))
+}
+
+// FindAll はフィルタとページネーションを適用して記事一覧を取得します
+func (r *articleRepository) FindAll(filters ArticleFilters, page, limit int) ([]models.Article, int64, error) {
+	var articles []models.Article
+	var totalCount int64
+
+	query := r.filteredArticles(filters)
+
 	// 総件数を取得
 	if err := query.Count(&totalCount).Error; err != nil {
 		return nil, 0, err
 	}
 
-	// ページネーション適用
+	// 並び順の指定がないキーワード検索は関連度順（タイトルの一致を本文より重視）、それ以外は指定された順（既定は新着順）
+	if filters.rankedByRelevance() {
+		query = query.Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:                relevanceSQL + " DESC",
+			Vars:               filters.relevanceVars(),
+			WithoutParentheses: true,
+		}})
+	}
+
+	// ページネーション適用（値が同じ記事の順序が変わらないようIDでも並べる）
+	column, desc := filters.sortColumn()
 	offset := (page - 1) * limit
-	if err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&articles).Error; err != nil {
+	if err := query.Preload("Author").Preload("Tags").Scopes(preloadViewerReactions(filters.ViewerID)).
+		Order(orderBy(column, desc)).
+		Limit(limit).Offset(offset).Find(&articles).Error; err != nil {
 		return nil, 0, err
 	}
 
 	return articles, totalCount, nil
 }
 
+// FindAllByCursor はキーセットページネーションで、filtersの並び順（既定は新着順）の記事一覧を取得します
+// cursorがnilの場合は先頭から、Beforeがfalseの場合はcursorより後ろの記事、trueの場合はcursorより前の記事を取得します
+// 記事はどちらの場合もfiltersの並び順で返し、取得した方向にまだ記事があるかをあわせて返します
+// 並び順の指定がないキーワード検索は関連度順に並べ、カーソルの作成に使う関連度を記事のRelevanceに読み込みます
+func (r *articleRepository) FindAllByCursor(filters ArticleFilters, cursor *ArticleCursor, limit int) ([]models.Article, bool, error) {
+	var articles []models.Article
+
+	query := r.filteredArticles(filters).Preload("Author").Preload("Tags").Scopes(preloadViewerReactions(filters.ViewerID))
+	column, desc := filters.sortColumn()
+	var columnVars []any
+	if filters.rankedByRelevance() {
+		column, desc, columnVars = relevanceSQL, true, filters.relevanceVars()
+		query = query.Select("articles.*, "+relevanceSQL+" AS relevance", columnVars...)
+	}
+	if cursor != nil {
+		// 前のページは逆順に並べて取得し、最後に並べ直す
+		if cursor.Before {
+			desc = !desc
+		}
+		operator := ">"
+		if desc {
+			operator = "<"
+		}
+		query = query.Where("("+column+", articles.id) "+operator+" (?, ?)", append(slices.Clone(columnVars), cursor.Value, cursor.ID)...)
+	}
+
+	// 1件多く取得して、次のページがあるかを判定する
+	order := clause.OrderBy{Expression: clause.Expr{SQL: orderBy(column, desc), Vars: columnVars, WithoutParentheses: true}}
+	if err := query.Order(order).Limit(limit + 1).Find(&articles).Error; err != nil {
+		return nil, false, err
+	}
+	hasMore := len(articles) > limit
+	if hasMore {
+		articles = articles[:limit]
+	}
+	if cursor != nil && cursor.Before {
+		slices.Reverse(articles)
+	}
+
+	return articles, hasMore, nil
+}
+
+// Count はフィルタと閲覧権限を適用した記事の件数を返します
+func (r *articleRepository) Count(filters ArticleFilters) (int64, error) {
+	var count int64
+	if err := r.filteredArticles(filters).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// FindPopular はフィルタと閲覧権限を適用した記事を、累計の閲覧数の多い順に取得します（閲覧されていない記事は含めません）
+func (r *articleRepository) FindPopular(filters ArticleFilters, limit int) ([]models.Article, error) {
+	var articles []models.Article
+	if err := r.filteredArticles(filters).Where("articles.view_count > 0").
+		Preload("Author").Preload("Tags").Scopes(preloadViewerReactions(filters.ViewerID)).
+		Order(orderBy("articles.view_count", true)).
+		Limit(limit).Find(&articles).Error; err != nil {
+		return nil, err
+	}
+	return articles, nil
+}
+
+// FindTrending はフィルタと閲覧権限を適用した記事を、部署ごとにトレンドのスコアの高い順にlimitPerDepartment件ずつ取得します
+// スコアは直近HourlyViewRetentionの1時間ごとの閲覧数を、halfLifeごとに重みが半分になるよう減衰させて合計したものです
+// 記事は部署名の順、部署の中ではスコアの高い順に並べて返します
+func (r *articleRepository) FindTrending(filters ArticleFilters, now time.Time, halfLife time.Duration, limitPerDepartment int) ([]models.Article, error) {
+	scores := r.db.Model(&models.ArticleHourlyView{}).
+		Select("article_id, SUM(views * POWER(0.5, EXTRACT(EPOCH FROM (? - hour_start)) / ?)) AS score", now, halfLife.Seconds()).
+		Where("hour_start >= ?", now.Add(-HourlyViewRetention)).
+		Group("article_id")
+
+	ranked := r.filteredArticles(filters).
+		Select("articles.id, articles.department, ROW_NUMBER() OVER (PARTITION BY articles.department ORDER BY trending.score DESC, articles.id DESC) AS trending_rank").
+		Joins("JOIN (?) AS trending ON trending.article_id = articles.id", scores)
+
+	var ids []int
+	if err := r.db.Table("(?) AS ranked", ranked).
+		Where("ranked.trending_rank <= ?", limitPerDepartment).
+		Order("ranked.department, ranked.trending_rank").
+		Pluck("ranked.id", &ids).Error; err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var articles []models.Article
+	if err := r.db.Preload("Author").Preload("Tags").Scopes(preloadViewerReactions(filters.ViewerID)).
+		Where("id IN ?", ids).Find(&articles).Error; err != nil {
+		return nil, err
+	}
+	// IN句では順序が保たれないため、ランキングの順に並べ直す
+	slices.SortFunc(articles, func(a, b models.Article) int {
+		return slices.Index(ids, a.ID) - slices.Index(ids, b.ID)
+	})
+	return articles, nil
+}
+
 // FindBySlug はslugを指定して記事を取得します
-func (r *articleRepository) FindBySlug(slug string, isAuthenticated bool) (*models.Article, error) {
+// userIDには閲覧者のユーザーID（ゲストの場合は0）を指定し、著者本人であれば下書きも返します
+func (r *articleRepository) FindBySlug(slug string, userID int) (*models.Article, error) {
 	var article models.Article
 
 	// まずは記事を取得（ステータスを問わず）
-	if err := r.db.Preload("Author").Where("slug = ?", slug).First(&article).Error; err != nil {
+	if err := r.db.Preload("Author").Preload("Tags").Scopes(preloadViewerReactions(userID)).
+		Where("slug = ?", slug).First(&article).Error; err != nil {
 		return nil, err
 	}
 
@@ -89,13 +348,200 @@ func (r *articleRepository) FindBySlug(slug string, isAuthenticated bool) (*mode
 		// publicは誰でもOK
 		return &article, nil
 	case "internal":
-		// internalはログイン済みのみ
-		if !isAuthenticated {
-			return nil, fmt.Errorf("内部公開記事にアクセスするにはログインが必要です")
+		// internalはメンバー（メールアドレス確認済みのユーザー）のみ
+		isMember, err := r.isMember(userID)
+		if err != nil {
+			return nil, err
+		}
+		if !isMember {
+			return nil, ErrLoginRequired
 		}
 		return &article, nil
 	default:
-		// draft等のその他のステータスは見つからない扱い（404）
+		// draft・in_review等のその他のステータスは著者本人、割り当てられたレビュアー、
+		// 記事を管理できるユーザー（管理者・記事の部署の編集者）のみ閲覧可能
+		if userID != 0 && article.AuthorID == userID {
+			return &article, nil
+		}
+		if userID != 0 {
+			var reviewerCount int64
+			if err := r.db.Model(&models.ArticleReview{}).Where("article_id = ? AND reviewer_id = ?", article.ID, userID).Count(&reviewerCount).Error; err != nil {
+				return nil, err
+			}
+			if reviewerCount > 0 {
+				return &article, nil
+			}
+
+			var managerCount int64
+			if err := r.db.Model(&models.User{}).
+				Where("id = ? AND email_verified_at IS NOT NULL AND (role = ? OR (role = ? AND department = ?))", userID, models.RoleAdmin, models.RoleEditor, article.Department).
+				Count(&managerCount).Error; err != nil {
+				return nil, err
+			}
+			if managerCount > 0 {
+				return &article, nil
+			}
+		}
+		// 閲覧権限がない場合は見つからない扱い（404）
 		return nil, gorm.ErrRecordNotFound
 	}
 }
+
+// FindBySlugAnyStatus は閲覧権限をチェックせずにslugで記事を取得します（編集・削除用）
+func (r *articleRepository) FindBySlugAnyStatus(slug string) (*models.Article, error) {
+	var article models.Article
+	if err := r.db.Preload("Author").Preload("Tags").Where("slug = ?", slug).First(&article).Error; err != nil {
+		return nil, err
+	}
+	return &article, nil
+}
+
+// Create は記事を作成します。article.Tagsに設定されたタグも紐付け、最初のリビジョンを保存します
+func (r *articleRepository) Create(article *models.Article, editorID int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Author", "ViewerReactions").Create(article).Error; err != nil {
+			return err
+		}
+		return createRevision(tx, article, &editorID)
+	})
+}
+
+// Update は記事を更新し、タグの紐付けをarticle.Tagsの内容に置き換えた上で、新しいリビジョンを保存します
+func (r *articleRepository) Update(article *models.Article, editorID int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return updateArticle(tx, article, editorID)
+	})
+}
+
+// UpdateAndResetApprovals は記事を保存し、同じトランザクションで承認済みのレビューを未レビューに戻します（承認後に内容が変更された場合）
+// 内容の変更だけが保存されて承認が残ることのないよう、承認の取り消しを別のトランザクションにしません
+func (r *articleRepository) UpdateAndResetApprovals(article *models.Article, editorID int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := updateArticle(tx, article, editorID); err != nil {
+			return err
+		}
+		return resetApprovals(tx, article.ID)
+	})
+}
+
+// updateArticle は記事とタグを保存し、リビジョンを記録します
+func updateArticle(tx *gorm.DB, article *models.Article, editorID int) error {
+	// 閲覧数とリアクション数は記事の編集とは別に更新されるため、読み込んだ時点の値で上書きしない
+	if err := tx.Omit("Author", "Tags", "ViewerReactions", "ViewCount", "ReactionCounts").Save(article).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(article).Association("Tags").Replace(article.Tags); err != nil {
+		return err
+	}
+	return createRevision(tx, article, &editorID)
+}
+
+// FindUnrendered はHTMLなどのキャッシュがまだないMarkdown記事（キャッシュを導入する前に保存された記事）を、
+// IDがafterIDより大きいものからID順にlimit件取得します
+func (r *articleRepository) FindUnrendered(afterID, limit int) ([]models.Article, error) {
+	var articles []models.Article
+	if err := r.db.
+		Where("article_type = ? AND content IS NOT NULL AND content_html IS NULL AND id > ?", "markdown", afterID).
+		Order("id").Limit(limit).
+		Find(&articles).Error; err != nil {
+		return nil, err
+	}
+	return articles, nil
+}
+
+// UpdateRenderedContent は本文から作成したHTML・目次・読了時間・抜粋だけを保存します
+// 記事の内容は変わらないため、リビジョンの記録は行いません
+// （articlesのトリガーはこれらの列だけの更新ではupdated_atを更新しません）
+func (r *articleRepository) UpdateRenderedContent(article *models.Article) error {
+	return r.db.Model(&models.Article{}).Where("id = ?", article.ID).UpdateColumns(map[string]any{
+		"content_html":         article.ContentHTML,
+		"toc":                  article.TOC,
+		"reading_time_minutes": article.ReadingTimeMinutes,
+		"excerpt":              article.Excerpt,
+	}).Error
+}
+
+// Delete は記事を削除します（article_tagsはON DELETE CASCADEで削除されます）
+func (r *articleRepository) Delete(id int) error {
+	result := r.db.Delete(&models.Article{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// PublishDueArticles は予約公開の日時を過ぎた記事のstatusをscheduled_statusに切り替え、切り替えた記事を返します
+// publicへの予約はレビューで承認されるまで保留されます
+// 複数のAPIレプリカで同時に実行されても、FOR UPDATE SKIP LOCKEDにより各記事は1回だけ処理されます
+func (r *articleRepository) PublishDueArticles(now time.Time, limit int) ([]models.Article, error) {
+	var articles []models.Article
+	// ステータスの切り替えもリビジョンとして記録する（editor_idはNULL = システムによる変更）
+	err := r.db.Raw(`
+		WITH published AS (
+			UPDATE articles
+			SET status = scheduled_status, scheduled_status = NULL
+			WHERE id IN (
+				SELECT id FROM articles
+				WHERE scheduled_status IS NOT NULL AND publish_at <= ?
+					-- publicへの切り替えは記事の部署のレビュアーが承認している場合のみ
+					AND (scheduled_status <> 'public' OR EXISTS (
+						SELECT 1 FROM article_reviews ar
+						JOIN users u ON u.id = ar.reviewer_id
+						WHERE ar.article_id = articles.id AND ar.state = 'approved' AND u.department = articles.department
+					))
+				ORDER BY publish_at
+				LIMIT ?
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING *
+		), revisions AS (
+			INSERT INTO article_revisions (article_id, revision_number, editor_id, title, content, status)
+			SELECT p.id,
+				(SELECT COALESCE(MAX(rev.revision_number), 0) + 1 FROM article_revisions rev WHERE rev.article_id = p.id),
+				NULL, p.title, p.content, p.status
+			FROM published p
+		)
+		SELECT * FROM published`, now, limit).Scan(&articles).Error
+	if err != nil {
+		return nil, err
+	}
+	return articles, nil
+}
+
+// orderBy はcolumnとIDで並べるORDER BY句を返します
+func orderBy(column string, desc bool) string {
+	if desc {
+		return column + " DESC, articles.id DESC"
+	}
+	return column + " ASC, articles.id ASC"
+}
+
+// escapeLike はLIKEパターンで特別な意味を持つ文字をエスケープします
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// CountPublic は公開(public)記事の件数を返します
+func (r *articleRepository) CountPublic() (int64, error) {
+	var count int64
+	if err := r.db.Model(&models.Article{}).Where("status = ?", "public").Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// FindPublicSitemapEntries はsitemap.xml用に公開記事のslugとupdated_atだけをID順に取得します
+func (r *articleRepository) FindPublicSitemapEntries(offset, limit int) ([]models.Article, error) {
+	var articles []models.Article
+	if err := r.db.Model(&models.Article{}).
+		Select("id", "slug", "updated_at").
+		Where("status = ?", "public").
+		Order("id").Offset(offset).Limit(limit).
+		Find(&articles).Error; err != nil {
+		return nil, err
+	}
+	return articles, nil
+}
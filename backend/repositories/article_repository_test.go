@@ -0,0 +1,124 @@
+package repositories
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/yamada-mikiya/team1-hackathon/models"
+)
+
+func TestUpdateRenderedContentKeepsUpdatedAt(t *testing.T) {
+	db := openTestDB(t)
+	article := createTestArticle(t, db)
+	repo := NewArticleRepository(db)
+
+	unrendered, err := repo.FindUnrendered(article.ID-1, 10)
+	if err != nil {
+		t.Fatalf("FindUnrendered: %v", err)
+	}
+	if len(unrendered) == 0 || unrendered[0].ID != article.ID {
+		t.Fatalf("FindUnrendered: キャッシュがない記事 %d が含まれていません", article.ID)
+	}
+
+	html := `<h1 id="テスト">テスト</h1>`
+	minutes := 1
+	excerpt := "テスト"
+	article.ContentHTML = &html
+	article.TOC = models.TOC{{Level: 1, Text: "テスト", ID: "テスト"}}
+	article.ReadingTimeMinutes = &minutes
+	article.Excerpt = &excerpt
+	if err := repo.UpdateRenderedContent(article); err != nil {
+		t.Fatalf("UpdateRenderedContent: %v", err)
+	}
+
+	got := reloadTestArticle(t, db, article.ID)
+	if got.ContentHTML == nil || *got.ContentHTML != html {
+		t.Errorf("content_html = %v, want %q", got.ContentHTML, html)
+	}
+	if !got.UpdatedAt.Equal(article.UpdatedAt) {
+		t.Errorf("updated_at = %v, want %v（キャッシュの保存で更新日時が変わっています）", got.UpdatedAt, article.UpdatedAt)
+	}
+
+	unrendered, err = repo.FindUnrendered(article.ID-1, 10)
+	if err != nil {
+		t.Fatalf("FindUnrendered: %v", err)
+	}
+	if len(unrendered) > 0 && unrendered[0].ID == article.ID {
+		t.Errorf("FindUnrendered: キャッシュを保存した記事 %d が含まれています", article.ID)
+	}
+}
+
+func TestSearchByCursorIsRankedByRelevance(t *testing.T) {
+	db := openTestDB(t)
+	first := createTestArticle(t, db)
+	repo := NewArticleRepository(db)
+
+	// 2文字のキーワードはバイグラムのインデックスで絞り込む。タイトルに含む記事を本文だけに含む記事より上位にする
+	contents := []struct{ title, content string }{
+		{"全文検索の基本", "# 全文検索の基本\n\nキーワードで検索する方法"},
+		{"ログの集計", "# ログ\n\nログを検索して集計します"},
+		{"デプロイ手順", "# デプロイ\n\nキーワードを含まない記事"},
+		{"検索", "# 検索\n\n検索の検索"},
+	}
+	for i, c := range contents {
+		content := c.content
+		article := &models.Article{
+			AuthorID:    first.AuthorID,
+			ArticleType: "markdown",
+			Title:       c.title,
+			Content:     &content,
+			Slug:        fmt.Sprintf("%s-%d", first.Slug, i),
+			Department:  "Dev",
+			Status:      "public",
+		}
+		if err := db.Omit("Author", "ViewerReactions").Create(article).Error; err != nil {
+			t.Fatalf("記事の作成に失敗しました: %v", err)
+		}
+	}
+
+	filters := ArticleFilters{Keywords: []string{"検索"}, AuthorID: first.AuthorID}
+	ranked, total, err := repo.FindAll(filters, 1, 10)
+	if err != nil {
+		t.Fatalf("FindAll: %v", err)
+	}
+	if total != 3 {
+		t.Fatalf("FindAll: %d件, want 3件（キーワードを含む記事のみ）", total)
+	}
+
+	// カーソル方式でも1件ずつ進めて、ページ番号方式と同じ関連度順になる
+	var cursor *ArticleCursor
+	var got []models.Article
+	for {
+		articles, hasMore, err := repo.FindAllByCursor(filters, cursor, 1)
+		if err != nil {
+			t.Fatalf("FindAllByCursor: %v", err)
+		}
+		got = append(got, articles...)
+		if !hasMore {
+			break
+		}
+		last := articles[len(articles)-1]
+		cursor = &ArticleCursor{Value: last.Relevance, ID: last.ID}
+	}
+	if len(got) != len(ranked) {
+		t.Fatalf("FindAllByCursor: %d件, want %d件", len(got), len(ranked))
+	}
+	for i := range ranked {
+		if got[i].ID != ranked[i].ID {
+			t.Errorf("FindAllByCursor: %d番目 = %q, want %q", i+1, got[i].Title, ranked[i].Title)
+		}
+	}
+	if ranked[0].Title != "検索" {
+		t.Errorf("最も関連度の高い記事 = %q, want 検索", ranked[0].Title)
+	}
+
+	// 最後の記事から前のページへ戻る
+	last := got[len(got)-1]
+	prev, hasMore, err := repo.FindAllByCursor(filters, &ArticleCursor{Value: last.Relevance, ID: last.ID, Before: true}, 1)
+	if err != nil {
+		t.Fatalf("FindAllByCursor: %v", err)
+	}
+	if len(prev) != 1 || prev[0].ID != got[len(got)-2].ID || !hasMore {
+		t.Errorf("前のページ = %v（hasMore = %v）, want %q", prev, hasMore, got[len(got)-2].Title)
+	}
+}
@@ -0,0 +1,70 @@
+package repositories
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// HourlyViewRetention は1時間ごとの閲覧数を保持する期間。トレンドはこの期間の閲覧数から計算します
+const HourlyViewRetention = 7 * 24 * time.Hour
+
+type ArticleViewRepository interface {
+	AddViews(ctx context.Context, counts map[int]int64, at time.Time) error
+	DeleteHourlyViewsBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type articleViewRepository struct {
+	db *gorm.DB
+}
+
+func NewArticleViewRepository(db *gorm.DB) ArticleViewRepository {
+	return &articleViewRepository{db: db}
+}
+
+// AddViews は記事ID→閲覧数のcountsを、記事の累計の閲覧数とatを含む1時間の閲覧数に加算します
+// 集計中に削除された記事の閲覧数は捨てます
+// 記事の更新日時は変えません（articlesのトリガーはview_countだけの更新ではupdated_atを更新しません）
+func (r *articleViewRepository) AddViews(ctx context.Context, counts map[int]int64, at time.Time) error {
+	if len(counts) == 0 {
+		return nil
+	}
+	// 複数のサーバーから同時に書き込んでもデッドロックしないよう、記事IDの順に更新する
+	ids := slices.Sorted(maps.Keys(counts))
+	hourStart := at.UTC().Truncate(time.Hour)
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing []int
+		if err := tx.Model(&models.Article{}).Where("id IN ?", ids).Order("id").Pluck("id", &existing).Error; err != nil {
+			return err
+		}
+		if len(existing) == 0 {
+			return nil
+		}
+
+		hourly := make([]models.ArticleHourlyView, len(existing))
+		for i, id := range existing {
+			if err := tx.Model(&models.Article{}).Where("id = ?", id).
+				UpdateColumn("view_count", gorm.Expr("view_count + ?", counts[id])).Error; err != nil {
+				return err
+			}
+			hourly[i] = models.ArticleHourlyView{ArticleID: id, HourStart: hourStart, Views: counts[id]}
+		}
+
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "article_id"}, {Name: "hour_start"}},
+			DoUpdates: clause.Set{{Column: clause.Column{Name: "views"}, Value: gorm.Expr("article_hourly_views.views + excluded.views")}},
+		}).Create(&hourly).Error
+	})
+}
+
+// DeleteHourlyViewsBefore はbeforeより前の1時間ごとの閲覧数を削除し、削除した件数を返します
+func (r *articleViewRepository) DeleteHourlyViewsBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("hour_start < ?", before).Delete(&models.ArticleHourlyView{})
+	return result.RowsAffected, result.Error
+}
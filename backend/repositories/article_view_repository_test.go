@@ -0,0 +1,53 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yamada-mikiya/team1-hackathon/models"
+)
+
+func TestAddViewsKeepsUpdatedAt(t *testing.T) {
+	db := openTestDB(t)
+	article := createTestArticle(t, db)
+	repo := NewArticleViewRepository(db)
+
+	now := time.Now()
+	if err := repo.AddViews(context.Background(), map[int]int64{article.ID: 3}, now); err != nil {
+		t.Fatalf("AddViews: %v", err)
+	}
+	if err := repo.AddViews(context.Background(), map[int]int64{article.ID: 2}, now); err != nil {
+		t.Fatalf("AddViews: %v", err)
+	}
+
+	got := reloadTestArticle(t, db, article.ID)
+	if got.ViewCount != 5 {
+		t.Errorf("view_count = %d, want 5", got.ViewCount)
+	}
+	if !got.UpdatedAt.Equal(article.UpdatedAt) {
+		t.Errorf("updated_at = %v, want %v（閲覧数の加算で更新日時が変わっています）", got.UpdatedAt, article.UpdatedAt)
+	}
+
+	var hourly models.ArticleHourlyView
+	if err := db.Where("article_id = ?", article.ID).First(&hourly).Error; err != nil {
+		t.Fatalf("1時間ごとの閲覧数の取得に失敗しました: %v", err)
+	}
+	if hourly.Views != 5 || !hourly.HourStart.Equal(now.UTC().Truncate(time.Hour)) {
+		t.Errorf("hourly = {views: %d, hour_start: %v}, want {5, %v}", hourly.Views, hourly.HourStart, now.UTC().Truncate(time.Hour))
+	}
+}
+
+func TestArticleContentUpdateChangesUpdatedAt(t *testing.T) {
+	db := openTestDB(t)
+	article := createTestArticle(t, db)
+
+	if err := db.Model(&models.Article{}).Where("id = ?", article.ID).UpdateColumn("title", "変更後のタイトル").Error; err != nil {
+		t.Fatalf("タイトルの更新に失敗しました: %v", err)
+	}
+
+	got := reloadTestArticle(t, db, article.ID)
+	if !got.UpdatedAt.After(article.UpdatedAt) {
+		t.Errorf("updated_at = %v, want after %v（記事の内容の変更で更新日時が変わっていません）", got.UpdatedAt, article.UpdatedAt)
+	}
+}
@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"gorm.io/gorm"
+)
+
+type CommentRepository interface {
+	FindTopLevel(articleID, page, limit int) ([]models.ArticleComment, int64, error)
+	FindReplies(parentIDs []int) ([]models.ArticleComment, error)
+	FindByID(articleID, id int) (*models.ArticleComment, error)
+	Create(comment *models.ArticleComment) error
+	Update(comment *models.ArticleComment) error
+}
+
+type commentRepository struct {
+	db *gorm.DB
+}
+
+func NewCommentRepository(db *gorm.DB) CommentRepository {
+	return &commentRepository{db: db}
+}
+
+// FindTopLevel は記事の返信でないコメントを作成日時の古い順にページ分けして取得し、総件数とあわせて返します
+// 削除・非表示のコメントも含めます
+func (r *commentRepository) FindTopLevel(articleID, page, limit int) ([]models.ArticleComment, int64, error) {
+	var comments []models.ArticleComment
+	var totalCount int64
+
+	query := r.db.Model(&models.ArticleComment{}).Where("article_id = ? AND parent_id IS NULL", articleID)
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	if err := query.Preload("User").Order("created_at, id").Limit(limit).Offset(offset).Find(&comments).Error; err != nil {
+		return nil, 0, err
+	}
+	return comments, totalCount, nil
+}
+
+// FindReplies はparentIDsのコメントへの返信を作成日時の古い順に取得します
+func (r *commentRepository) FindReplies(parentIDs []int) ([]models.ArticleComment, error) {
+	if len(parentIDs) == 0 {
+		return nil, nil
+	}
+	var replies []models.ArticleComment
+	if err := r.db.Preload("User").Where("parent_id IN ?", parentIDs).Order("created_at, id").Find(&replies).Error; err != nil {
+		return nil, err
+	}
+	return replies, nil
+}
+
+// FindByID は記事のコメントをIDで取得します（別の記事のコメントの場合は見つからない扱い）
+func (r *commentRepository) FindByID(articleID, id int) (*models.ArticleComment, error) {
+	var comment models.ArticleComment
+	if err := r.db.Preload("User").Where("article_id = ? AND id = ?", articleID, id).First(&comment).Error; err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// Create はコメントを保存します
+func (r *commentRepository) Create(comment *models.ArticleComment) error {
+	return r.db.Omit("User").Create(comment).Error
+}
+
+// Update はコメントの本文と、削除・非表示の状態を保存します
+func (r *commentRepository) Update(comment *models.ArticleComment) error {
+	return r.db.Model(&models.ArticleComment{}).Where("id = ?", comment.ID).Updates(map[string]any{
+		"body":       comment.Body,
+		"body_html":  comment.BodyHTML,
+		"edited_at":  comment.EditedAt,
+		"deleted_at": comment.DeletedAt,
+		"hidden_at":  comment.HiddenAt,
+		"hidden_by":  comment.HiddenBy,
+	}).Error
+}
@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"gorm.io/gorm"
+)
+
+type PasswordResetTokenRepository interface {
+	Create(ctx context.Context, token *models.PasswordResetToken) error
+	Consume(ctx context.Context, tokenHash string, now time.Time) (*models.PasswordResetToken, error)
+	InvalidateForUser(ctx context.Context, userID int) error
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+type passwordResetTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewPasswordResetTokenRepository(db *gorm.DB) PasswordResetTokenRepository {
+	return &passwordResetTokenRepository{db: db}
+}
+
+// Create はパスワードリセットトークンを保存します
+func (r *passwordResetTokenRepository) Create(ctx context.Context, token *models.PasswordResetToken) error {
+	return r.db.WithContext(ctx).Create(token).Error
+}
+
+// Consume は未使用かつ有効期限内のトークンを使用済みにして返します
+// 1つのUPDATE文で判定と更新を行うため、同じトークンを同時に使っても成功するのは1回だけです
+// 該当するトークンがない場合はgorm.ErrRecordNotFoundを返します
+func (r *passwordResetTokenRepository) Consume(ctx context.Context, tokenHash string, now time.Time) (*models.PasswordResetToken, error) {
+	var tokens []models.PasswordResetToken
+	result := r.db.WithContext(ctx).Raw(`
+		UPDATE password_reset_tokens
+		SET used_at = ?
+		WHERE token_hash = ? AND used_at IS NULL AND expires_at > ?
+		RETURNING *
+	`, now, tokenHash, now).Scan(&tokens)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if len(tokens) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &tokens[0], nil
+}
+
+// InvalidateForUser はユーザーの未使用のトークンをすべて使用済みにします
+func (r *passwordResetTokenRepository) InvalidateForUser(ctx context.Context, userID int) error {
+	return r.db.WithContext(ctx).Model(&models.PasswordResetToken{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update("used_at", gorm.Expr("NOW()")).Error
+}
+
+// DeleteExpired は有効期限を過ぎたトークンを削除し、削除した件数を返します
+func (r *passwordResetTokenRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("expires_at < ?", now).Delete(&models.PasswordResetToken{})
+	return result.RowsAffected, result.Error
+}
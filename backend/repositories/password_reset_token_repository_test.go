@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"gorm.io/gorm"
+)
+
+// createTestResetToken はテスト用のユーザーとパスワードリセットトークンを作成します
+func createTestResetToken(t *testing.T, db *gorm.DB, expiresAt time.Time) *models.PasswordResetToken {
+	t.Helper()
+	suffix := fmt.Sprintf("%s-%d", t.Name(), time.Now().UnixNano())
+
+	user := &models.User{Name: "テストユーザー", Email: suffix + "@example.com", PasswordHash: "x"}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatalf("ユーザーの作成に失敗しました: %v", err)
+	}
+	t.Cleanup(func() { db.Delete(&models.User{}, user.ID) })
+
+	token := &models.PasswordResetToken{UserID: user.ID, TokenHash: fmt.Sprintf("%064d", time.Now().UnixNano()), ExpiresAt: expiresAt}
+	if err := NewPasswordResetTokenRepository(db).Create(context.Background(), token); err != nil {
+		t.Fatalf("トークンの作成に失敗しました: %v", err)
+	}
+	return token
+}
+
+func TestConsumePasswordResetTokenOnce(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewPasswordResetTokenRepository(db)
+	token := createTestResetToken(t, db, time.Now().Add(time.Hour))
+
+	consumed, err := repo.Consume(context.Background(), token.TokenHash, time.Now())
+	if err != nil {
+		t.Fatalf("Consume: %v", err)
+	}
+	if consumed.UserID != token.UserID || consumed.UsedAt == nil {
+		t.Errorf("使用済みにしたトークン = %+v", consumed)
+	}
+
+	if _, err := repo.Consume(context.Background(), token.TokenHash, time.Now()); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("2回目のConsume: err = %v, want gorm.ErrRecordNotFound", err)
+	}
+}
+
+func TestConsumeExpiredPasswordResetToken(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewPasswordResetTokenRepository(db)
+	token := createTestResetToken(t, db, time.Now().Add(-time.Minute))
+
+	if _, err := repo.Consume(context.Background(), token.TokenHash, time.Now()); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("err = %v, want gorm.ErrRecordNotFound", err)
+	}
+}
+
+func TestInvalidatePasswordResetTokensForUser(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewPasswordResetTokenRepository(db)
+	token := createTestResetToken(t, db, time.Now().Add(time.Hour))
+
+	if err := repo.InvalidateForUser(context.Background(), token.UserID); err != nil {
+		t.Fatalf("InvalidateForUser: %v", err)
+	}
+	if _, err := repo.Consume(context.Background(), token.TokenHash, time.Now()); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("無効にしたトークンのConsume: err = %v, want gorm.ErrRecordNotFound", err)
+	}
+}
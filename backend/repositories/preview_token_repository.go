@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"gorm.io/gorm"
+)
+
+type PreviewTokenRepository interface {
+	Create(token *models.ArticlePreviewToken) error
+	FindByID(id string) (*models.ArticlePreviewToken, error)
+	FindByArticleID(articleID int) ([]models.ArticlePreviewToken, error)
+	Revoke(id string, articleID int) error
+}
+
+type previewTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewPreviewTokenRepository(db *gorm.DB) PreviewTokenRepository {
+	return &previewTokenRepository{db: db}
+}
+
+// Create はプレビューリンクの発行履歴を保存します
+func (r *previewTokenRepository) Create(token *models.ArticlePreviewToken) error {
+	return r.db.Create(token).Error
+}
+
+// FindByID はjtiを指定してプレビューリンクを取得します
+func (r *previewTokenRepository) FindByID(id string) (*models.ArticlePreviewToken, error) {
+	var token models.ArticlePreviewToken
+	if err := r.db.Where("id = ?", id).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// FindByArticleID は記事に発行されたプレビューリンクを新しい順に取得します
+func (r *previewTokenRepository) FindByArticleID(articleID int) ([]models.ArticlePreviewToken, error) {
+	var tokens []models.ArticlePreviewToken
+	if err := r.db.Where("article_id = ?", articleID).Order("created_at DESC").Find(&tokens).Error; err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// Revoke はプレビューリンクを失効させます。既に失効済みの場合も成功扱いにします
+func (r *previewTokenRepository) Revoke(id string, articleID int) error {
+	result := r.db.Model(&models.ArticlePreviewToken{}).
+		Where("id = ? AND article_id = ?", id, articleID).
+		Update("revoked_at", gorm.Expr("COALESCE(revoked_at, ?)", time.Now()))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReactionRepository interface {
+	Add(articleID, userID int, emoji string) (models.ReactionCounts, error)
+	Remove(articleID, userID int, emoji string) (models.ReactionCounts, error)
+	FindEmojisByUser(articleID, userID int) ([]string, error)
+}
+
+type reactionRepository struct {
+	db *gorm.DB
+}
+
+func NewReactionRepository(db *gorm.DB) ReactionRepository {
+	return &reactionRepository{db: db}
+}
+
+// Add はリアクションを追加し、記事の絵文字ごとのリアクション数を返します。既に追加済みの場合も成功扱いにします
+func (r *reactionRepository) Add(articleID, userID int, emoji string) (models.ReactionCounts, error) {
+	return r.updateReactions(articleID, func(tx *gorm.DB) error {
+		reaction := models.ArticleReaction{ArticleID: articleID, UserID: userID, Emoji: emoji}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&reaction).Error
+	})
+}
+
+// Remove はリアクションを取り消し、記事の絵文字ごとのリアクション数を返します。リアクションしていない場合も成功扱いにします
+func (r *reactionRepository) Remove(articleID, userID int, emoji string) (models.ReactionCounts, error) {
+	return r.updateReactions(articleID, func(tx *gorm.DB) error {
+		return tx.Where("article_id = ? AND user_id = ? AND emoji = ?", articleID, userID, emoji).
+			Delete(&models.ArticleReaction{}).Error
+	})
+}
+
+// updateReactions はchangeでリアクションを変更した後、記事のreaction_countsを集計し直して保存します
+// 同じ記事へのリアクションが同時に来ても件数がずれないよう、記事の行をロックしてから変更します
+// 記事の内容は変わらないため、updated_atは更新しません（articlesのトリガーはreaction_countsだけの更新ではupdated_atを更新しません）
+func (r *reactionRepository) updateReactions(articleID int, change func(tx *gorm.DB) error) (models.ReactionCounts, error) {
+	counts := models.ReactionCounts{}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var article models.Article
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&article, articleID).Error; err != nil {
+			return err
+		}
+		if err := change(tx); err != nil {
+			return err
+		}
+
+		var rows []struct {
+			Emoji string
+			Count int
+		}
+		if err := tx.Model(&models.ArticleReaction{}).
+			Select("emoji, COUNT(*) AS count").
+			Where("article_id = ?", articleID).
+			Group("emoji").
+			Scan(&rows).Error; err != nil {
+			return err
+		}
+		for _, row := range rows {
+			counts[row.Emoji] = row.Count
+		}
+
+		return tx.Model(&models.Article{}).Where("id = ?", articleID).UpdateColumn("reaction_counts", counts).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// FindEmojisByUser はユーザーが記事に付けたリアクションの絵文字を追加した順に返します
+func (r *reactionRepository) FindEmojisByUser(articleID, userID int) ([]string, error) {
+	var emojis []string
+	if err := r.db.Model(&models.ArticleReaction{}).
+		Where("article_id = ? AND user_id = ?", articleID, userID).
+		Order("created_at, emoji").
+		Pluck("emoji", &emojis).Error; err != nil {
+		return nil, err
+	}
+	return emojis, nil
+}
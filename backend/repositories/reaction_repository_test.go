@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"testing"
+
+	"github.com/yamada-mikiya/team1-hackathon/models"
+)
+
+func TestReactionsKeepUpdatedAt(t *testing.T) {
+	db := openTestDB(t)
+	article := createTestArticle(t, db)
+	repo := NewReactionRepository(db)
+
+	counts, err := repo.Add(article.ID, article.AuthorID, "heart")
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if counts["heart"] != 1 {
+		t.Errorf("Add: reaction_counts = %v, want heart: 1", counts)
+	}
+	// 同じ絵文字のリアクションは1回まで
+	if counts, err = repo.Add(article.ID, article.AuthorID, "heart"); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if counts["heart"] != 1 {
+		t.Errorf("Add（2回目）: reaction_counts = %v, want heart: 1", counts)
+	}
+
+	got := reloadTestArticle(t, db, article.ID)
+	if got.ReactionCounts["heart"] != 1 {
+		t.Errorf("articles.reaction_counts = %v, want heart: 1", got.ReactionCounts)
+	}
+	if !got.UpdatedAt.Equal(article.UpdatedAt) {
+		t.Errorf("Add: updated_at = %v, want %v（リアクションで更新日時が変わっています）", got.UpdatedAt, article.UpdatedAt)
+	}
+
+	if counts, err = repo.Remove(article.ID, article.AuthorID, "heart"); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if len(counts) != 0 {
+		t.Errorf("Remove: reaction_counts = %v, want {}", counts)
+	}
+
+	got = reloadTestArticle(t, db, article.ID)
+	if len(got.ReactionCounts) != 0 {
+		t.Errorf("articles.reaction_counts = %v, want {}", got.ReactionCounts)
+	}
+	if !got.UpdatedAt.Equal(article.UpdatedAt) {
+		t.Errorf("Remove: updated_at = %v, want %v（リアクションの取り消しで更新日時が変わっています）", got.UpdatedAt, article.UpdatedAt)
+	}
+
+	var remaining int64
+	db.Model(&models.ArticleReaction{}).Where("article_id = ?", article.ID).Count(&remaining)
+	if remaining != 0 {
+		t.Errorf("article_reactions = %d件, want 0件", remaining)
+	}
+}
@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"gorm.io/gorm"
+)
+
+// ErrRefreshTokenReused は使用済み（または失効済み）のリフレッシュトークンでローテーションしようとした場合のエラー
+var ErrRefreshTokenReused = errors.New("使用済みのリフレッシュトークンです")
+
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *models.RefreshToken) error
+	FindByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error)
+	Rotate(ctx context.Context, current, next *models.RefreshToken) error
+	RevokeFamily(ctx context.Context, familyID string) error
+	RevokeByUser(ctx context.Context, userID int) error
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+type refreshTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewRefreshTokenRepository(db *gorm.DB) RefreshTokenRepository {
+	return &refreshTokenRepository{db: db}
+}
+
+// Create はリフレッシュトークンを保存します
+func (r *refreshTokenRepository) Create(ctx context.Context, token *models.RefreshToken) error {
+	return r.db.WithContext(ctx).Create(token).Error
+}
+
+// FindByHash はトークンのハッシュからリフレッシュトークンを取得します
+func (r *refreshTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	if err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// Rotate はcurrentを使用済みにし、同じファミリーの新しいトークンnextを保存します
+// currentが既に使用済み・失効済みの場合（同時に使われた場合を含む）はErrRefreshTokenReusedを返します
+func (r *refreshTokenRepository) Rotate(ctx context.Context, current, next *models.RefreshToken) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.RefreshToken{}).
+			Where("id = ? AND used_at IS NULL AND revoked_at IS NULL", current.ID).
+			Update("used_at", gorm.Expr("NOW()"))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrRefreshTokenReused
+		}
+
+		next.UserID = current.UserID
+		next.FamilyID = current.FamilyID
+		return tx.Create(next).Error
+	})
+}
+
+// RevokeFamily は同じファミリーのリフレッシュトークンをすべて失効させます
+func (r *refreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	return r.db.WithContext(ctx).Model(&models.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", gorm.Expr("NOW()")).Error
+}
+
+// RevokeByUser はユーザーのリフレッシュトークンをすべて失効させます（全端末からのログアウト）
+func (r *refreshTokenRepository) RevokeByUser(ctx context.Context, userID int) error {
+	return r.db.WithContext(ctx).Model(&models.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", gorm.Expr("NOW()")).Error
+}
+
+// DeleteExpired は有効期限を過ぎたリフレッシュトークンを削除し、削除した件数を返します
+func (r *refreshTokenRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("expires_at < ?", now).Delete(&models.RefreshToken{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,152 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrReviewNotInProgress = errors.New("この記事はレビュー中ではありません")
+	ErrRevisionOutdated    = errors.New("記事が更新されています。最新のリビジョンを確認してから承認してください")
+)
+
+type ReviewRepository interface {
+	FindByArticleID(articleID int) ([]models.ArticleReview, error)
+	IsReviewer(articleID, userID int) (bool, error)
+	AssignReviewers(articleID int, reviewerIDs []int) error
+	UpdateState(articleID, reviewerID int, state string) error
+	Approve(articleID, reviewerID, revisionNumber int) error
+	LatestRevisionNumber(articleID int) (int, error)
+	HasDepartmentApproval(articleID int, department string) (bool, error)
+	CreateComment(comment *models.ArticleReviewComment) error
+	FindComments(articleID int) ([]models.ArticleReviewComment, error)
+}
+
+type reviewRepository struct {
+	db *gorm.DB
+}
+
+func NewReviewRepository(db *gorm.DB) ReviewRepository {
+	return &reviewRepository{db: db}
+}
+
+// FindByArticleID は記事に割り当てられたレビュアーをレビュアー情報付きで取得します
+func (r *reviewRepository) FindByArticleID(articleID int) ([]models.ArticleReview, error) {
+	var reviews []models.ArticleReview
+	if err := r.db.Preload("Reviewer").Where("article_id = ?", articleID).Order("id").Find(&reviews).Error; err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+// IsReviewer はユーザーが記事のレビュアーに割り当てられているかを返します
+func (r *reviewRepository) IsReviewer(articleID, userID int) (bool, error) {
+	var count int64
+	if err := r.db.Model(&models.ArticleReview{}).Where("article_id = ? AND reviewer_id = ?", articleID, userID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// AssignReviewers はレビュアーを割り当てます。既に割り当て済みのレビュアーは未レビューの状態に戻します
+func (r *reviewRepository) AssignReviewers(articleID int, reviewerIDs []int) error {
+	reviews := make([]models.ArticleReview, len(reviewerIDs))
+	for i, reviewerID := range reviewerIDs {
+		reviews[i] = models.ArticleReview{ArticleID: articleID, ReviewerID: reviewerID, State: "pending"}
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "article_id"}, {Name: "reviewer_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"state"}),
+	}).Create(&reviews).Error
+}
+
+// UpdateState はレビュアーのレビュー状態を更新します
+func (r *reviewRepository) UpdateState(articleID, reviewerID int, state string) error {
+	result := r.db.Model(&models.ArticleReview{}).
+		Where("article_id = ? AND reviewer_id = ?", articleID, reviewerID).
+		Update("state", state)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Approve はレビュアーが確認したリビジョンが記事の最新のリビジョンである場合のみ承認します
+// 記事の行をロックしてから確認するため、記事の保存（リビジョンの記録）と同時に実行されても確認していない内容を承認することはありません
+func (r *reviewRepository) Approve(articleID, reviewerID, revisionNumber int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var article models.Article
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "status").
+			First(&article, articleID).Error; err != nil {
+			return err
+		}
+		if article.Status != "in_review" {
+			return ErrReviewNotInProgress
+		}
+
+		latest, err := latestRevisionNumber(tx, articleID)
+		if err != nil {
+			return err
+		}
+		if revisionNumber != latest {
+			return ErrRevisionOutdated
+		}
+
+		result := tx.Model(&models.ArticleReview{}).
+			Where("article_id = ? AND reviewer_id = ?", articleID, reviewerID).
+			Update("state", "approved")
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+// LatestRevisionNumber は記事の最新のリビジョン番号を返します（リビジョンがない場合は0）
+func (r *reviewRepository) LatestRevisionNumber(articleID int) (int, error) {
+	return latestRevisionNumber(r.db, articleID)
+}
+
+// resetApprovals は承認済みのレビューを未レビューに戻します（承認後に内容が変更された場合）
+func resetApprovals(db *gorm.DB, articleID int) error {
+	return db.Model(&models.ArticleReview{}).
+		Where("article_id = ? AND state = ?", articleID, "approved").
+		Update("state", "pending").Error
+}
+
+// HasDepartmentApproval は指定した部署に所属するレビュアーの承認があるかを返します
+func (r *reviewRepository) HasDepartmentApproval(articleID int, department string) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.ArticleReview{}).
+		Joins("JOIN users ON users.id = article_reviews.reviewer_id").
+		Where("article_reviews.article_id = ? AND article_reviews.state = ? AND users.department = ?", articleID, "approved", department).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// CreateComment はレビューコメントを保存します
+func (r *reviewRepository) CreateComment(comment *models.ArticleReviewComment) error {
+	return r.db.Omit("User").Create(comment).Error
+}
+
+// FindComments は記事のレビューコメントを古い順に取得します
+func (r *reviewRepository) FindComments(articleID int) ([]models.ArticleReviewComment, error) {
+	var comments []models.ArticleReviewComment
+	if err := r.db.Preload("User").Where("article_id = ?", articleID).Order("created_at, id").Find(&comments).Error; err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
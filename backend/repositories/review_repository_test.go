@@ -0,0 +1,83 @@
+package repositories
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestApproveRequiresLatestRevision(t *testing.T) {
+	db := openTestDB(t)
+	article := createTestArticle(t, db)
+	articleRepo := NewArticleRepository(db)
+	repo := NewReviewRepository(db)
+	reviewerID := article.AuthorID
+
+	article.Status = "in_review"
+	if err := articleRepo.Update(article, article.AuthorID); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if err := repo.AssignReviewers(article.ID, []int{reviewerID}); err != nil {
+		t.Fatalf("AssignReviewers: %v", err)
+	}
+	reviewed, err := repo.LatestRevisionNumber(article.ID)
+	if err != nil {
+		t.Fatalf("LatestRevisionNumber: %v", err)
+	}
+
+	// レビュアーが確認した後に本文が変更された
+	edited := "# 確認していない本文"
+	article.Content = &edited
+	if err := articleRepo.UpdateAndResetApprovals(article, article.AuthorID); err != nil {
+		t.Fatalf("UpdateAndResetApprovals: %v", err)
+	}
+	if err := repo.Approve(article.ID, reviewerID, reviewed); !errors.Is(err, ErrRevisionOutdated) {
+		t.Fatalf("古いリビジョンの承認: err = %v, want ErrRevisionOutdated", err)
+	}
+
+	latest, err := repo.LatestRevisionNumber(article.ID)
+	if err != nil {
+		t.Fatalf("LatestRevisionNumber: %v", err)
+	}
+	if latest != reviewed+1 {
+		t.Fatalf("LatestRevisionNumber = %d, want %d", latest, reviewed+1)
+	}
+	if err := repo.Approve(article.ID, reviewerID, latest); err != nil {
+		t.Fatalf("最新のリビジョンの承認: %v", err)
+	}
+	if state := reviewState(t, repo, article.ID); state != "approved" {
+		t.Errorf("承認後のstate = %q, want approved", state)
+	}
+
+	// 承認後に内容が変わると、記事の保存と同時に承認が取り消される
+	edited = "# 承認後に変更した本文"
+	if err := articleRepo.UpdateAndResetApprovals(article, article.AuthorID); err != nil {
+		t.Fatalf("UpdateAndResetApprovals: %v", err)
+	}
+	if state := reviewState(t, repo, article.ID); state != "pending" {
+		t.Errorf("内容の変更後のstate = %q, want pending", state)
+	}
+
+	article.Status = "draft"
+	if err := articleRepo.Update(article, article.AuthorID); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if latest, err = repo.LatestRevisionNumber(article.ID); err != nil {
+		t.Fatalf("LatestRevisionNumber: %v", err)
+	}
+	if err := repo.Approve(article.ID, reviewerID, latest); !errors.Is(err, ErrReviewNotInProgress) {
+		t.Errorf("下書きの承認: err = %v, want ErrReviewNotInProgress", err)
+	}
+}
+
+// reviewState は記事に割り当てられた最初のレビュアーのレビュー状態を返します
+func reviewState(t *testing.T, repo ReviewRepository, articleID int) string {
+	t.Helper()
+	reviews, err := repo.FindByArticleID(articleID)
+	if err != nil {
+		t.Fatalf("FindByArticleID: %v", err)
+	}
+	if len(reviews) == 0 {
+		t.Fatal("レビュアーが割り当てられていません")
+	}
+	return reviews[0].State
+}
@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"gorm.io/gorm"
+)
+
+type RevisionRepository interface {
+	FindByArticleID(articleID int) ([]models.ArticleRevision, error)
+	FindByNumber(articleID, revisionNumber int) (*models.ArticleRevision, error)
+}
+
+type revisionRepository struct {
+	db *gorm.DB
+}
+
+func NewRevisionRepository(db *gorm.DB) RevisionRepository {
+	return &revisionRepository{db: db}
+}
+
+// FindByArticleID は記事のリビジョン一覧を新しい順に取得します（本文は含みません）
+func (r *revisionRepository) FindByArticleID(articleID int) ([]models.ArticleRevision, error) {
+	var revisions []models.ArticleRevision
+	err := r.db.Omit("content").Preload("Editor").
+		Where("article_id = ?", articleID).
+		Order("revision_number DESC").
+		Find(&revisions).Error
+	if err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// FindByNumber はリビジョン番号を指定してリビジョンを取得します
+func (r *revisionRepository) FindByNumber(articleID, revisionNumber int) (*models.ArticleRevision, error) {
+	var revision models.ArticleRevision
+	err := r.db.Preload("Editor").
+		Where("article_id = ? AND revision_number = ?", articleID, revisionNumber).
+		First(&revision).Error
+	if err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// createRevision は記事の現在の内容を次の番号のリビジョンとして保存します
+// 記事の保存と同じトランザクション内で呼び出します
+func createRevision(tx *gorm.DB, article *models.Article, editorID *int) error {
+	latest, err := latestRevisionNumber(tx, article.ID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Create(&models.ArticleRevision{
+		ArticleID:      article.ID,
+		RevisionNumber: latest + 1,
+		EditorID:       editorID,
+		Title:          article.Title,
+		Content:        article.Content,
+		Status:         article.Status,
+	}).Error
+}
+
+// latestRevisionNumber は記事の最新のリビジョン番号を返します（リビジョンがない場合は0）
+func latestRevisionNumber(db *gorm.DB, articleID int) (int, error) {
+	var latest int
+	err := db.Model(&models.ArticleRevision{}).
+		Where("article_id = ?", articleID).
+		Select("COALESCE(MAX(revision_number), 0)").
+		Scan(&latest).Error
+	return latest, err
+}
@@ -0,0 +1,97 @@
+package repositories
+
+import (
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TagRepository interface {
+	FindOrCreateByNames(names []string) ([]models.Tag, error)
+	FindByName(name string) (*models.Tag, error)
+	FindAllWithArticleCount(isCategory bool, filters ArticleFilters) ([]TagWithArticleCount, error)
+}
+
+// TagWithArticleCount は閲覧可能な記事数付きのタグ
+type TagWithArticleCount struct {
+	models.Tag
+	ArticleCount int64
+}
+
+type tagRepository struct {
+	db *gorm.DB
+}
+
+func NewTagRepository(db *gorm.DB) TagRepository {
+	return &tagRepository{db: db}
+}
+
+// FindOrCreateByNames は名前でタグを取得し、存在しないタグは作成します
+// 戻り値は引数のnamesと同じ順序で返します
+func (r *tagRepository) FindOrCreateByNames(names []string) ([]models.Tag, error) {
+	if len(names) == 0 {
+		return []models.Tag{}, nil
+	}
+
+	newTags := make([]models.Tag, len(names))
+	for i, name := range names {
+		newTags[i] = models.Tag{Name: name}
+	}
+
+	// 既存のタグは作成せずにスキップ（同時作成時の重複も無視）
+	if err := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&newTags).Error; err != nil {
+		return nil, err
+	}
+
+	var found []models.Tag
+	if err := r.db.Where("name IN ?", names).Find(&found).Error; err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]models.Tag, len(found))
+	for _, tag := range found {
+		byName[tag.Name] = tag
+	}
+
+	tags := make([]models.Tag, 0, len(names))
+	for _, name := range names {
+		if tag, ok := byName[name]; ok {
+			tags = append(tags, tag)
+		}
+	}
+	return tags, nil
+}
+
+// FindByName は名前を指定してタグを取得します
+func (r *tagRepository) FindByName(name string) (*models.Tag, error) {
+	var tag models.Tag
+	if err := r.db.Where("name = ?", name).First(&tag).Error; err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// FindAllWithArticleCount はタグ（またはカテゴリ）の一覧を、閲覧者が見られる記事数付きで取得します
+// 記事数は記事一覧と同じ閲覧ルール（visibleArticles）で数えます
+// タグは下書きや社内限定の記事の保存時にも作成されるため、閲覧者が見られる記事が1件もないタグは返しません
+func (r *tagRepository) FindAllWithArticleCount(isCategory bool, filters ArticleFilters) ([]TagWithArticleCount, error) {
+	countQuery := r.db.Table("article_tags").
+		Select("COUNT(*)").
+		Joins("JOIN articles ON articles.id = article_tags.article_id").
+		Where("article_tags.tag_id = tags.id").
+		Scopes(visibleArticles(filters))
+
+	tagsWithCount := r.db.Model(&models.Tag{}).
+		Select("tags.*, (?) AS article_count", countQuery).
+		Where("tags.is_category = ?", isCategory)
+
+	var tags []TagWithArticleCount
+	err := r.db.Table("(?) AS tags", tagsWithCount).
+		Where("tags.article_count > 0").
+		Order("tags.article_count DESC, tags.name ASC").
+		Find(&tags).Error
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
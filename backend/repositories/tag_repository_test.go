@@ -0,0 +1,48 @@
+package repositories
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/yamada-mikiya/team1-hackathon/models"
+)
+
+func TestFindAllWithArticleCountHidesTagsWithoutVisibleArticles(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewTagRepository(db)
+
+	publicArticle := createTestArticle(t, db)
+	draftArticle := createTestArticle(t, db)
+	if err := db.Model(&models.Article{}).Where("id = ?", draftArticle.ID).UpdateColumn("status", "draft").Error; err != nil {
+		t.Fatalf("下書きへの変更に失敗しました: %v", err)
+	}
+
+	suffix := time.Now().UnixNano()
+	publicTagName := fmt.Sprintf("公開タグ-%d", suffix)
+	draftTagName := fmt.Sprintf("下書きタグ-%d", suffix)
+	tags, err := repo.FindOrCreateByNames([]string{publicTagName, draftTagName})
+	if err != nil {
+		t.Fatalf("FindOrCreateByNames: %v", err)
+	}
+	t.Cleanup(func() { db.Delete(&tags) })
+	if err := db.Exec("INSERT INTO article_tags (article_id, tag_id) VALUES (?, ?), (?, ?)",
+		publicArticle.ID, tags[0].ID, draftArticle.ID, tags[1].ID).Error; err != nil {
+		t.Fatalf("タグの設定に失敗しました: %v", err)
+	}
+
+	found, err := repo.FindAllWithArticleCount(false, ArticleFilters{})
+	if err != nil {
+		t.Fatalf("FindAllWithArticleCount: %v", err)
+	}
+	counts := map[string]int64{}
+	for _, tag := range found {
+		counts[tag.Name] = tag.ArticleCount
+	}
+	if counts[publicTagName] != 1 {
+		t.Errorf("公開記事のタグの記事数 = %d, want 1", counts[publicTagName])
+	}
+	if _, ok := counts[draftTagName]; ok {
+		t.Error("下書きにだけ付いているタグがゲストのタグ一覧に含まれています")
+	}
+}
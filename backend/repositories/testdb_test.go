@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// openTestDB はTEST_DATABASE_URLのデータベースにマイグレーションを適用して接続します
+// TEST_DATABASE_URLが設定されていない場合はテストをスキップします
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URLが設定されていないため、データベースを使うテストをスキップします")
+	}
+
+	m, err := migrate.New("file://../db/migrations", url)
+	if err != nil {
+		t.Fatalf("マイグレーションの初期化に失敗しました: %v", err)
+	}
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		t.Fatalf("マイグレーションに失敗しました: %v", err)
+	}
+	m.Close()
+
+	db, err := gorm.Open(postgres.Open(url), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("データベースへの接続に失敗しました: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
+
+// createTestArticle はテスト用のユーザーと公開記事を作成し、テストの終了時に削除します
+// 記事の更新日時は、更新されたかを判定できるよう過去の日時にしておきます
+func createTestArticle(t *testing.T, db *gorm.DB) *models.Article {
+	t.Helper()
+	suffix := fmt.Sprintf("%s-%d", t.Name(), time.Now().UnixNano())
+
+	user := &models.User{Name: "テストユーザー", Email: suffix + "@example.com", PasswordHash: "x"}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatalf("ユーザーの作成に失敗しました: %v", err)
+	}
+	t.Cleanup(func() { db.Delete(&models.User{}, user.ID) })
+
+	content := "# テスト"
+	article := &models.Article{
+		AuthorID:    user.ID,
+		ArticleType: "markdown",
+		Title:       "テスト記事",
+		Content:     &content,
+		Slug:        suffix,
+		Department:  "Dev",
+		Status:      "public",
+	}
+	if err := db.Omit("Author", "ViewerReactions").Create(article).Error; err != nil {
+		t.Fatalf("記事の作成に失敗しました: %v", err)
+	}
+
+	updatedAt := time.Now().Add(-24 * time.Hour).Truncate(time.Microsecond)
+	if err := db.Model(&models.Article{}).Where("id = ?", article.ID).UpdateColumn("updated_at", updatedAt).Error; err != nil {
+		t.Fatalf("記事の更新日時の設定に失敗しました: %v", err)
+	}
+	article.UpdatedAt = updatedAt
+	return article
+}
+
+// reloadTestArticle は記事をDBから読み直します
+func reloadTestArticle(t *testing.T, db *gorm.DB, id int) *models.Article {
+	t.Helper()
+	var article models.Article
+	if err := db.First(&article, id).Error; err != nil {
+		t.Fatalf("記事の取得に失敗しました: %v", err)
+	}
+	return &article
+}
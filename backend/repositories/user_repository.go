@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/yamada-mikiya/team1-hackathon/models"
 	"gorm.io/gorm"
@@ -12,6 +13,14 @@ type UserRepository interface {
 	CreateUser(ctx context.Context, user *models.User) error
 	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
 	GetUserByID(ctx context.Context, userID int) (*models.User, error)
+	GetUsersByIDs(ctx context.Context, userIDs []int) ([]models.User, error)
+	FindAll(ctx context.Context, page, limit int) ([]models.User, int64, error)
+	UpdateRole(ctx context.Context, userID int, role string, department *string) error
+	UpdatePassword(ctx context.Context, userID int, passwordHash string) error
+	MarkEmailVerified(ctx context.Context, userID int, verifiedAt time.Time) error
+	RevokeEmailVerificationOutsideDomains(ctx context.Context, domains []string) (int64, error)
+	UpdateProfile(ctx context.Context, userID int, name string, affiliation *string) error
+	UpdateIconURL(ctx context.Context, userID int, iconURL string) error
 }
 
 type userRepository struct {
@@ -53,3 +62,101 @@ func (r *userRepository) GetUserByID(ctx context.Context, userID int) (*models.U
 	}
 	return &user, nil
 }
+
+// GetUsersByIDs は複数のユーザーIDからユーザーを取得します（存在しないIDは無視されます）
+func (r *userRepository) GetUsersByIDs(ctx context.Context, userIDs []int) ([]models.User, error) {
+	var users []models.User
+	if err := r.db.WithContext(ctx).Where("id IN ?", userIDs).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// FindAll はユーザー一覧をID順に取得します（管理者用）
+func (r *userRepository) FindAll(ctx context.Context, page, limit int) ([]models.User, int64, error) {
+	var users []models.User
+	var totalCount int64
+
+	query := r.db.WithContext(ctx).Model(&models.User{})
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	if err := query.Order("id").Offset(offset).Limit(limit).Find(&users).Error; err != nil {
+		return nil, 0, err
+	}
+	return users, totalCount, nil
+}
+
+// UpdateRole はユーザーのロールと所属部署を更新します
+func (r *userRepository) UpdateRole(ctx context.Context, userID int, role string, department *string) error {
+	result := r.db.WithContext(ctx).Model(&models.User{}).
+		Where("id = ?", userID).
+		Updates(map[string]interface{}{"role": role, "department": department})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// UpdatePassword はユーザーのパスワードハッシュを更新します
+func (r *userRepository) UpdatePassword(ctx context.Context, userID int, passwordHash string) error {
+	result := r.db.WithContext(ctx).Model(&models.User{}).
+		Where("id = ?", userID).
+		Update("password_hash", passwordHash)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// MarkEmailVerified はメールアドレスを確認済みにします。既に確認済みの場合は確認日時を変更しません
+func (r *userRepository) MarkEmailVerified(ctx context.Context, userID int, verifiedAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.User{}).
+		Where("id = ? AND email_verified_at IS NULL", userID).
+		Update("email_verified_at", verifiedAt).Error
+}
+
+// RevokeEmailVerificationOutsideDomains は指定したドメイン（小文字）以外のメールアドレスのユーザーを未確認に戻し、戻した人数を返します
+func (r *userRepository) RevokeEmailVerificationOutsideDomains(ctx context.Context, domains []string) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&models.User{}).
+		Where("email_verified_at IS NOT NULL").
+		Where("COALESCE(lower(substring(email from '@([^@]*)$')), '') NOT IN ?", domains).
+		Update("email_verified_at", nil)
+	return result.RowsAffected, result.Error
+}
+
+// UpdateProfile はユーザーの名前と所属を更新します
+func (r *userRepository) UpdateProfile(ctx context.Context, userID int, name string, affiliation *string) error {
+	result := r.db.WithContext(ctx).Model(&models.User{}).
+		Where("id = ?", userID).
+		Updates(map[string]interface{}{"name": name, "affiliation": affiliation})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// UpdateIconURL はユーザーのアイコン画像のURLを更新します
+func (r *userRepository) UpdateIconURL(ctx context.Context, userID int, iconURL string) error {
+	result := r.db.WithContext(ctx).Model(&models.User{}).
+		Where("id = ?", userID).
+		Update("icon_url", iconURL)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
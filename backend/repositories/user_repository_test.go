@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/yamada-mikiya/team1-hackathon/models"
+)
+
+func TestRevokeEmailVerificationOutsideDomains(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewUserRepository(db)
+	ctx := context.Background()
+	now := time.Now()
+
+	suffix := fmt.Sprintf("%d", now.UnixNano())
+	member := &models.User{Name: "メンバー", Email: "member-" + suffix + "@Example.com", PasswordHash: "x", EmailVerifiedAt: &now}
+	outsider := &models.User{Name: "社外のユーザー", Email: "outsider-" + suffix + "@example.org", PasswordHash: "x", EmailVerifiedAt: &now}
+	for _, user := range []*models.User{member, outsider} {
+		if err := repo.CreateUser(ctx, user); err != nil {
+			t.Fatalf("ユーザーの作成に失敗しました: %v", err)
+		}
+		t.Cleanup(func() { db.Delete(&models.User{}, user.ID) })
+	}
+
+	if _, err := repo.RevokeEmailVerificationOutsideDomains(ctx, []string{"example.com"}); err != nil {
+		t.Fatalf("RevokeEmailVerificationOutsideDomains: %v", err)
+	}
+
+	// ドメインは大文字・小文字を区別しない
+	if got, err := repo.GetUserByID(ctx, member.ID); err != nil {
+		t.Fatalf("GetUserByID: %v", err)
+	} else if got.EmailVerifiedAt == nil {
+		t.Error("許可するドメインのユーザーが未確認に戻っています")
+	}
+	if got, err := repo.GetUserByID(ctx, outsider.ID); err != nil {
+		t.Fatalf("GetUserByID: %v", err)
+	} else if got.EmailVerifiedAt != nil {
+		t.Error("許可するドメイン以外のユーザーが確認済みのままです")
+	}
+}
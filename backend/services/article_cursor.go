@@ -0,0 +1,117 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"github.com/yamada-mikiya/team1-hackathon/repositories"
+)
+
+// articleCursor は記事一覧のカーソルの中身。クライアントには中身を意識させないよう、JSONをBase64URLにして渡します
+// 並び順ごとに位置の値が異なるため、どの並び順で作成したカーソルかも含めます
+type articleCursor struct {
+	Sort   string          `json:"s"`
+	Order  string          `json:"o"`
+	Value  json.RawMessage `json:"v"`
+	ID     int             `json:"i"`
+	Before bool            `json:"b,omitempty"`
+}
+
+// errInvalidCursor はカーソルを読み取れない場合のエラー
+var errInvalidCursor = &ValidationError{Message: "cursorが不正です"}
+
+// effectiveSort は並び順のキーと向きの既定値を補います（既定は新着順、titleは昇順）
+// 並び順の指定がないキーワード検索は関連度順（relevance）になり、orderは無視します
+func effectiveSort(filters repositories.ArticleFilters) (string, string) {
+	sort, order := filters.Sort, filters.Order
+	if sort == "" && len(filters.Keywords) > 0 {
+		return "relevance", "desc"
+	}
+	if sort == "" {
+		sort = "created_at"
+	}
+	if order == "" {
+		order = "desc"
+		if sort == "title" {
+			order = "asc"
+		}
+	}
+	return sort, order
+}
+
+// sortValue は並び順のキーに対応する記事の値を返します
+func sortValue(article *models.Article, sort string) any {
+	switch sort {
+	case "updated_at":
+		return article.UpdatedAt
+	case "title":
+		return article.Title
+	case "popularity":
+		return article.ViewCount
+	case "relevance":
+		return article.Relevance
+	}
+	return article.CreatedAt
+}
+
+// encodeArticleCursor はarticleの位置を表すカーソルを作成します
+// beforeがtrueの場合はこの記事より前のページ、falseの場合は後ろのページを指します
+func encodeArticleCursor(filters repositories.ArticleFilters, article *models.Article, before bool) *string {
+	sort, order := effectiveSort(filters)
+	value, _ := json.Marshal(sortValue(article, sort))
+	data, _ := json.Marshal(articleCursor{Sort: sort, Order: order, Value: value, ID: article.ID, Before: before})
+	cursor := base64.RawURLEncoding.EncodeToString(data)
+	return &cursor
+}
+
+// decodeArticleCursor はカーソルを読み取ります。空の場合はnil（先頭から）を返します
+// 別の並び順で作成されたカーソルは、位置の意味が変わるためエラーにします
+func decodeArticleCursor(filters repositories.ArticleFilters, cursor string) (*repositories.ArticleCursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+	var c articleCursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID < 1 {
+		return nil, errInvalidCursor
+	}
+	if sort, order := effectiveSort(filters); c.Sort != sort || c.Order != order {
+		return nil, &ValidationError{Message: "cursorは同じsort・orderで取得したものを指定してください"}
+	}
+
+	var value any
+	switch c.Sort {
+	case "created_at", "updated_at":
+		var t time.Time
+		if err := json.Unmarshal(c.Value, &t); err != nil || t.IsZero() {
+			return nil, errInvalidCursor
+		}
+		value = t
+	case "title":
+		var title string
+		if err := json.Unmarshal(c.Value, &title); err != nil {
+			return nil, errInvalidCursor
+		}
+		value = title
+	case "popularity":
+		var views int64
+		if err := json.Unmarshal(c.Value, &views); err != nil {
+			return nil, errInvalidCursor
+		}
+		value = views
+	case "relevance":
+		var relevance float64
+		if err := json.Unmarshal(c.Value, &relevance); err != nil {
+			return nil, errInvalidCursor
+		}
+		value = relevance
+	default:
+		return nil, errInvalidCursor
+	}
+	return &repositories.ArticleCursor{Value: value, ID: c.ID, Before: c.Before}, nil
+}
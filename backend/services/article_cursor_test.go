@@ -0,0 +1,30 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"github.com/yamada-mikiya/team1-hackathon/repositories"
+)
+
+func TestArticleCursorRelevance(t *testing.T) {
+	filters := repositories.ArticleFilters{Keywords: []string{"検索"}}
+	article := &models.Article{ID: 42, Relevance: 0.1 + 0.2}
+
+	// 並び順の指定がないキーワード検索は関連度順のカーソルになり、関連度をそのまま復元できる
+	cursor := encodeArticleCursor(filters, article, true)
+	position, err := decodeArticleCursor(filters, *cursor)
+	if err != nil {
+		t.Fatalf("decodeArticleCursor: %v", err)
+	}
+	if position.Value != article.Relevance || position.ID != article.ID || !position.Before {
+		t.Errorf("position = %+v, want {Value:%v ID:%d Before:true}", position, article.Relevance, article.ID)
+	}
+
+	// 関連度順のカーソルは、並び順を指定した一覧では使えない
+	sorted := filters
+	sorted.Sort = "created_at"
+	if _, err := decodeArticleCursor(sorted, *cursor); err == nil {
+		t.Error("別の並び順のカーソルを受け付けました")
+	}
+}
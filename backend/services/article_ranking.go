@@ -0,0 +1,77 @@
+package services
+
+import (
+	"time"
+
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"github.com/yamada-mikiya/team1-hackathon/repositories"
+)
+
+// trendingHalfLife はトレンドのスコアで閲覧の重みが半分になるまでの時間
+// 集計の対象はrepositories.HourlyViewRetention（7日間）の閲覧です
+const trendingHalfLife = 24 * time.Hour
+
+// GetPopularArticles は閲覧者が見られる記事を累計の閲覧数の多い順にlimit件取得します
+func (s *articleService) GetPopularArticles(filters repositories.ArticleFilters, limit int) (*models.ArticleListResponse, error) {
+	filters, err := prepareListFilters(filters)
+	if err != nil {
+		return nil, err
+	}
+
+	articles, err := s.repo.FindPopular(filters, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.ArticleListResponse{
+		Articles: make([]models.ArticleResponse, len(articles)),
+		Limit:    limit,
+	}
+	for i, article := range articles {
+		ensureRendered(&article)
+		response.Articles[i] = s.convertArticleToResponse(&article)
+	}
+	return response, nil
+}
+
+// GetTrendingArticles は閲覧者が見られる記事を、部署ごとに直近の閲覧数から計算したスコアの高い順にlimit件ずつ取得します
+// 新しい閲覧ほど重く数えるため、累計の閲覧数が少なくても最近よく読まれている記事が上位になります
+// 部署の指定がない場合はすべての部署を返します（記事がない部署は空の一覧）
+func (s *articleService) GetTrendingArticles(filters repositories.ArticleFilters, limit int) (*models.TrendingArticlesResponse, error) {
+	filters, err := prepareListFilters(filters)
+	if err != nil {
+		return nil, err
+	}
+
+	articles, err := s.repo.FindTrending(filters, time.Now(), trendingHalfLife, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	departments := filters.Departments
+	if len(departments) == 0 {
+		departments = validDepartments
+	}
+	response := &models.TrendingArticlesResponse{Departments: []models.DepartmentTrendingArticles{}}
+	index := map[string]int{}
+	for _, department := range departments {
+		if _, ok := index[department]; ok {
+			continue
+		}
+		index[department] = len(response.Departments)
+		response.Departments = append(response.Departments, models.DepartmentTrendingArticles{
+			Department: department,
+			Articles:   []models.ArticleResponse{},
+		})
+	}
+
+	for _, article := range articles {
+		i, ok := index[article.Department]
+		if !ok {
+			continue
+		}
+		ensureRendered(&article)
+		response.Departments[i].Articles = append(response.Departments[i].Articles, s.convertArticleToResponse(&article))
+	}
+	return response, nil
+}
@@ -1,34 +1,80 @@
 package services
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/yamada-mikiya/team1-hackathon/linkpreview"
 	"github.com/yamada-mikiya/team1-hackathon/models"
 	"github.com/yamada-mikiya/team1-hackathon/repositories"
-	"errors"
+	"gorm.io/gorm"
 )
 
 type ArticleService interface {
 	GetArticles(filters repositories.ArticleFilters, page, limit int) (*models.ArticleListResponse, error)
-	GetArticleBySlug(slug string, isAuthenticated bool) (*models.ArticleResponse, error)
+	GetArticlesByCursor(filters repositories.ArticleFilters, cursor string, limit int, includeTotal bool) (*models.ArticleListResponse, error)
+	GetPopularArticles(filters repositories.ArticleFilters, limit int) (*models.ArticleListResponse, error)
+	GetTrendingArticles(filters repositories.ArticleFilters, limit int) (*models.TrendingArticlesResponse, error)
+	GetArticleBySlug(slug string, userID int) (*models.ArticleResponse, error)
+	GetArticlePreview(slug string, articleID int) (*models.ArticleResponse, error)
+	CreateArticle(ctx context.Context, userID int, req models.CreateArticleRequest) (*models.ArticleResponse, error)
+	UpdateArticle(ctx context.Context, slug string, userID int, req models.CreateArticleRequest) (*models.ArticleResponse, error)
+	PatchArticle(ctx context.Context, slug string, userID int, req models.PatchArticleRequest) (*models.ArticleResponse, error)
+	DeleteArticle(slug string, userID int) error
 }
 
+var (
+	validArticleTypes = []string{"markdown", "external"}
+	validDepartments  = []string{"Dev", "MKT", "Ops"}
+	validStatuses     = []string{"draft", "in_review", "internal", "public"}
+	// 予約公開で切り替え先として指定できるステータス
+	validScheduledStatuses = []string{"internal", "public"}
+	// 記事一覧で絞り込みに指定できるステータス
+	validListStatuses = []string{"internal", "public", "all"}
+	// 記事一覧の並び順
+	validSorts  = []string{"created_at", "updated_at", "title", "popularity"}
+	validOrders = []string{"asc", "desc"}
+	slugPattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+	// /api/articles/以下の固定のパスと重なるため、記事のslugには使えない値
+	reservedSlugs = []string{"popular", "trending"}
+)
+
+const (
+	maxTagsPerArticle = 10
+	maxTagNameLength  = 50
+)
 
 type articleService struct {
-	repo repositories.ArticleRepository
+	repo        repositories.ArticleRepository
+	tagRepo     repositories.TagRepository
+	reviewRepo  repositories.ReviewRepository
+	userRepo    repositories.UserRepository
+	linkPreview linkpreview.Fetcher
 }
 
-func NewArticleService(repo repositories.ArticleRepository) ArticleService {
-	return &articleService{repo: repo}
+// NewArticleService は記事サービスを作成します
+// linkPreviewには外部記事のリンクプレビューを取得するFetcherを指定します（記事を保存しない用途ではnilでも構いません）
+func NewArticleService(repo repositories.ArticleRepository, tagRepo repositories.TagRepository, reviewRepo repositories.ReviewRepository, userRepo repositories.UserRepository, linkPreview linkpreview.Fetcher) ArticleService {
+	return &articleService{repo: repo, tagRepo: tagRepo, reviewRepo: reviewRepo, userRepo: userRepo, linkPreview: linkPreview}
 }
 
 // GetArticles は記事一覧を取得します
 func (s *articleService) GetArticles(filters repositories.ArticleFilters, page, limit int) (*models.ArticleListResponse, error) {
-	// リポジトリから記事を取得
-	filtersInRepository := repositories.ArticleFilters{
-		Department: filters.Department,
-		Status:     filters.Status,
-		IsAuthenticated: filters.IsAuthenticated,
+	filters, err := prepareListFilters(filters)
+	if err != nil {
+		return nil, err
 	}
-	articles, totalCount, err := s.repo.FindAll(filtersInRepository, page, limit)
+
+	// リポジトリから記事を取得
+	articles, totalCount, err := s.repo.FindAll(filters, page, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -36,33 +82,158 @@ func (s *articleService) GetArticles(filters repositories.ArticleFilters, page,
 	// レスポンスを構築
 	articleResponses := make([]models.ArticleResponse, len(articles))
 	for i, article := range articles {
+		ensureRendered(&article)
 		articleResponses[i] = s.convertArticleToResponse(&article)
+		// キーワード検索時は本文の一致箇所をハイライトした抜粋を付ける
+		if len(filters.Keywords) > 0 && article.Content != nil {
+			articleResponses[i].Snippet = buildSnippet(*article.Content, filters.Keywords)
+		}
 	}
 
-	totalPages := int((totalCount + int64(limit) - 1) / int64(limit))
+	total := int(totalCount)
+	totalPages := (total + limit - 1) / limit
 
 	return &models.ArticleListResponse{
 		Articles:   articleResponses,
-		TotalCount: int(totalCount),
+		TotalCount: &total,
 		Page:       page,
 		Limit:      limit,
-		TotalPages: totalPages,
+		TotalPages: &totalPages,
 	}, nil
 }
 
+// GetArticlesByCursor はカーソル（キーセットページネーション）で記事一覧を取得します。並び順は既定で新着順です
+// cursorには前回のレスポンスのnext_cursorまたはprev_cursorを指定します（空の場合は先頭から）
+// 総件数の取得は記事が多いと重いため、includeTotalがtrueの場合のみ行います
+func (s *articleService) GetArticlesByCursor(filters repositories.ArticleFilters, cursor string, limit int, includeTotal bool) (*models.ArticleListResponse, error) {
+	filters, err := prepareListFilters(filters)
+	if err != nil {
+		return nil, err
+	}
+	position, err := decodeArticleCursor(filters, cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	articles, hasMore, err := s.repo.FindAllByCursor(filters, position, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.ArticleListResponse{
+		Articles: make([]models.ArticleResponse, len(articles)),
+		Limit:    limit,
+	}
+	for i, article := range articles {
+		ensureRendered(&article)
+		response.Articles[i] = s.convertArticleToResponse(&article)
+		if len(filters.Keywords) > 0 && article.Content != nil {
+			response.Articles[i].Snippet = buildSnippet(*article.Content, filters.Keywords)
+		}
+	}
+
+	if len(articles) > 0 {
+		first, last := &articles[0], &articles[len(articles)-1]
+		backward := position != nil && position.Before
+		// 後ろに記事があるのは、後ろへ進んでまだ続きがある場合か、前のページへ戻ってきた場合
+		if hasMore || backward {
+			response.NextCursor = encodeArticleCursor(filters, last, false)
+		}
+		// 前に記事があるのは、前へ戻ってまだ続きがある場合か、2ページ目以降へ進んだ場合
+		if (backward && hasMore) || (!backward && position != nil) {
+			response.PrevCursor = encodeArticleCursor(filters, first, true)
+		}
+	}
+
+	if includeTotal {
+		count, err := s.repo.Count(filters)
+		if err != nil {
+			return nil, err
+		}
+		total := int(count)
+		response.TotalCount = &total
+	}
+
+	return response, nil
+}
+
+// prepareListFilters は記事一覧のフィルタと並び順を検証し、検索文字列をキーワードに分割します
+// 不明な値は黙って無視せず、*ValidationErrorを返します
+func prepareListFilters(filters repositories.ArticleFilters) (repositories.ArticleFilters, error) {
+	for _, department := range filters.Departments {
+		if !slices.Contains(validDepartments, department) {
+			return filters, &ValidationError{Message: fmt.Sprintf("departmentに不明な値 %q が指定されています（Dev, MKT, Opsのいずれか）", department)}
+		}
+	}
+	for _, articleType := range filters.ArticleTypes {
+		if !slices.Contains(validArticleTypes, articleType) {
+			return filters, &ValidationError{Message: fmt.Sprintf("article_typeに不明な値 %q が指定されています（markdown, externalのいずれか）", articleType)}
+		}
+	}
+	if filters.Status != "" && !slices.Contains(validListStatuses, filters.Status) {
+		return filters, &ValidationError{Message: fmt.Sprintf("statusに不明な値 %q が指定されています（internal, public, allのいずれか）", filters.Status)}
+	}
+	if filters.Sort != "" && !slices.Contains(validSorts, filters.Sort) {
+		return filters, &ValidationError{Message: fmt.Sprintf("sortに不明な値 %q が指定されています（created_at, updated_at, title, popularityのいずれか）", filters.Sort)}
+	}
+	if filters.Order != "" {
+		if !slices.Contains(validOrders, filters.Order) {
+			return filters, &ValidationError{Message: fmt.Sprintf("orderに不明な値 %q が指定されています（asc, descのいずれか）", filters.Order)}
+		}
+		if filters.Sort == "" {
+			return filters, &ValidationError{Message: "orderを指定する場合はsortも指定してください"}
+		}
+	}
+	if filters.CreatedAfter != nil && filters.CreatedBefore != nil && !filters.CreatedAfter.Before(*filters.CreatedBefore) {
+		return filters, &ValidationError{Message: "created_afterにはcreated_beforeより前の日時を指定してください"}
+	}
+
+	keywords, err := parseSearchQuery(filters.Query)
+	if err != nil {
+		return filters, err
+	}
+	filters.Keywords = keywords
+	return filters, nil
+}
 
 // GetArticleBySlug はslugを指定して記事を取得します
-func (s *articleService) GetArticleBySlug(slug string, isAuthenticated bool) (*models.ArticleResponse, error) {
-	article, err := s.repo.FindBySlug(slug, isAuthenticated)
+// userIDには閲覧者のユーザーID（ゲストの場合は0）を指定します。著者本人は下書きも閲覧できます
+func (s *articleService) GetArticleBySlug(slug string, userID int) (*models.ArticleResponse, error) {
+	article, err := s.repo.FindBySlug(slug, userID)
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrArticleNotFound
+		}
 		return nil, err
 	}
 
 	//データがnilだった場合のチェック
 	if article == nil {
-		return nil, errors.New("article not found")
+		return nil, ErrArticleNotFound
+	}
+
+	ensureRendered(article)
+	res := s.convertArticleToResponse(article)
+	return &res, nil
+}
+
+// GetArticlePreview はプレビューリンクで指定された記事を、ステータスに関わらず取得します
+// articleIDには検証済みのプレビュートークンに含まれる記事IDを指定します
+func (s *articleService) GetArticlePreview(slug string, articleID int) (*models.ArticleResponse, error) {
+	article, err := s.repo.FindBySlugAnyStatus(slug)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrArticleNotFound
+		}
+		return nil, err
+	}
+
+	// トークンは発行された記事にのみ有効
+	if article.ID != articleID {
+		return nil, ErrInvalidPreview
 	}
 
+	ensureRendered(article)
 	res := s.convertArticleToResponse(article)
 	return &res, nil
 }
@@ -74,26 +245,426 @@ func (s *articleService) convertArticleToResponse(article *models.Article) model
 
 	// Authorのnilチェックと詰め替え
 	if article.Author != nil {
-		authorResponse = models.AuthorResponse{
-			ID:          article.Author.ID,
-			Name:        article.Author.Name,
-			Affiliation: article.Author.Affiliation,
-			IconURL:     article.Author.IconURL,
-		}
+		authorResponse = convertUserToAuthorResponse(article.Author)
 	}
 
 	return models.ArticleResponse{
-		ID:           article.ID,
-		Title:        article.Title,
-		ArticleType:  article.ArticleType,
-		Content:      article.Content,
-		ExternalURL:  article.ExternalURL,
-		ThumbnailURL: article.ThumbnailURL,
-		Slug:         article.Slug,
-		Department:   article.Department,
-		Status:       article.Status,
-		Author:       authorResponse,
-		CreatedAt:    article.CreatedAt,
-		UpdatedAt:    article.UpdatedAt,
+		ID:                 article.ID,
+		Title:              article.Title,
+		ArticleType:        article.ArticleType,
+		Content:            article.Content,
+		ExternalURL:        article.ExternalURL,
+		ThumbnailURL:       article.ThumbnailURL,
+		Slug:               article.Slug,
+		Department:         article.Department,
+		Status:             article.Status,
+		PublishAt:          article.PublishAt,
+		ScheduledStatus:    article.ScheduledStatus,
+		Author:             authorResponse,
+		CreatedAt:          article.CreatedAt,
+		UpdatedAt:          article.UpdatedAt,
+		Tags:               tagNamesOf(article.Tags),
+		ContentHTML:        article.ContentHTML,
+		TOC:                article.TOC,
+		ReadingTimeMinutes: article.ReadingTimeMinutes,
+		Excerpt:            article.Excerpt,
+		ViewCount:          article.ViewCount,
+		ReactionCounts:     reactionCountsOf(article.ReactionCounts),
+		MyReactions:        reactionEmojisOf(article.ViewerReactions),
+	}
+}
+
+// ensureRendered はHTMLなどのキャッシュがまだない記事（キャッシュを導入する前に保存された記事）について、
+// レスポンス用にその場で作成します。保存は起動時のワーカー（RenderBackfill）が行うため、取得時にはDBへ書き込みません
+// 失敗しても記事の取得自体は続けられるよう、ログに残すだけにします
+func ensureRendered(article *models.Article) {
+	if !needsRendering(article) {
+		return
+	}
+	if err := applyRenderedContent(article); err != nil {
+		slog.Warn("記事本文のHTMLの作成に失敗しました", "article_id", article.ID, "error", err)
+	}
+}
+
+// CreateArticle はログインユーザーを著者として記事を作成します
+func (s *articleService) CreateArticle(ctx context.Context, userID int, req models.CreateArticleRequest) (*models.ArticleResponse, error) {
+	if err := requireWriter(s.userRepo, userID); err != nil {
+		return nil, err
+	}
+
+	article := &models.Article{AuthorID: userID}
+	applyCreateRequest(article, req)
+	if err := setSchedule(article, req.PublishAt, req.ScheduledStatus); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateArticle(article); err != nil {
+		return nil, err
+	}
+	tagNames, err := normalizeTagNames(req.Tags)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkStatusTransition(&models.Article{Status: "draft"}, article, tagNames); err != nil {
+		return nil, err
+	}
+	if err := s.completeWithLinkPreview(ctx, nil, article); err != nil {
+		return nil, err
+	}
+
+	tags, err := s.tagRepo.FindOrCreateByNames(tagNames)
+	if err != nil {
+		return nil, err
+	}
+	article.Tags = tags
+
+	if err := applyRenderedContent(article); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(article, userID); err != nil {
+		return nil, err
+	}
+
+	return s.reloadArticle(article.Slug)
+}
+
+// UpdateArticle は記事全体を置き換えます（PUT）
+func (s *articleService) UpdateArticle(ctx context.Context, slug string, userID int, req models.CreateArticleRequest) (*models.ArticleResponse, error) {
+	article, err := findManageableArticle(s.repo, s.userRepo, slug, userID)
+	if err != nil {
+		return nil, err
+	}
+	before := *article
+
+	applyCreateRequest(article, req)
+	if err := setSchedule(article, req.PublishAt, req.ScheduledStatus); err != nil {
+		return nil, err
+	}
+
+	return s.saveArticle(ctx, &before, article, req.Tags, userID)
+}
+
+// PatchArticle は指定された項目のみ記事を更新します（PATCH）
+func (s *articleService) PatchArticle(ctx context.Context, slug string, userID int, req models.PatchArticleRequest) (*models.ArticleResponse, error) {
+	article, err := findManageableArticle(s.repo, s.userRepo, slug, userID)
+	if err != nil {
+		return nil, err
+	}
+	before := *article
+
+	if req.Title != nil {
+		article.Title = strings.TrimSpace(*req.Title)
+	}
+	if req.ArticleType != nil {
+		article.ArticleType = *req.ArticleType
+	}
+	if req.Content != nil {
+		article.Content = req.Content
+	}
+	if req.ExternalURL != nil {
+		article.ExternalURL = req.ExternalURL
+	}
+	if req.ThumbnailURL != nil {
+		article.ThumbnailURL = req.ThumbnailURL
+	}
+	if req.Slug != nil {
+		article.Slug = strings.TrimSpace(*req.Slug)
+	}
+	if req.Department != nil {
+		article.Department = *req.Department
+	}
+	if req.Status != nil {
+		article.Status = *req.Status
+	}
+	if req.ScheduledStatus != nil {
+		if err := setSchedule(article, req.PublishAt, req.ScheduledStatus); err != nil {
+			return nil, err
+		}
+	} else if req.PublishAt != nil {
+		// 公開日時のみ変更する場合は既存の予約に対して適用する
+		if article.ScheduledStatus == nil {
+			return nil, &ValidationError{Message: "予約公開するにはscheduled_statusも指定してください"}
+		}
+		if err := setSchedule(article, req.PublishAt, article.ScheduledStatus); err != nil {
+			return nil, err
+		}
+	}
+
+	// tagsが省略された場合は既存のタグを維持する
+	var tagNames []string
+	if req.Tags != nil {
+		tagNames = *req.Tags
+	} else {
+		tagNames = tagNamesOf(article.Tags)
+	}
+
+	return s.saveArticle(ctx, &before, article, tagNames, userID)
+}
+
+// DeleteArticle は記事を削除します
+func (s *articleService) DeleteArticle(slug string, userID int) error {
+	article, err := findManageableArticle(s.repo, s.userRepo, slug, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(article.ID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrArticleNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// saveArticle は検証後に記事とタグを保存し（リビジョンも記録されます）、最新の状態をレスポンスとして返します
+// beforeには変更前の記事を渡し、ステータス遷移の検証とレビュー承認の取り消しに使います
+// 外部記事のリンクプレビューは、検証を通った場合のみリクエストのctxで取得します
+func (s *articleService) saveArticle(ctx context.Context, before, article *models.Article, tagNames []string, editorID int) (*models.ArticleResponse, error) {
+	if err := s.validateArticle(article); err != nil {
+		return nil, err
+	}
+	tagNames, err := normalizeTagNames(tagNames)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkStatusTransition(before, article, tagNames); err != nil {
+		return nil, err
+	}
+	changed := contentChanged(before, article, tagNames)
+	if err := s.completeWithLinkPreview(ctx, before, article); err != nil {
+		return nil, err
+	}
+
+	tags, err := s.tagRepo.FindOrCreateByNames(tagNames)
+	if err != nil {
+		return nil, err
+	}
+	article.Tags = tags
+
+	if err := applyRenderedContent(article); err != nil {
+		return nil, err
+	}
+	// 承認後に内容が変わった場合、その承認は記事の保存と同じトランザクションで無効にする
+	if changed {
+		err = s.repo.UpdateAndResetApprovals(article, editorID)
+	} else {
+		err = s.repo.Update(article, editorID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.reloadArticle(article.Slug)
+}
+
+// checkStatusTransition は記事のステータス遷移を検証します
+//
+//	draft / internal -> in_review : レビュー依頼（POST /api/articles/{slug}/reviews）からのみ
+//	in_review -> draft            : 修正依頼または取り下げ
+//	任意 -> public                : 記事の部署のレビュアーが現在の内容を承認している場合のみ
+//	public -> internal / draft    : いつでも可能（公開の取り下げ）
+//
+// 公開中の記事の内容（公開ページに表示される項目。contentChangedを参照）や部署は、レビューを経ずに変更できないよう、公開のままでは変更できません
+// 公開を取り下げてから変更し、改めてレビューを依頼します。tagNamesには変更後の正規化したタグ名を渡します
+func (s *articleService) checkStatusTransition(before, after *models.Article, tagNames []string) error {
+	if before.Status == "public" && after.Status == "public" &&
+		(contentChanged(before, after, tagNames) || before.Department != after.Department) {
+		return ErrReviewApprovalRequired
+	}
+	if before.Status == after.Status {
+		return nil
+	}
+
+	switch after.Status {
+	case "in_review":
+		return &ValidationError{Message: "レビュー依頼は POST /api/articles/{slug}/reviews で行ってください"}
+	case "public":
+		// 新規作成時や、承認後に内容を変更した直後は承認がないものとして扱う
+		if after.ID == 0 || contentChanged(before, after, tagNames) {
+			return ErrReviewApprovalRequired
+		}
+		approved, err := s.reviewRepo.HasDepartmentApproval(after.ID, after.Department)
+		if err != nil {
+			return err
+		}
+		if !approved {
+			return ErrReviewApprovalRequired
+		}
+	}
+	return nil
+}
+
+// reloadArticle は保存後の記事を著者情報付きで取得し直します
+func (s *articleService) reloadArticle(slug string) (*models.ArticleResponse, error) {
+	saved, err := s.repo.FindBySlugAnyStatus(slug)
+	if err != nil {
+		return nil, err
+	}
+
+	res := s.convertArticleToResponse(saved)
+	return &res, nil
+}
+
+// validateArticle はarticle_typeごとの必須項目やenum値、slugの重複を検証します
+// 外部記事のタイトルはリンク先のページから補うため、ここでは省略できます（completeWithLinkPreviewで検証します）
+func (s *articleService) validateArticle(article *models.Article) error {
+	if article.Title == "" && article.ArticleType != "external" {
+		return &ValidationError{Message: "タイトルは必須です"}
+	}
+	if !slices.Contains(validArticleTypes, article.ArticleType) {
+		return &ValidationError{Message: "article_typeはmarkdownまたはexternalを指定してください"}
+	}
+	if !slices.Contains(validDepartments, article.Department) {
+		return &ValidationError{Message: "departmentはDev, MKT, Opsのいずれかを指定してください"}
+	}
+	if !slices.Contains(validStatuses, article.Status) {
+		return &ValidationError{Message: "statusはdraft, in_review, internal, publicのいずれかを指定してください"}
+	}
+	if article.ScheduledStatus != nil && *article.ScheduledStatus == article.Status {
+		return &ValidationError{Message: "scheduled_statusには現在のstatusと異なる値を指定してください"}
+	}
+	if !slugPattern.MatchString(article.Slug) {
+		return &ValidationError{Message: "slugは半角英小文字・数字・ハイフンのみで指定してください"}
+	}
+	if slices.Contains(reservedSlugs, article.Slug) {
+		return &ValidationError{Message: fmt.Sprintf("slugに %q は使用できません", article.Slug)}
+	}
+
+	switch article.ArticleType {
+	case "markdown":
+		// markdown記事は本文が必須
+		if article.Content == nil || strings.TrimSpace(*article.Content) == "" {
+			return &ValidationError{Message: "markdown記事にはcontentが必要です"}
+		}
+	case "external":
+		// 外部記事はURLが必須
+		if article.ExternalURL == nil || !isHTTPURL(*article.ExternalURL) {
+			return &ValidationError{Message: "external記事には有効なexternal_urlが必要です"}
+		}
+	}
+
+	// slugの重複チェック（自分自身は除く）
+	existing, err := s.repo.FindBySlugAnyStatus(article.Slug)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if existing != nil && existing.ID != article.ID {
+		return ErrSlugAlreadyExists
+	}
+
+	return nil
+}
+
+// normalizeTagNames はタグ名の前後の空白と重複を取り除き、タグの数と長さを検証します
+func normalizeTagNames(names []string) ([]string, error) {
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || slices.Contains(normalized, name) {
+			continue
+		}
+		if utf8.RuneCountInString(name) > maxTagNameLength {
+			return nil, &ValidationError{Message: fmt.Sprintf("タグ名は%d文字以内で指定してください", maxTagNameLength)}
+		}
+		normalized = append(normalized, name)
+	}
+
+	if len(normalized) > maxTagsPerArticle {
+		return nil, &ValidationError{Message: fmt.Sprintf("タグは%d個まで指定できます", maxTagsPerArticle)}
+	}
+
+	return normalized, nil
+}
+
+// setSchedule は予約公開の設定を記事モデルに反映します
+// scheduledStatusが未指定（nilまたは空文字）の場合は予約を取り消します。publish_atは公開日時の記録として残します
+func setSchedule(article *models.Article, publishAt *time.Time, scheduledStatus *string) error {
+	if scheduledStatus == nil || *scheduledStatus == "" {
+		article.ScheduledStatus = nil
+		return nil
+	}
+
+	if !slices.Contains(validScheduledStatuses, *scheduledStatus) {
+		return &ValidationError{Message: "scheduled_statusはinternalまたはpublicを指定してください"}
+	}
+	if publishAt == nil {
+		return &ValidationError{Message: "予約公開するにはpublish_atも指定してください"}
+	}
+	if !publishAt.After(time.Now()) {
+		return &ValidationError{Message: "publish_atには未来の日時を指定してください"}
+	}
+
+	status := *scheduledStatus
+	at := *publishAt
+	article.ScheduledStatus = &status
+	article.PublishAt = &at
+	return nil
+}
+
+// applyCreateRequest はリクエストの内容を記事モデルに反映します
+func applyCreateRequest(article *models.Article, req models.CreateArticleRequest) {
+	article.Title = strings.TrimSpace(req.Title)
+	article.ArticleType = req.ArticleType
+	article.Content = req.Content
+	article.ExternalURL = req.ExternalURL
+	article.ThumbnailURL = req.ThumbnailURL
+	article.Slug = strings.TrimSpace(req.Slug)
+	article.Department = req.Department
+	article.Status = req.Status
+	if article.Status == "" {
+		article.Status = "draft"
+	}
+}
+
+// convertUserToAuthorResponse はユーザーを記事の著者・レビュアー等の公開用の情報に変換します
+func convertUserToAuthorResponse(user *models.User) models.AuthorResponse {
+	return models.AuthorResponse{
+		ID:          user.ID,
+		Name:        user.Name,
+		Affiliation: user.Affiliation,
+		IconURL:     user.IconURL,
+	}
+}
+
+// contentChanged はレビュー対象となる、公開ページに表示される項目が変更されたかを返します
+// タイトル・本文・リンク先・サムネイル・記事の種類・スラグ（URL）・タグが対象です
+// 本文のHTML・目次・概要などは本文やリンク先から作るため、それらの比較に含まれます。tagNamesには変更後のタグ名を渡します
+func contentChanged(before, after *models.Article, tagNames []string) bool {
+	return before.Title != after.Title ||
+		before.ArticleType != after.ArticleType ||
+		before.Slug != after.Slug ||
+		derefString(before.Content) != derefString(after.Content) ||
+		derefString(before.ExternalURL) != derefString(after.ExternalURL) ||
+		derefString(before.ThumbnailURL) != derefString(after.ThumbnailURL) ||
+		!sameTagNames(tagNamesOf(before.Tags), tagNames)
+}
+
+// sameTagNames は2つのタグ名の集合が等しいかを返します（順序は問いません）
+func sameTagNames(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, name := range a {
+		if !slices.Contains(b, name) {
+			return false
+		}
+	}
+	return true
+}
+
+func tagNamesOf(tags []models.Tag) []string {
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = tag.Name
+	}
+	return names
+}
+
+func isHTTPURL(raw string) bool {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return false
 	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
 }
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"github.com/yamada-mikiya/team1-hackathon/repositories"
+	"gorm.io/gorm"
+)
+
+// saveArticleRepository は記事の保存方法を記録するArticleRepository
+type saveArticleRepository struct {
+	repositories.ArticleRepository
+	saved          *models.Article
+	resetApprovals bool
+}
+
+func (r *saveArticleRepository) FindBySlugAnyStatus(slug string) (*models.Article, error) {
+	if r.saved == nil || r.saved.Slug != slug {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return r.saved, nil
+}
+
+func (r *saveArticleRepository) Update(article *models.Article, editorID int) error {
+	r.saved = article
+	return nil
+}
+
+func (r *saveArticleRepository) UpdateAndResetApprovals(article *models.Article, editorID int) error {
+	r.saved = article
+	r.resetApprovals = true
+	return nil
+}
+
+// saveTagRepository はタグを保存せずに返すTagRepository
+type saveTagRepository struct {
+	repositories.TagRepository
+}
+
+func (r *saveTagRepository) FindOrCreateByNames(names []string) ([]models.Tag, error) {
+	return nil, nil
+}
+
+func TestSaveArticleResetsApprovalsWithUpdate(t *testing.T) {
+	content := "本文"
+	edited := "修正した本文"
+	before := models.Article{ID: 1, AuthorID: 1, ArticleType: "markdown", Title: "タイトル", Content: &content, Slug: "article", Department: "Dev", Status: "in_review"}
+
+	tests := []struct {
+		name      string
+		modify    func(a *models.Article)
+		wantReset bool
+	}{
+		{"本文の変更", func(a *models.Article) { a.Content = &edited }, true},
+		{"タイトルの変更", func(a *models.Article) { a.Title = "別のタイトル" }, true},
+		{"サムネイルの変更", func(a *models.Article) { thumbnail := "https://example.com/a.png"; a.ThumbnailURL = &thumbnail }, true},
+		{"ステータスのみの変更", func(a *models.Article) { a.Status = "draft" }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &saveArticleRepository{}
+			s := &articleService{repo: repo, tagRepo: &saveTagRepository{}}
+			after := before
+			tt.modify(&after)
+
+			if _, err := s.saveArticle(context.Background(), &before, &after, nil, 1); err != nil {
+				t.Fatalf("saveArticle: %v", err)
+			}
+			// 承認の取り消しは記事の保存と同じトランザクション（UpdateAndResetApprovals）で行う
+			if repo.resetApprovals != tt.wantReset {
+				t.Errorf("承認の取り消し = %v, want %v", repo.resetApprovals, tt.wantReset)
+			}
+		})
+	}
+}
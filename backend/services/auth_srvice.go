@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"errors"
+	"log/slog"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -12,42 +13,76 @@ import (
 	"gorm.io/gorm"
 )
 
+const (
+	// accessTokenAudience はプレビュー用・メールアドレス確認用のトークンと区別するためのaudクレーム
+	accessTokenAudience    = "access"
+	defaultAccessTokenTTL  = 15 * time.Minute
+	defaultRefreshTokenTTL = 14 * 24 * time.Hour
+)
+
 type AuthService interface {
-	SignUp(ctx context.Context, req models.SignUpRequest) (models.UserResponse, string, error)
-	LogIn(ctx context.Context, req models.AuthenticateRequest) (models.UserResponse, string, error)
+	SignUp(ctx context.Context, req models.SignUpRequest) (models.UserResponse, *AuthTokens, error)
+	LogIn(ctx context.Context, req models.AuthenticateRequest) (models.UserResponse, *AuthTokens, error)
+	Refresh(ctx context.Context, refreshToken string) (models.UserResponse, *AuthTokens, error)
 	ValidateToken(ctx context.Context, tokenString string) (int, error)
 	GetUserByID(ctx context.Context, userID int) (models.UserResponse, error)
+	LogOut(ctx context.Context, claims *models.JwtCustomClaims, refreshToken string) error
+	ChangePassword(ctx context.Context, claims *models.JwtCustomClaims, req models.ChangePasswordRequest) (models.UserResponse, *AuthTokens, error)
+}
+
+// AuthTokens はログイン時・リフレッシュ時に発行するトークンの組
+type AuthTokens struct {
+	AccessToken           string
+	AccessTokenExpiresAt  time.Time
+	RefreshToken          string
+	RefreshTokenExpiresAt time.Time
 }
 
 type authService struct {
-	userRepo  repositories.UserRepository
-	db        *gorm.DB
-	secretKey string
+	userRepo         repositories.UserRepository
+	revokedTokenRepo repositories.RevokedTokenRepository
+	refreshTokenRepo repositories.RefreshTokenRepository
+	db               *gorm.DB
+	secretKey        string
+	accessTokenTTL   time.Duration
+	refreshTokenTTL  time.Duration
 }
 
-func NewAuthService(userRepo repositories.UserRepository, db *gorm.DB, secretKey string) AuthService {
+func NewAuthService(userRepo repositories.UserRepository, revokedTokenRepo repositories.RevokedTokenRepository, refreshTokenRepo repositories.RefreshTokenRepository, db *gorm.DB, secretKey string, accessTokenTTL, refreshTokenTTL time.Duration) AuthService {
+	if accessTokenTTL <= 0 {
+		accessTokenTTL = defaultAccessTokenTTL
+	}
+	if refreshTokenTTL <= 0 {
+		refreshTokenTTL = defaultRefreshTokenTTL
+	}
 	return &authService{
-		userRepo:  userRepo,
-		db:        db,
-		secretKey: secretKey,
+		userRepo:         userRepo,
+		revokedTokenRepo: revokedTokenRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		db:               db,
+		secretKey:        secretKey,
+		accessTokenTTL:   accessTokenTTL,
+		refreshTokenTTL:  refreshTokenTTL,
 	}
 }
 
-// SignUp は新しいユーザーを作成し、JWTトークンを返します
-func (s *authService) SignUp(ctx context.Context, req models.SignUpRequest) (models.UserResponse, string, error) {
+// SignUp は新しいユーザーを作成し、アクセストークンとリフレッシュトークンを返します
+// 作成したユーザーはメールアドレスを確認するまでゲストと同じ権限になります
+// 許可するドメイン以外のメールアドレスでも登録でき、その場合は確認できないためゲストのままです
+func (s *authService) SignUp(ctx context.Context, req models.SignUpRequest) (models.UserResponse, *AuthTokens, error) {
 	// メールアドレスの重複チェック
 	existingUser, err := s.userRepo.GetUserByEmail(ctx, req.Email)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		return models.UserResponse{}, "", err
+		return models.UserResponse{}, nil, err
 	}
 	if existingUser != nil {
-		return models.UserResponse{}, "", errors.New("このメールアドレスは既に使用されています")
+		return models.UserResponse{}, nil, errors.New("このメールアドレスは既に使用されています")
 	}
 
 	// パスワードをハッシュ化
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
-		return models.UserResponse{}, "", err
+		return models.UserResponse{}, nil, err
 	}
 
 	// 新しいユーザーを作成
@@ -55,69 +90,160 @@ func (s *authService) SignUp(ctx context.Context, req models.SignUpRequest) (mod
 		Name:         req.Name,
 		Email:        req.Email,
 		PasswordHash: string(hashedPassword),
+		Role:         models.RoleWriter,
 	}
 
 	if err := s.userRepo.CreateUser(ctx, newUser); err != nil {
-		return models.UserResponse{}, "", err
+		return models.UserResponse{}, nil, err
 	}
 
-	// JWTトークンを生成
-	tokenString, err := s.createToken(ctx, *newUser)
+	// トークンを発行（新しいトークンファミリーを開始）
+	tokens, err := s.issueTokens(ctx, newUser, nil)
 	if err != nil {
-		return models.UserResponse{}, "", err
+		return models.UserResponse{}, nil, err
 	}
 
-	userResponse := models.UserResponse{
-		ID:          newUser.ID,
-		Name:        newUser.Name,
-		Email:       newUser.Email,
-		Affiliation: newUser.Affiliation,
-		IconURL:     newUser.IconURL,
-	}
+	userResponse := convertUserToUserResponse(newUser)
 
-	return userResponse, tokenString, nil
+	return userResponse, tokens, nil
 }
 
-// LogIn は既存ユーザーを認証し、JWTトークンを返します
-func (s *authService) LogIn(ctx context.Context, req models.AuthenticateRequest) (models.UserResponse, string, error) {
+// LogIn は既存ユーザーを認証し、アクセストークンとリフレッシュトークンを返します
+func (s *authService) LogIn(ctx context.Context, req models.AuthenticateRequest) (models.UserResponse, *AuthTokens, error) {
 	// メールアドレスでユーザーを取得
 	user, err := s.userRepo.GetUserByEmail(ctx, req.Email)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return models.UserResponse{}, "", errors.New("メールアドレスまたはパスワードが正しくありません")
+			return models.UserResponse{}, nil, errors.New("メールアドレスまたはパスワードが正しくありません")
 		}
-		return models.UserResponse{}, "", err
+		return models.UserResponse{}, nil, err
 	}
 
 	// パスワードを検証
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
-		return models.UserResponse{}, "", errors.New("メールアドレスまたはパスワードが正しくありません")
+		return models.UserResponse{}, nil, errors.New("メールアドレスまたはパスワードが正しくありません")
 	}
 
-	// JWTトークンを生成
-	tokenString, err := s.createToken(ctx, *user)
+	// トークンを発行（新しいトークンファミリーを開始）
+	tokens, err := s.issueTokens(ctx, user, nil)
 	if err != nil {
-		return models.UserResponse{}, "", err
+		return models.UserResponse{}, nil, err
 	}
 
-	userResponse := models.UserResponse{
-		ID:          user.ID,
-		Name:        user.Name,
-		Email:       user.Email,
-		Affiliation: user.Affiliation,
-		IconURL:     user.IconURL,
+	userResponse := convertUserToUserResponse(user)
+
+	return userResponse, tokens, nil
+}
+
+// Refresh はリフレッシュトークンを検証してローテーションし、新しいトークンの組を返します
+// 使用済みのリフレッシュトークンが再利用された場合は、漏洩とみなして同じファミリーのトークンをすべて失効させます
+func (s *authService) Refresh(ctx context.Context, refreshToken string) (models.UserResponse, *AuthTokens, error) {
+	if refreshToken == "" {
+		return models.UserResponse{}, nil, ErrInvalidRefreshToken
 	}
 
-	return userResponse, tokenString, nil
+	current, err := s.refreshTokenRepo.FindByHash(ctx, hashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.UserResponse{}, nil, ErrInvalidRefreshToken
+		}
+		return models.UserResponse{}, nil, err
+	}
+	if current.RevokedAt != nil || !time.Now().Before(current.ExpiresAt) {
+		return models.UserResponse{}, nil, ErrInvalidRefreshToken
+	}
+	if current.UsedAt != nil {
+		return models.UserResponse{}, nil, s.revokeReusedFamily(ctx, current)
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, current.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.UserResponse{}, nil, ErrInvalidRefreshToken
+		}
+		return models.UserResponse{}, nil, err
+	}
+
+	// ロール等の変更が反映されるよう、アクセストークンは最新のユーザー情報から発行する
+	tokens, err := s.issueTokens(ctx, user, current)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRefreshTokenReused) {
+			return models.UserResponse{}, nil, s.revokeReusedFamily(ctx, current)
+		}
+		return models.UserResponse{}, nil, err
+	}
+
+	return convertUserToUserResponse(user), tokens, nil
+}
+
+// revokeReusedFamily は再利用されたリフレッシュトークンのファミリーを失効させます
+func (s *authService) revokeReusedFamily(ctx context.Context, token *models.RefreshToken) error {
+	slog.Warn("使用済みのリフレッシュトークンが再利用されたため、トークンファミリーを失効させます", "user_id", token.UserID, "family_id", token.FamilyID)
+	if err := s.refreshTokenRepo.RevokeFamily(ctx, token.FamilyID); err != nil {
+		return err
+	}
+	return ErrInvalidRefreshToken
+}
+
+// issueTokens はアクセストークンとリフレッシュトークンを発行します
+// currentにはローテーション元のリフレッシュトークンを指定します（ログイン時はnilで新しいファミリーを開始）
+func (s *authService) issueTokens(ctx context.Context, user *models.User, current *models.RefreshToken) (*AuthTokens, error) {
+	refreshToken, err := newOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	next := &models.RefreshToken{
+		TokenHash: hashToken(refreshToken),
+		ExpiresAt: time.Now().Add(s.refreshTokenTTL),
+	}
+	if current == nil {
+		familyID, err := newTokenID()
+		if err != nil {
+			return nil, err
+		}
+		next.UserID = user.ID
+		next.FamilyID = familyID
+		if err := s.refreshTokenRepo.Create(ctx, next); err != nil {
+			return nil, err
+		}
+	} else if err := s.refreshTokenRepo.Rotate(ctx, current, next); err != nil {
+		return nil, err
+	}
+
+	accessToken, expiresAt, err := s.createToken(ctx, *user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthTokens{
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  expiresAt,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: next.ExpiresAt,
+	}, nil
 }
 
-// createToken はJWTトークンを作成します
-func (s *authService) createToken(ctx context.Context, user models.User) (string, error) {
+// createToken はアクセストークン（JWT）を作成し、トークンと有効期限を返します
+func (s *authService) createToken(ctx context.Context, user models.User) (string, time.Time, error) {
+	// jtiはログアウト時にトークンを失効させるために使う
+	jti, err := newTokenID()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.accessTokenTTL)
+
 	claims := &models.JwtCustomClaims{
-		UserID: user.ID,
+		UserID:     user.ID,
+		Role:       user.Role,
+		Department: user.Department,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour * 72)), // 72時間後に期限切れ
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ID:        jti,
+			Audience:  jwt.ClaimStrings{accessTokenAudience},
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
 		},
 	}
 
@@ -125,27 +251,120 @@ func (s *authService) createToken(ctx context.Context, user models.User) (string
 
 	tokenString, err := token.SignedString([]byte(s.secretKey))
 	if err != nil {
-		return "", err
+		return "", time.Time{}, err
 	}
 
-	return tokenString, nil
+	return tokenString, expiresAt, nil
 }
 
 // ValidateToken はJWTトークンを検証し、ユーザーIDを返します
 func (s *authService) ValidateToken(ctx context.Context, tokenString string) (int, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &models.JwtCustomClaims{}, func(token *jwt.Token) (interface{}, error) {
-		return []byte(s.secretKey), nil
-	})
+	claims, err := ParseAccessToken(s.secretKey, tokenString)
+	if err != nil {
+		return 0, err
+	}
 
+	revoked, err := s.revokedTokenRepo.IsRevoked(ctx, claims.ID)
 	if err != nil {
 		return 0, err
 	}
+	if revoked {
+		return 0, errors.New("このトークンは無効化されています")
+	}
 
-	if claims, ok := token.Claims.(*models.JwtCustomClaims); ok && token.Valid {
-		return claims.UserID, nil
+	return claims.UserID, nil
+}
+
+// ParseAccessToken はアクセストークンの署名・有効期限・audクレームを検証し、クレームを返します（失効の確認は行いません）
+// 同じ秘密鍵で署名するプレビュー用・メールアドレス確認用のトークンをログインとして扱わないよう、audがaccessのトークンのみ受け付けます
+// jtiのないトークンは失効させられないため受け付けません
+func ParseAccessToken(secretKey, tokenString string) (*models.JwtCustomClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &models.JwtCustomClaims{}, func(token *jwt.Token) (interface{}, error) {
+		return []byte(secretKey), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithAudience(accessTokenAudience), jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, err
 	}
 
-	return 0, errors.New("無効なトークンです")
+	claims, ok := token.Claims.(*models.JwtCustomClaims)
+	if !ok || !token.Valid || claims.UserID == 0 || claims.ID == "" {
+		return nil, errors.New("無効なトークンです")
+	}
+	return claims, nil
+}
+
+// LogOut はアクセストークンを有効期限まで失効リストに登録し、リフレッシュトークンのファミリーを失効させます
+// claims・refreshTokenはどちらも省略（nil・空文字）できます
+func (s *authService) LogOut(ctx context.Context, claims *models.JwtCustomClaims, refreshToken string) error {
+	if claims != nil && claims.ID != "" && claims.ExpiresAt != nil {
+		userID := claims.UserID
+		if err := s.revokedTokenRepo.Revoke(ctx, &models.RevokedToken{
+			JTI:       claims.ID,
+			UserID:    &userID,
+			ExpiresAt: claims.ExpiresAt.Time,
+		}); err != nil {
+			return err
+		}
+	}
+
+	if refreshToken != "" {
+		token, err := s.refreshTokenRepo.FindByHash(ctx, hashToken(refreshToken))
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+		if err := s.refreshTokenRepo.RevokeFamily(ctx, token.FamilyID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ChangePassword は現在のパスワードを確認してからパスワードを変更します
+// 他の端末のログインを無効にするため、使用中のアクセストークンとすべてのリフレッシュトークンを失効させ、この端末用に新しいトークンを発行します
+func (s *authService) ChangePassword(ctx context.Context, claims *models.JwtCustomClaims, req models.ChangePasswordRequest) (models.UserResponse, *AuthTokens, error) {
+	user, err := s.userRepo.GetUserByID(ctx, claims.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.UserResponse{}, nil, ErrUserNotFound
+		}
+		return models.UserResponse{}, nil, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.CurrentPassword)); err != nil {
+		return models.UserResponse{}, nil, ErrIncorrectPassword
+	}
+	if len(req.NewPassword) < minPasswordLength {
+		return models.UserResponse{}, nil, &ValidationError{Message: "パスワードは8文字以上である必要があります"}
+	}
+	if req.NewPassword == req.CurrentPassword {
+		return models.UserResponse{}, nil, &ValidationError{Message: "新しいパスワードには現在と異なるものを指定してください"}
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return models.UserResponse{}, nil, err
+	}
+	if err := s.userRepo.UpdatePassword(ctx, user.ID, string(hashedPassword)); err != nil {
+		return models.UserResponse{}, nil, err
+	}
+
+	if err := s.refreshTokenRepo.RevokeByUser(ctx, user.ID); err != nil {
+		return models.UserResponse{}, nil, err
+	}
+	if err := s.LogOut(ctx, claims, ""); err != nil {
+		return models.UserResponse{}, nil, err
+	}
+
+	tokens, err := s.issueTokens(ctx, user, nil)
+	if err != nil {
+		return models.UserResponse{}, nil, err
+	}
+
+	return convertUserToUserResponse(user), tokens, nil
 }
 
 // GetUserByID はユーザーIDからユーザー情報を取得します
@@ -155,13 +374,21 @@ func (s *authService) GetUserByID(ctx context.Context, userID int) (models.UserR
 		return models.UserResponse{}, err
 	}
 
-	userResponse := models.UserResponse{
-		ID:          user.ID,
-		Name:        user.Name,
-		Email:       user.Email,
-		Affiliation: user.Affiliation,
-		IconURL:     user.IconURL,
-	}
+	userResponse := convertUserToUserResponse(user)
 
 	return userResponse, nil
 }
+
+// convertUserToUserResponse はユーザーをレスポンス形式に変換します
+func convertUserToUserResponse(user *models.User) models.UserResponse {
+	return models.UserResponse{
+		ID:            user.ID,
+		Name:          user.Name,
+		Email:         user.Email,
+		Affiliation:   user.Affiliation,
+		Department:    user.Department,
+		Role:          user.Role,
+		IconURL:       user.IconURL,
+		EmailVerified: user.EmailVerifiedAt != nil,
+	}
+}
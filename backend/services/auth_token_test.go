@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/yamada-mikiya/team1-hackathon/models"
+)
+
+const testSecretKey = "test-secret"
+
+func signTestToken(t *testing.T, method jwt.SigningMethod, key any, claims jwt.Claims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	if err != nil {
+		t.Fatalf("トークンの署名に失敗しました: %v", err)
+	}
+	return token
+}
+
+func TestParseAccessToken(t *testing.T) {
+	service := &authService{secretKey: testSecretKey, accessTokenTTL: time.Minute}
+	accessToken, _, err := service.createToken(context.Background(), models.User{ID: 1, Role: models.RoleWriter})
+	if err != nil {
+		t.Fatalf("createToken: %v", err)
+	}
+
+	claims, err := ParseAccessToken(testSecretKey, accessToken)
+	if err != nil {
+		t.Fatalf("ParseAccessToken: %v", err)
+	}
+	if claims.UserID != 1 || claims.ID == "" {
+		t.Errorf("claims = {user_id: %d, jti: %q}, want {1, jtiあり}", claims.UserID, claims.ID)
+	}
+
+	expiresAt := jwt.NewNumericDate(time.Now().Add(time.Minute))
+	registered := func(audience ...string) jwt.RegisteredClaims {
+		return jwt.RegisteredClaims{ID: "jti", Audience: audience, ExpiresAt: expiresAt}
+	}
+	rejected := map[string]string{
+		// 同じ秘密鍵で署名した、ログイン用でないトークン
+		"メールアドレス確認用のトークン": signTestToken(t, jwt.SigningMethodHS256, []byte(testSecretKey), &models.EmailVerificationClaims{
+			UserID: 1, Email: "user@example.com", RegisteredClaims: registered(emailVerificationAudience),
+		}),
+		"プレビュー用のトークン": signTestToken(t, jwt.SigningMethodHS256, []byte(testSecretKey), &models.JwtCustomClaims{
+			UserID: 1, RegisteredClaims: registered(previewTokenAudience),
+		}),
+		"audのないトークン": signTestToken(t, jwt.SigningMethodHS256, []byte(testSecretKey), &models.JwtCustomClaims{
+			UserID: 1, RegisteredClaims: registered(),
+		}),
+		"HS256以外の署名方式": signTestToken(t, jwt.SigningMethodHS512, []byte(testSecretKey), &models.JwtCustomClaims{
+			UserID: 1, RegisteredClaims: registered(accessTokenAudience),
+		}),
+		"署名なし（alg: none）": signTestToken(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, &models.JwtCustomClaims{
+			UserID: 1, RegisteredClaims: registered(accessTokenAudience),
+		}),
+		"別の秘密鍵": signTestToken(t, jwt.SigningMethodHS256, []byte("other-secret"), &models.JwtCustomClaims{
+			UserID: 1, RegisteredClaims: registered(accessTokenAudience),
+		}),
+		"jtiのないトークン": signTestToken(t, jwt.SigningMethodHS256, []byte(testSecretKey), &models.JwtCustomClaims{
+			UserID: 1, RegisteredClaims: jwt.RegisteredClaims{Audience: jwt.ClaimStrings{accessTokenAudience}, ExpiresAt: expiresAt},
+		}),
+		"有効期限のないトークン": signTestToken(t, jwt.SigningMethodHS256, []byte(testSecretKey), &models.JwtCustomClaims{
+			UserID: 1, RegisteredClaims: jwt.RegisteredClaims{ID: "jti", Audience: jwt.ClaimStrings{accessTokenAudience}},
+		}),
+	}
+	for name, token := range rejected {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseAccessToken(testSecretKey, token); err == nil {
+				t.Error("ログイン用のトークンとして受け付けています")
+			}
+		})
+	}
+}
@@ -0,0 +1,314 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"github.com/yamada-mikiya/team1-hackathon/repositories"
+	"gorm.io/gorm"
+)
+
+// コメントの本文の最大文字数
+const maxCommentLength = 5000
+
+type CommentService interface {
+	GetComments(slug string, viewerID, page, limit int) (*models.CommentListResponse, error)
+	CreateComment(slug string, userID int, req models.CreateCommentRequest) (*models.CommentResponse, error)
+	UpdateComment(slug string, commentID, userID int, req models.UpdateCommentRequest) (*models.CommentResponse, error)
+	DeleteComment(slug string, commentID, userID int) error
+	HideComment(slug string, commentID, userID int) (*models.CommentResponse, error)
+	UnhideComment(slug string, commentID, userID int) (*models.CommentResponse, error)
+}
+
+type commentService struct {
+	articleRepo repositories.ArticleRepository
+	repo        repositories.CommentRepository
+	userRepo    repositories.UserRepository
+}
+
+func NewCommentService(articleRepo repositories.ArticleRepository, repo repositories.CommentRepository, userRepo repositories.UserRepository) CommentService {
+	return &commentService{
+		articleRepo: articleRepo,
+		repo:        repo,
+		userRepo:    userRepo,
+	}
+}
+
+// GetComments は記事のコメントを、返信でないコメントの作成日時の古い順にページ分けして、それぞれの返信とあわせて取得します
+// コメントを閲覧できるのは記事を閲覧できるユーザーのみです（internal記事はメンバーのみ、下書きは著者・レビュアー・管理できるユーザーのみ）
+func (s *commentService) GetComments(slug string, viewerID, page, limit int) (*models.CommentListResponse, error) {
+	article, err := s.findVisibleArticle(slug, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	canModerate, err := s.canModerate(article, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, totalCount, err := s.repo.FindTopLevel(article.ID, page, limit)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int, len(comments))
+	for i, comment := range comments {
+		ids[i] = comment.ID
+	}
+	replies, err := s.repo.FindReplies(ids)
+	if err != nil {
+		return nil, err
+	}
+	repliesByParent := map[int][]models.CommentResponse{}
+	for _, reply := range replies {
+		repliesByParent[*reply.ParentID] = append(repliesByParent[*reply.ParentID], convertCommentToResponse(&reply, viewerID, canModerate))
+	}
+
+	response := &models.CommentListResponse{
+		Comments:   make([]models.CommentResponse, len(comments)),
+		TotalCount: int(totalCount),
+		Page:       page,
+		Limit:      limit,
+		TotalPages: (int(totalCount) + limit - 1) / limit,
+	}
+	for i, comment := range comments {
+		response.Comments[i] = convertCommentToResponse(&comment, viewerID, canModerate)
+		response.Comments[i].Replies = repliesByParent[comment.ID]
+	}
+	return response, nil
+}
+
+// CreateComment は記事にコメント、またはコメントへの返信を投稿します
+// メールアドレスを確認済みのメンバーが、閲覧できる公開中（internal・public）の記事にのみ投稿できます
+func (s *commentService) CreateComment(slug string, userID int, req models.CreateCommentRequest) (*models.CommentResponse, error) {
+	if err := requireMember(s.userRepo, userID); err != nil {
+		return nil, err
+	}
+	article, err := s.findVisibleArticle(slug, userID)
+	if err != nil {
+		return nil, err
+	}
+	if article.Status != "internal" && article.Status != "public" {
+		return nil, &ValidationError{Message: "公開中の記事にのみコメントできます"}
+	}
+
+	comment := &models.ArticleComment{ArticleID: article.ID, UserID: userID}
+	if req.ParentID != nil {
+		parent, err := s.repo.FindByID(article.ID, *req.ParentID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, &ValidationError{Message: "返信先のコメントが見つかりません"}
+			}
+			return nil, err
+		}
+		if parent.ParentID != nil {
+			return nil, &ValidationError{Message: "返信には返信できません。元のコメントに返信してください"}
+		}
+		if parent.DeletedAt != nil || parent.HiddenAt != nil {
+			return nil, &ValidationError{Message: "削除・非表示にされたコメントには返信できません"}
+		}
+		comment.ParentID = &parent.ID
+	}
+	if err := setCommentBody(comment, req.Body); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(comment); err != nil {
+		return nil, err
+	}
+	return s.commentResponse(article.ID, comment.ID, userID, false)
+}
+
+// UpdateComment はコメントの本文を編集します。投稿者本人のみ編集でき、削除したコメントは編集できません
+func (s *commentService) UpdateComment(slug string, commentID, userID int, req models.UpdateCommentRequest) (*models.CommentResponse, error) {
+	article, comment, err := s.findOwnComment(slug, commentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := setCommentBody(comment, req.Body); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	comment.EditedAt = &now
+
+	if err := s.repo.Update(comment); err != nil {
+		return nil, err
+	}
+	return s.commentResponse(article.ID, comment.ID, userID, false)
+}
+
+// DeleteComment はコメントを削除します。投稿者本人のみ削除でき、返信のスレッドを保つため本文を伏せて残します
+func (s *commentService) DeleteComment(slug string, commentID, userID int) error {
+	_, comment, err := s.findOwnComment(slug, commentID, userID)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	comment.DeletedAt = &now
+	return s.repo.Update(comment)
+}
+
+// HideComment はコメントを非表示にします。記事の著者と管理者のみ実行できます
+func (s *commentService) HideComment(slug string, commentID, userID int) (*models.CommentResponse, error) {
+	return s.setHidden(slug, commentID, userID, true)
+}
+
+// UnhideComment は非表示にしたコメントを再び表示します。記事の著者と管理者のみ実行できます
+func (s *commentService) UnhideComment(slug string, commentID, userID int) (*models.CommentResponse, error) {
+	return s.setHidden(slug, commentID, userID, false)
+}
+
+func (s *commentService) setHidden(slug string, commentID, userID int, hidden bool) (*models.CommentResponse, error) {
+	article, err := s.findVisibleArticle(slug, userID)
+	if err != nil {
+		return nil, err
+	}
+	canModerate, err := s.canModerate(article, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canModerate {
+		return nil, ErrPermissionDenied
+	}
+
+	comment, err := s.findComment(article.ID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if hidden && comment.HiddenAt == nil {
+		now := time.Now()
+		comment.HiddenAt = &now
+		comment.HiddenBy = &userID
+	} else if !hidden {
+		comment.HiddenAt = nil
+		comment.HiddenBy = nil
+	}
+
+	if err := s.repo.Update(comment); err != nil {
+		return nil, err
+	}
+	return s.commentResponse(article.ID, comment.ID, userID, true)
+}
+
+// findVisibleArticle はslugで記事を取得します。閲覧の可否は記事詳細（FindBySlug）と同じルールで判定します
+func (s *commentService) findVisibleArticle(slug string, viewerID int) (*models.Article, error) {
+	article, err := s.articleRepo.FindBySlug(slug, viewerID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrArticleNotFound
+		}
+		return nil, err
+	}
+	return article, nil
+}
+
+func (s *commentService) findComment(articleID, commentID int) (*models.ArticleComment, error) {
+	comment, err := s.repo.FindByID(articleID, commentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, err
+	}
+	return comment, nil
+}
+
+// findOwnComment はログインユーザー自身の、削除されていないコメントを取得します
+func (s *commentService) findOwnComment(slug string, commentID, userID int) (*models.Article, *models.ArticleComment, error) {
+	if err := requireMember(s.userRepo, userID); err != nil {
+		return nil, nil, err
+	}
+	article, err := s.findVisibleArticle(slug, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	comment, err := s.findComment(article.ID, commentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if comment.UserID != userID {
+		return nil, nil, ErrNotCommentAuthor
+	}
+	if comment.DeletedAt != nil {
+		return nil, nil, ErrCommentNotFound
+	}
+	return article, comment, nil
+}
+
+// canModerate はユーザーが記事のコメントを非表示にできるか（メールアドレスを確認済みの記事の著者・管理者か）を返します
+// ロールは変更直後から反映されるよう、トークンではなくデータベースの値で判定します
+func (s *commentService) canModerate(article *models.Article, userID int) (bool, error) {
+	if userID == 0 {
+		return false, nil
+	}
+	user, err := s.userRepo.GetUserByID(context.Background(), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	if user.EmailVerifiedAt == nil {
+		return false, nil
+	}
+	return article.AuthorID == user.ID || user.Role == models.RoleAdmin, nil
+}
+
+// commentResponse は保存したコメントを投稿者の情報付きで読み直してレスポンスにします
+func (s *commentService) commentResponse(articleID, commentID, viewerID int, canModerate bool) (*models.CommentResponse, error) {
+	comment, err := s.findComment(articleID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	res := convertCommentToResponse(comment, viewerID, canModerate)
+	return &res, nil
+}
+
+// setCommentBody は本文を検証し、Markdownから作成したサニタイズ済みのHTMLとあわせて設定します
+func setCommentBody(comment *models.ArticleComment, body string) error {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return &ValidationError{Message: "コメントの本文を入力してください"}
+	}
+	if utf8.RuneCountInString(body) > maxCommentLength {
+		return &ValidationError{Message: fmt.Sprintf("コメントは%d文字以内で入力してください", maxCommentLength)}
+	}
+	html, err := renderCommentMarkdown(body)
+	if err != nil {
+		return err
+	}
+	comment.Body = body
+	comment.BodyHTML = html
+	return nil
+}
+
+// convertCommentToResponse はコメントをレスポンスに変換します
+// 削除したコメントは本文と投稿者を伏せます。非表示のコメントは記事の著者・管理者と投稿者本人にのみ本文を返します
+func convertCommentToResponse(comment *models.ArticleComment, viewerID int, canModerate bool) models.CommentResponse {
+	res := models.CommentResponse{
+		ID:        comment.ID,
+		ParentID:  comment.ParentID,
+		Deleted:   comment.DeletedAt != nil,
+		Hidden:    comment.HiddenAt != nil,
+		CreatedAt: comment.CreatedAt,
+	}
+	if res.Deleted {
+		return res
+	}
+	if res.Hidden && !canModerate && (viewerID == 0 || comment.UserID != viewerID) {
+		return res
+	}
+
+	if comment.User != nil {
+		author := convertUserToAuthorResponse(comment.User)
+		res.User = &author
+	}
+	res.Body = &comment.Body
+	res.BodyHTML = &comment.BodyHTML
+	res.EditedAt = comment.EditedAt
+	return res
+}
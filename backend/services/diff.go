@@ -0,0 +1,168 @@
+package services
+
+import (
+	"strings"
+
+	"github.com/yamada-mikiya/team1-hackathon/models"
+)
+
+const (
+	diffEqual  = "equal"
+	diffInsert = "insert"
+	diffDelete = "delete"
+)
+
+// diffLines は2つのテキストを行単位で比較し、Myersのアルゴリズムで最小の差分を返します
+// 経路の途中の状態を保存せず、中央のスネーク（middle snake）で分割して再帰的に比較する線形空間版を使うため、
+// メモリ使用量は差分の量によらず行数に比例します
+func diffLines(oldText, newText string) []models.DiffLine {
+	d := &lineDiff{a: splitLines(oldText), b: splitLines(newText), lines: []models.DiffLine{}}
+	d.compare(0, len(d.a), 0, len(d.b))
+	return d.lines
+}
+
+// lineDiff は比較中の2つのテキストの行と、作成した差分を持ちます
+type lineDiff struct {
+	a, b  []string
+	lines []models.DiffLine
+}
+
+// compare はa[aLo:aHi]とb[bLo:bHi]の差分を先頭から順にlinesに追加します
+func (d *lineDiff) compare(aLo, aHi, bLo, bHi int) {
+	// 先頭と末尾の共通する行は差分の計算から除く
+	for aLo < aHi && bLo < bHi && d.a[aLo] == d.b[bLo] {
+		d.addEqual(aLo, bLo)
+		aLo++
+		bLo++
+	}
+	suffix := 0
+	for aLo < aHi-suffix && bLo < bHi-suffix && d.a[aHi-suffix-1] == d.b[bHi-suffix-1] {
+		suffix++
+	}
+	aHi -= suffix
+	bHi -= suffix
+
+	switch {
+	case aLo == aHi:
+		d.addInserts(bLo, bHi)
+	case bLo == bHi:
+		d.addDeletes(aLo, aHi)
+	default:
+		x, y, ok := d.middleSnake(aLo, aHi, bLo, bHi)
+		// 分割しても範囲が小さくならない場合は、すべて削除してすべて追加したものとして扱う
+		if !ok || (x == aLo && y == bLo) || (x == aHi && y == bHi) {
+			d.addDeletes(aLo, aHi)
+			d.addInserts(bLo, bHi)
+		} else {
+			d.compare(aLo, x, bLo, y)
+			d.compare(x, aHi, y, bHi)
+		}
+	}
+
+	for i := 0; i < suffix; i++ {
+		d.addEqual(aHi+i, bHi+i)
+	}
+}
+
+// middleSnake は先頭からと末尾からの両方向に最短経路を探し、経路が重なった点（最短経路の中央付近）を返します
+// 見つからない場合（共通する行がない場合）はokがfalseになります
+func (d *lineDiff) middleSnake(aLo, aHi, bLo, bHi int) (x, y int, ok bool) {
+	n, m := aHi-aLo, bHi-bLo
+	maxD := (n + m + 1) / 2
+	offset := maxD
+	// vf[k+offset] は先頭からの探索で対角線k（x-y=k）上で到達した最も遠いx、vbは末尾からの探索の同じ値（末尾からの距離）
+	vf := make([]int, 2*maxD+2)
+	vb := make([]int, 2*maxD+2)
+	for i := range vf {
+		vf[i] = -1
+		vb[i] = -1
+	}
+	vf[offset+1] = 0
+	vb[offset+1] = 0
+
+	delta := n - m
+	// 差が奇数なら先頭からの探索、偶数なら末尾からの探索で重なりを判定する
+	front := delta%2 != 0
+	// グリッドの外に出た対角線は以降の探索から除く
+	fStart, fEnd, bStart, bEnd := 0, 0, 0, 0
+	for step := 0; step < maxD; step++ {
+		for k := -step + fStart; k <= step-fEnd; k += 2 {
+			var fx int
+			if k == -step || (k != step && vf[k-1+offset] < vf[k+1+offset]) {
+				fx = vf[k+1+offset]
+			} else {
+				fx = vf[k-1+offset] + 1
+			}
+			fy := fx - k
+			for fx < n && fy < m && d.a[aLo+fx] == d.b[bLo+fy] {
+				fx++
+				fy++
+			}
+			vf[k+offset] = fx
+			switch {
+			case fx > n:
+				fEnd += 2
+			case fy > m:
+				fStart += 2
+			case front:
+				if bk := delta - k + offset; bk >= 0 && bk < len(vb) && vb[bk] != -1 && fx >= n-vb[bk] {
+					return aLo + fx, bLo + fy, true
+				}
+			}
+		}
+
+		for k := -step + bStart; k <= step-bEnd; k += 2 {
+			var bx int
+			if k == -step || (k != step && vb[k-1+offset] < vb[k+1+offset]) {
+				bx = vb[k+1+offset]
+			} else {
+				bx = vb[k-1+offset] + 1
+			}
+			by := bx - k
+			for bx < n && by < m && d.a[aHi-bx-1] == d.b[bHi-by-1] {
+				bx++
+				by++
+			}
+			vb[k+offset] = bx
+			switch {
+			case bx > n:
+				bEnd += 2
+			case by > m:
+				bStart += 2
+			case !front:
+				if fk := delta - k + offset; fk >= 0 && fk < len(vf) && vf[fk] != -1 {
+					fx := vf[fk]
+					fy := fx - (fk - offset)
+					if fx >= n-bx {
+						return aLo + fx, bLo + fy, true
+					}
+				}
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+func (d *lineDiff) addEqual(x, y int) {
+	d.lines = append(d.lines, models.DiffLine{Type: diffEqual, Text: d.a[x], OldLine: x + 1, NewLine: y + 1})
+}
+
+func (d *lineDiff) addDeletes(from, to int) {
+	for x := from; x < to; x++ {
+		d.lines = append(d.lines, models.DiffLine{Type: diffDelete, Text: d.a[x], OldLine: x + 1})
+	}
+}
+
+func (d *lineDiff) addInserts(from, to int) {
+	for y := from; y < to; y++ {
+		d.lines = append(d.lines, models.DiffLine{Type: diffInsert, Text: d.b[y], NewLine: y + 1})
+	}
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
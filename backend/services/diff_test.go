@@ -0,0 +1,132 @@
+package services
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/yamada-mikiya/team1-hackathon/models"
+)
+
+func TestDiffLines(t *testing.T) {
+	eq := func(text string, oldLine, newLine int) models.DiffLine {
+		return models.DiffLine{Type: diffEqual, Text: text, OldLine: oldLine, NewLine: newLine}
+	}
+	ins := func(text string, newLine int) models.DiffLine {
+		return models.DiffLine{Type: diffInsert, Text: text, NewLine: newLine}
+	}
+	del := func(text string, oldLine int) models.DiffLine {
+		return models.DiffLine{Type: diffDelete, Text: text, OldLine: oldLine}
+	}
+
+	tests := []struct {
+		name     string
+		old, new string
+		want     []models.DiffLine
+	}{
+		{"どちらも空", "", "", []models.DiffLine{}},
+		{"同じ内容", "a\nb\n", "a\nb\n", []models.DiffLine{eq("a", 1, 1), eq("b", 2, 2)}},
+		{"空から追加", "", "a\nb", []models.DiffLine{ins("a", 1), ins("b", 2)}},
+		{"すべて削除", "a\nb", "", []models.DiffLine{del("a", 1), del("b", 2)}},
+		{"途中に追加", "a\nc", "a\nb\nc", []models.DiffLine{eq("a", 1, 1), ins("b", 2), eq("c", 2, 3)}},
+		{"途中を削除", "a\nb\nc", "a\nc", []models.DiffLine{eq("a", 1, 1), del("b", 2), eq("c", 3, 2)}},
+		{
+			"変更と追加と削除",
+			"# タイトル\n本文1\n本文2\n本文3",
+			"# タイトル\n本文1（修正）\n本文3\n追記",
+			[]models.DiffLine{eq("# タイトル", 1, 1), del("本文1", 2), del("本文2", 3), ins("本文1（修正）", 2), eq("本文3", 4, 3), ins("追記", 4)},
+		},
+		{"CRLFとLFは同じ行として扱う", "a\r\nb\r\n", "a\nb", []models.DiffLine{eq("a", 1, 1), eq("b", 2, 2)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := diffLines(tt.old, tt.new); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffLines() =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestDiffLinesRandom はランダムなテキストの差分から元の2つのテキストを復元でき、変更行数が最小であることを確かめます
+func TestDiffLinesRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	randomLines := func() []string {
+		lines := make([]string, rng.Intn(30))
+		for i := range lines {
+			lines[i] = string(rune('a' + rng.Intn(4)))
+		}
+		return lines
+	}
+
+	for i := 0; i < 500; i++ {
+		a, b := randomLines(), randomLines()
+		got := diffLines(strings.Join(a, "\n"), strings.Join(b, "\n"))
+
+		var oldLines, newLines []string
+		changes := 0
+		for _, line := range got {
+			switch line.Type {
+			case diffEqual:
+				oldLines = append(oldLines, line.Text)
+				newLines = append(newLines, line.Text)
+				if line.OldLine != len(oldLines) || line.NewLine != len(newLines) {
+					t.Fatalf("行番号が正しくありません: %+v", line)
+				}
+			case diffDelete:
+				oldLines = append(oldLines, line.Text)
+				changes++
+			case diffInsert:
+				newLines = append(newLines, line.Text)
+				changes++
+			}
+		}
+		if strings.Join(oldLines, "\n") != strings.Join(a, "\n") || strings.Join(newLines, "\n") != strings.Join(b, "\n") {
+			t.Fatalf("差分から元のテキストを復元できません: a=%v b=%v diff=%+v", a, b, got)
+		}
+		if want := len(a) + len(b) - 2*lcsLength(a, b); changes != want {
+			t.Fatalf("変更行数 = %d, want %d (a=%v b=%v)", changes, want, a, b)
+		}
+	}
+}
+
+// lcsLength は最長共通部分列の長さを動的計画法で求めます（テストの期待値用）
+func lcsLength(a, b []string) int {
+	dp := make([][]int, len(a)+1)
+	for i := range dp {
+		dp[i] = make([]int, len(b)+1)
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			if a[i-1] == b[j-1] {
+				dp[i][j] = dp[i-1][j-1] + 1
+			} else {
+				dp[i][j] = max(dp[i-1][j], dp[i][j-1])
+			}
+		}
+	}
+	return dp[len(a)][len(b)]
+}
+
+// TestDiffLinesMemory は差分が大きくてもメモリ使用量が行数に比例する程度に収まることを確かめます
+func TestDiffLinesMemory(t *testing.T) {
+	var oldText, newText strings.Builder
+	for i := 0; i < 4000; i++ {
+		fmt.Fprintf(&oldText, "old %d\n", i)
+		fmt.Fprintf(&newText, "new %d\n", i)
+	}
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	lines := diffLines(oldText.String(), newText.String())
+	runtime.ReadMemStats(&after)
+
+	if len(lines) != 8000 {
+		t.Fatalf("差分の行数 = %d, want 8000", len(lines))
+	}
+	if allocated := after.TotalAlloc - before.TotalAlloc; allocated > 64<<20 {
+		t.Errorf("差分の計算で %d MB 確保しています", allocated>>20)
+	}
+}
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/yamada-mikiya/team1-hackathon/mailer"
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"github.com/yamada-mikiya/team1-hackathon/repositories"
+	"gorm.io/gorm"
+)
+
+const (
+	// emailVerificationAudience はログイン用トークンと区別するためのaudクレーム
+	emailVerificationAudience   = "email-verification"
+	defaultEmailVerificationTTL = 24 * time.Hour
+)
+
+type EmailVerificationService interface {
+	SendVerificationEmail(ctx context.Context, userID int) error
+	VerifyEmail(ctx context.Context, req models.VerifyEmailRequest) (models.UserResponse, error)
+}
+
+type emailVerificationService struct {
+	userRepo       repositories.UserRepository
+	mailer         mailer.Mailer
+	secretKey      string
+	verifyURL      string
+	ttl            time.Duration
+	allowedDomains []string
+}
+
+func NewEmailVerificationService(userRepo repositories.UserRepository, m mailer.Mailer, secretKey, verifyURL string, ttl time.Duration, allowedDomains []string) EmailVerificationService {
+	if ttl <= 0 {
+		ttl = defaultEmailVerificationTTL
+	}
+	return &emailVerificationService{
+		userRepo:       userRepo,
+		mailer:         m,
+		secretKey:      secretKey,
+		verifyURL:      verifyURL,
+		ttl:            ttl,
+		allowedDomains: allowedDomains,
+	}
+}
+
+// SendVerificationEmail はメールアドレス確認用の署名付きリンクをメールで送信します
+func (s *emailVerificationService) SendVerificationEmail(ctx context.Context, userID int) error {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+	if user.EmailVerifiedAt != nil {
+		return &ValidationError{Message: "メールアドレスは既に確認済みです"}
+	}
+	if !isAllowedEmailDomain(user.Email, s.allowedDomains) {
+		return ErrEmailDomainNotAllowed
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.ttl)
+	claims := &models.EmailVerificationClaims{
+		UserID: user.ID,
+		Email:  user.Email,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Audience:  jwt.ClaimStrings{emailVerificationAudience},
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}
+	tokenString, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(s.secretKey))
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "メールアドレスの確認",
+		Body:    s.buildVerificationMail(user, tokenString, expiresAt),
+	})
+}
+
+// VerifyEmail は確認リンクのトークンを検証し、メールアドレスを確認済みにします
+// トークンは発行時のメールアドレスに対してのみ有効です。確認済みの場合もそのまま成功として扱います
+func (s *emailVerificationService) VerifyEmail(ctx context.Context, req models.VerifyEmailRequest) (models.UserResponse, error) {
+	token, err := jwt.ParseWithClaims(req.Token, &models.EmailVerificationClaims{}, func(token *jwt.Token) (interface{}, error) {
+		return []byte(s.secretKey), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithAudience(emailVerificationAudience), jwt.WithExpirationRequired())
+	if err != nil {
+		return models.UserResponse{}, ErrInvalidVerification
+	}
+	claims, ok := token.Claims.(*models.EmailVerificationClaims)
+	if !ok || !token.Valid || claims.UserID == 0 {
+		return models.UserResponse{}, ErrInvalidVerification
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, claims.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.UserResponse{}, ErrInvalidVerification
+		}
+		return models.UserResponse{}, err
+	}
+	if !strings.EqualFold(user.Email, claims.Email) {
+		return models.UserResponse{}, ErrInvalidVerification
+	}
+	if !isAllowedEmailDomain(user.Email, s.allowedDomains) {
+		return models.UserResponse{}, ErrEmailDomainNotAllowed
+	}
+
+	if user.EmailVerifiedAt == nil {
+		now := time.Now()
+		if err := s.userRepo.MarkEmailVerified(ctx, user.ID, now); err != nil {
+			return models.UserResponse{}, err
+		}
+		user.EmailVerifiedAt = &now
+	}
+
+	return convertUserToUserResponse(user), nil
+}
+
+func (s *emailVerificationService) buildVerificationMail(user *models.User, token string, expiresAt time.Time) string {
+	link := s.verifyURL
+	if link == "" {
+		link = "http://localhost:3000/verify-email"
+	}
+	separator := "?"
+	if strings.Contains(link, "?") {
+		separator = "&"
+	}
+	link += separator + "token=" + url.QueryEscape(token)
+
+	return fmt.Sprintf(`%s 様
+
+ご登録ありがとうございます。
+以下のリンクからメールアドレスの確認を完了してください。確認が完了すると社内向けの記事を閲覧・執筆できるようになります。
+
+%s
+
+このリンクは %s まで有効です。
+お心当たりがない場合は、このメールを破棄してください。
+`, user.Name, link, expiresAt.Format("2006-01-02 15:04 MST"))
+}
+
+// RevokeEmailVerificationOutsideDomains は許可するドメイン以外のメールアドレスのユーザーを未確認（ゲスト）に戻し、戻した人数を返します
+// メールアドレスの確認を導入する前から登録していたユーザー（マイグレーションで確認済みにしたユーザー）や、
+// 許可するドメインの設定を変える前に確認したユーザーがメンバーのまま残らないよう、起動時に実行します
+// 許可するドメインが設定されていない場合は何もしません
+func RevokeEmailVerificationOutsideDomains(ctx context.Context, userRepo repositories.UserRepository, allowedDomains []string) (int64, error) {
+	if len(allowedDomains) == 0 {
+		return 0, nil
+	}
+	// isAllowedEmailDomainと同じく、大文字・小文字と前後の空白を区別しない
+	domains := make([]string, len(allowedDomains))
+	for i, domain := range allowedDomains {
+		domains[i] = strings.ToLower(strings.TrimSpace(domain))
+	}
+	return userRepo.RevokeEmailVerificationOutsideDomains(ctx, domains)
+}
+
+// isAllowedEmailDomain はメールアドレスのドメインがメンバーとして許可されているかを返します
+// 許可するドメインが設定されていない場合はすべて許可します
+func isAllowedEmailDomain(email string, allowedDomains []string) bool {
+	if len(allowedDomains) == 0 {
+		return true
+	}
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	domain := strings.ToLower(email[at+1:])
+	return slices.ContainsFunc(allowedDomains, func(allowed string) bool {
+		return strings.ToLower(strings.TrimSpace(allowed)) == domain
+	})
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/yamada-mikiya/team1-hackathon/repositories"
+)
+
+// revokeUserRepository は確認の取り消しに渡されたドメインを記録するUserRepository
+type revokeUserRepository struct {
+	repositories.UserRepository
+	called  bool
+	domains []string
+}
+
+func (r *revokeUserRepository) RevokeEmailVerificationOutsideDomains(ctx context.Context, domains []string) (int64, error) {
+	r.called = true
+	r.domains = domains
+	return 1, nil
+}
+
+func TestRevokeEmailVerificationOutsideDomains(t *testing.T) {
+	t.Run("許可するドメインが未設定", func(t *testing.T) {
+		repo := &revokeUserRepository{}
+		// 制限しない場合は、既存のメンバーを未確認に戻さない
+		if _, err := RevokeEmailVerificationOutsideDomains(context.Background(), repo, nil); err != nil {
+			t.Fatalf("RevokeEmailVerificationOutsideDomains: %v", err)
+		}
+		if repo.called {
+			t.Error("許可するドメインが未設定なのに確認を取り消しました")
+		}
+	})
+
+	t.Run("許可するドメインを設定", func(t *testing.T) {
+		repo := &revokeUserRepository{}
+		revoked, err := RevokeEmailVerificationOutsideDomains(context.Background(), repo, []string{"Example.com", " corp.example.jp "})
+		if err != nil {
+			t.Fatalf("RevokeEmailVerificationOutsideDomains: %v", err)
+		}
+		if revoked != 1 {
+			t.Errorf("revoked = %d, want 1", revoked)
+		}
+		// isAllowedEmailDomainと同じく小文字にして前後の空白を取り除く
+		if want := []string{"example.com", "corp.example.jp"}; !slices.Equal(repo.domains, want) {
+			t.Errorf("domains = %v, want %v", repo.domains, want)
+		}
+	})
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/yamada-mikiya/team1-hackathon/repositories"
+)
+
+// サービス層で発生するエラー。コントローラーでHTTPステータスに変換します
+var (
+	ErrArticleNotFound        = errors.New("article not found")
+	ErrLoginRequired          = repositories.ErrLoginRequired
+	ErrNotArticleAuthor       = errors.New("この記事を変更する権限がありません")
+	ErrSlugAlreadyExists      = errors.New("このスラグは既に使用されています")
+	ErrTagNotFound            = errors.New("タグが見つかりません")
+	ErrInvalidPreview         = errors.New("プレビューリンクが無効か有効期限が切れています")
+	ErrPreviewNotFound        = errors.New("プレビューリンクが見つかりません")
+	ErrRevisionNotFound       = errors.New("リビジョンが見つかりません")
+	ErrReviewApprovalRequired = errors.New("公開するには記事の部署のレビュアーによる承認が必要です")
+	ErrNotReviewer            = errors.New("この記事のレビュアーに割り当てられていません")
+	ErrInvalidReviewState     = repositories.ErrReviewNotInProgress
+	ErrRevisionOutdated       = repositories.ErrRevisionOutdated
+	ErrPermissionDenied       = errors.New("この操作を行う権限がありません")
+	ErrUserNotFound           = errors.New("ユーザーが見つかりません")
+	ErrInvalidRefreshToken    = errors.New("リフレッシュトークンが無効か有効期限が切れています")
+	ErrInvalidResetToken      = errors.New("パスワード再設定のリンクが無効か有効期限が切れています")
+	ErrEmailNotVerified       = errors.New("メールアドレスの確認が完了していません")
+	ErrInvalidVerification    = errors.New("確認リンクが無効か有効期限が切れています")
+	ErrEmailDomainNotAllowed  = errors.New("このメールアドレスのドメインでは登録できません")
+	ErrIncorrectPassword      = errors.New("現在のパスワードが正しくありません")
+	ErrSitemapNotFound        = errors.New("サイトマップが見つかりません")
+	ErrCommentNotFound        = errors.New("コメントが見つかりません")
+	ErrNotCommentAuthor       = errors.New("このコメントを変更する権限がありません")
+)
+
+// ValidationError はリクエスト内容の検証エラー
+type ValidationError struct {
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/feeds"
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"github.com/yamada-mikiya/team1-hackathon/repositories"
+	"gorm.io/gorm"
+)
+
+const (
+	// フィードに載せる記事の件数（新しい順）
+	feedSize         = 20
+	defaultSiteTitle = "Team1 Tech Blog"
+	defaultSiteURL   = "http://localhost:3000"
+	// フロントエンドの記事詳細ページのパス
+	articlePagePath = "/detail/"
+)
+
+type FeedService interface {
+	GetFeed(ctx context.Context, filters repositories.ArticleFilters) (*feeds.Feed, error)
+}
+
+type feedService struct {
+	articleService ArticleService
+	userRepo       repositories.UserRepository
+	title          string
+	description    string
+	siteURL        string
+}
+
+func NewFeedService(articleService ArticleService, userRepo repositories.UserRepository, title, description, siteURL string) FeedService {
+	if title == "" {
+		title = defaultSiteTitle
+	}
+	if siteURL == "" {
+		siteURL = defaultSiteURL
+	}
+	return &feedService{
+		articleService: articleService,
+		userRepo:       userRepo,
+		title:          title,
+		description:    description,
+		siteURL:        strings.TrimSuffix(siteURL, "/"),
+	}
+}
+
+// GetFeed は公開記事の新着フィードを組み立てます
+// filtersのうち部署・タグ・著者だけを使い、閲覧者に関わらずpublicの記事のみを載せます
+func (s *feedService) GetFeed(ctx context.Context, filters repositories.ArticleFilters) (*feeds.Feed, error) {
+	title := []string{s.title}
+	if len(filters.Departments) > 0 {
+		title = append(title, strings.Join(filters.Departments, ", "))
+	}
+	if filters.Tag != "" {
+		title = append(title, "#"+filters.Tag)
+	}
+	if filters.AuthorID != 0 {
+		author, err := s.userRepo.GetUserByID(ctx, filters.AuthorID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrUserNotFound
+			}
+			return nil, err
+		}
+		title = append(title, author.Name)
+	}
+
+	// 最新の記事だけが必要なので、総件数を数えないカーソル方式で取得する
+	// 部署などの値の検証も記事一覧と同じルールで行われる
+	articles, err := s.articleService.GetArticlesByCursor(repositories.ArticleFilters{
+		Departments: filters.Departments,
+		Status:      "public",
+		Tag:         filters.Tag,
+		AuthorID:    filters.AuthorID,
+	}, "", feedSize, false)
+	if err != nil {
+		return nil, err
+	}
+
+	feed := &feeds.Feed{
+		Title:       strings.Join(title, " - "),
+		Link:        &feeds.Link{Href: s.siteURL + "/"},
+		Description: s.description,
+	}
+	for i := range articles.Articles {
+		item, err := s.feedItem(&articles.Articles[i])
+		if err != nil {
+			return nil, err
+		}
+		feed.Add(item)
+		// フィードの更新日時は最後に更新された記事に合わせる（条件付きGETのLast-Modifiedにも使う）
+		if item.Updated.After(feed.Updated) {
+			feed.Updated = item.Updated
+		}
+	}
+	if feed.Updated.IsZero() {
+		feed.Updated = time.Unix(0, 0).UTC()
+	}
+
+	return feed, nil
+}
+
+// feedItem は記事をフィードの1件に変換します
+// Markdownの記事は本文全体をHTMLで載せ、外部記事はリンク先の記事を指します
+func (s *feedService) feedItem(article *models.ArticleResponse) (*feeds.Item, error) {
+	item := &feeds.Item{
+		Title:       article.Title,
+		Author:      &feeds.Author{Name: article.Author.Name},
+		Id:          s.entryID(article),
+		IsPermaLink: "false",
+		Created:     article.CreatedAt,
+		Updated:     article.UpdatedAt,
+	}
+
+	switch {
+	case article.ArticleType == "external" && article.ExternalURL != nil:
+		item.Link = &feeds.Link{Href: *article.ExternalURL}
+		item.Description = fmt.Sprintf(`<p><a href="%s">%s</a></p>`, html.EscapeString(*article.ExternalURL), html.EscapeString(article.Title))
+	default:
+		item.Link = &feeds.Link{Href: s.siteURL + articlePagePath + url.PathEscape(article.Slug)}
+		if article.Excerpt != nil {
+			item.Description = html.EscapeString(*article.Excerpt)
+		}
+		// 保存時に作成したHTMLを使う（作成に失敗していた場合のみここで変換する）
+		switch {
+		case article.ContentHTML != nil:
+			item.Content = *article.ContentHTML
+		case article.Content != nil:
+			rendered, err := renderMarkdown(*article.Content)
+			if err != nil {
+				return nil, err
+			}
+			item.Content = rendered.HTML
+		}
+	}
+
+	return item, nil
+}
+
+// entryID はスラグが変わっても変わらない記事のID（tag URI）を返します
+func (s *feedService) entryID(article *models.ArticleResponse) string {
+	host := s.siteURL
+	if u, err := url.Parse(s.siteURL); err == nil && u.Host != "" {
+		host = u.Hostname()
+	}
+	return fmt.Sprintf("tag:%s,%s:articles/%d", host, article.CreatedAt.UTC().Format("2006-01-02"), article.ID)
+}
@@ -0,0 +1,245 @@
+package services
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	// アップロードを受け付ける画像の縦横それぞれの最大ピクセル数（展開後のメモリ使用量を抑えるため）
+	maxImageDimension = 8000
+	jpegQuality       = 90
+)
+
+// errUnsupportedImage は対応していない形式の画像が指定された場合のエラー
+var errUnsupportedImage = errors.New("対応していない画像形式です")
+
+// allowedImageTypes はアップロードを受け付ける画像のContent-Type
+var allowedImageTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+// readImage はサイズの上限を確認しながら画像を読み込み、中身から判定したContent-Typeとともにデコードします
+// 拡張子やリクエストのContent-Typeは信用せず、ファイルの先頭バイトから形式を判定します
+func readImage(r io.Reader, maxSize int64) (image.Image, string, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxSize+1))
+	if err != nil {
+		return nil, "", err
+	}
+	if int64(len(data)) > maxSize {
+		return nil, "", &ValidationError{Message: "画像のサイズが大きすぎます"}
+	}
+
+	contentType := http.DetectContentType(data)
+	if !allowedImageTypes[contentType] {
+		return nil, "", &ValidationError{Message: "画像はJPEG, PNG, GIF, WebPのいずれかを指定してください"}
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", &ValidationError{Message: "画像を読み込めませんでした"}
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width > maxImageDimension || cfg.Height > maxImageDimension {
+		return nil, "", &ValidationError{Message: "画像の縦横のサイズが大きすぎます"}
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", &ValidationError{Message: "画像を読み込めませんでした"}
+	}
+	// 再エンコードでExifは失われるため、スマートフォンの写真などが横倒しにならないよう先に向きを反映しておく
+	if contentType == "image/jpeg" {
+		img = applyOrientation(img, jpegOrientation(data))
+	}
+	return img, contentType, nil
+}
+
+// squareThumbnail は画像の中央を正方形に切り抜き、size×sizeに縮小します（元画像の方が小さい場合は拡大しません）
+func squareThumbnail(src image.Image, size int) image.Image {
+	b := src.Bounds()
+	side := min(b.Dx(), b.Dy())
+	crop := image.Rect(0, 0, side, side).Add(image.Pt(b.Min.X+(b.Dx()-side)/2, b.Min.Y+(b.Dy()-side)/2))
+
+	size = min(size, side)
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, crop, draw.Src, nil)
+	return dst
+}
+
+// resizeToFit は画像の幅と高さがどちらもmaxSide以下になるよう縦横比を保って縮小します（元画像の方が小さい場合はそのまま返します）
+func resizeToFit(src image.Image, maxSide int) image.Image {
+	b := src.Bounds()
+	if b.Dx() <= maxSide && b.Dy() <= maxSide {
+		return src
+	}
+	w, h := maxSide, b.Dy()*maxSide/b.Dx()
+	if b.Dy() > b.Dx() {
+		w, h = b.Dx()*maxSide/b.Dy(), maxSide
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, max(w, 1), max(h, 1)))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, b, draw.Src, nil)
+	return dst
+}
+
+// resizeToWidth は画像を縦横比を保って幅widthに縮小します
+func resizeToWidth(src image.Image, width int) image.Image {
+	b := src.Bounds()
+	if b.Dx() <= width {
+		return src
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, width, max(b.Dy()*width/b.Dx(), 1)))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, b, draw.Src, nil)
+	return dst
+}
+
+// encodeImage は画像を保存用にエンコードし、エンコード後のContent-Typeと拡張子を返します
+// 透過を含みうるPNG・GIFはPNGに、それ以外はJPEGにします。再エンコードするためExifなどのメタデータは残りません
+func encodeImage(img image.Image, sourceType string) ([]byte, string, string, error) {
+	var buf bytes.Buffer
+	switch sourceType {
+	case "image/png", "image/gif":
+		if err := png.Encode(&buf, img); err != nil {
+			return nil, "", "", err
+		}
+		return buf.Bytes(), "image/png", ".png", nil
+	case "image/jpeg", "image/webp":
+		if err := jpeg.Encode(&buf, flattenAlpha(img), &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, "", "", err
+		}
+		return buf.Bytes(), "image/jpeg", ".jpg", nil
+	}
+	return nil, "", "", errUnsupportedImage
+}
+
+// flattenAlpha は透過部分を白で塗りつぶします（JPEGは透過を扱えないため）
+func flattenAlpha(img image.Image) image.Image {
+	b := img.Bounds()
+	dst := image.NewRGBA(b)
+	draw.Draw(dst, b, image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(dst, b, img, b.Min, draw.Over)
+	return dst
+}
+
+// jpegOrientation はJPEGのExifから画像の向き（Orientationタグ、1〜8）を読み取ります。見つからない場合は1を返します
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 1
+		}
+		marker := data[i+1]
+		if marker == 0xFF {
+			// マーカー前の埋め草
+			i++
+			continue
+		}
+		// SOS以降は画像データなので、そこまでにExifがなければ諦める
+		if marker == 0xDA || marker == 0xD9 {
+			return 1
+		}
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || i+2+length > len(data) {
+			return 1
+		}
+		segment := data[i+4 : i+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return tiffOrientation(segment[6:])
+		}
+		i += 2 + length
+	}
+	return 1
+}
+
+// tiffOrientation はExifのTIFF構造の最初のIFDからOrientationタグを探します
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	offset := int(order.Uint32(tiff[4:]))
+	if offset < 8 || offset+2 > len(tiff) {
+		return 1
+	}
+	count := int(order.Uint16(tiff[offset:]))
+	for i := 0; i < count; i++ {
+		entry := offset + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			orientation := int(order.Uint16(tiff[entry+8:]))
+			if orientation < 1 || orientation > 8 {
+				return 1
+			}
+			return orientation
+		}
+	}
+	return 1
+}
+
+// applyOrientation はExifのOrientationに従って画像を回転・反転し、正しい向きにします
+func applyOrientation(img image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return img
+	}
+
+	b := img.Bounds()
+	src := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(src, src.Bounds(), img, b.Min, draw.Src)
+
+	w, h := b.Dx(), b.Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // 左右反転
+				dx, dy = w-1-x, y
+			case 3: // 180度回転
+				dx, dy = w-1-x, h-1-y
+			case 4: // 上下反転
+				dx, dy = x, h-1-y
+			case 5: // 左上と右下を結ぶ線で反転
+				dx, dy = y, x
+			case 6: // 時計回りに90度回転
+				dx, dy = h-1-y, x
+			case 7: // 右上と左下を結ぶ線で反転
+				dx, dy = h-1-y, w-1-x
+			case 8: // 反時計回りに90度回転
+				dx, dy = y, w-1-x
+			}
+			si, di := src.PixOffset(x, y), dst.PixOffset(dx, dy)
+			copy(dst.Pix[di:di+4], src.Pix[si:si+4])
+		}
+	}
+	return dst
+}
@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/yamada-mikiya/team1-hackathon/linkpreview"
+	"github.com/yamada-mikiya/team1-hackathon/models"
+)
+
+// applyLinkPreview は外部記事のリンク先のページからOGPなどを取得し、タイトル・サムネイル・概要を補います
+// 新規作成時（beforeがnil）と外部記事のURLが変わった場合のみ取得し、それ以外は前回取得した概要をそのまま使います
+// タイトルとサムネイルは著者が指定していない場合のみ設定します
+// 取得に失敗しても記事の保存は続けられるよう、ログに残すだけにします（fetcherがnilの場合は取得しません）
+// ctxにはリクエストのcontextを渡し、クライアントが切断した場合は取得を中止します
+func applyLinkPreview(ctx context.Context, fetcher linkpreview.Fetcher, before, article *models.Article) {
+	if article.ArticleType != "external" {
+		return
+	}
+	if article.ExternalURL == nil || !isHTTPURL(*article.ExternalURL) {
+		article.Excerpt = nil
+		return
+	}
+	externalURL := strings.TrimSpace(*article.ExternalURL)
+	if before != nil && before.ArticleType == "external" && strings.TrimSpace(derefString(before.ExternalURL)) == externalURL {
+		return
+	}
+
+	article.Excerpt = nil
+	if fetcher == nil {
+		return
+	}
+	preview, err := fetcher.Fetch(ctx, externalURL)
+	if err != nil {
+		slog.Warn("外部記事のリンクプレビューの取得に失敗しました", "url", externalURL, "error", err)
+		return
+	}
+
+	if article.Title == "" {
+		article.Title = preview.Title
+	}
+	if derefString(article.ThumbnailURL) == "" && preview.ImageURL != "" {
+		article.ThumbnailURL = &preview.ImageURL
+	}
+	if preview.Description != "" {
+		excerpt := truncateRunes(preview.Description, excerptLength)
+		article.Excerpt = &excerpt
+	}
+}
+
+// completeWithLinkPreview は検証を通った記事にリンクプレビューを反映します
+// 外部記事のタイトルを省略し、リンク先のページからも取得できなかった場合はエラーを返します
+func (s *articleService) completeWithLinkPreview(ctx context.Context, before, article *models.Article) error {
+	applyLinkPreview(ctx, s.linkPreview, before, article)
+	if article.Title == "" {
+		return &ValidationError{Message: "タイトルは必須です（リンク先のページからタイトルを取得できませんでした）"}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yamada-mikiya/team1-hackathon/linkpreview"
+	"github.com/yamada-mikiya/team1-hackathon/models"
+)
+
+type ctxKey struct{}
+
+// recordingFetcher は取得の呼び出しと渡されたcontextを記録するFetcher
+type recordingFetcher struct {
+	calls int
+	ctx   context.Context
+}
+
+func (f *recordingFetcher) Fetch(ctx context.Context, rawURL string) (*linkpreview.Preview, error) {
+	f.calls++
+	f.ctx = ctx
+	return &linkpreview.Preview{Title: "リンク先のタイトル", Description: "リンク先の概要"}, nil
+}
+
+func TestCreateArticleValidatesBeforeLinkPreview(t *testing.T) {
+	now := time.Now()
+	writer := &models.User{ID: 1, Role: models.RoleWriter, EmailVerifiedAt: &now}
+	fetcher := &recordingFetcher{}
+	s := &articleService{
+		repo:        &saveArticleRepository{},
+		tagRepo:     &saveTagRepository{},
+		userRepo:    &reviewUserRepository{users: map[int]*models.User{1: writer}},
+		linkPreview: fetcher,
+	}
+	externalURL := "https://example.com/post"
+
+	// 検証に失敗するリクエストでは、リンク先のページを取得しない
+	_, err := s.CreateArticle(context.Background(), 1, models.CreateArticleRequest{
+		ArticleType: "external",
+		ExternalURL: &externalURL,
+		Slug:        "Invalid Slug",
+		Department:  "Dev",
+	})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("err = %v, want *ValidationError", err)
+	}
+	if fetcher.calls != 0 {
+		t.Errorf("検証に失敗した記事のリンクプレビューを%d回取得しました", fetcher.calls)
+	}
+
+	// 公開中の記事はレビューを経ずに作成できないため、取得しない
+	_, err = s.CreateArticle(context.Background(), 1, models.CreateArticleRequest{
+		ArticleType: "external",
+		ExternalURL: &externalURL,
+		Slug:        "external-post",
+		Department:  "Dev",
+		Status:      "public",
+	})
+	if !errors.Is(err, ErrReviewApprovalRequired) {
+		t.Fatalf("err = %v, want ErrReviewApprovalRequired", err)
+	}
+	if fetcher.calls != 0 {
+		t.Errorf("ステータスの検証に失敗した記事のリンクプレビューを%d回取得しました", fetcher.calls)
+	}
+}
+
+func TestCompleteWithLinkPreview(t *testing.T) {
+	externalURL := "https://example.com/post"
+
+	t.Run("リクエストのcontextで取得してタイトルを補う", func(t *testing.T) {
+		fetcher := &recordingFetcher{}
+		s := &articleService{linkPreview: fetcher}
+		article := &models.Article{ArticleType: "external", ExternalURL: &externalURL}
+		ctx := context.WithValue(context.Background(), ctxKey{}, "request")
+
+		if err := s.completeWithLinkPreview(ctx, nil, article); err != nil {
+			t.Fatalf("completeWithLinkPreview: %v", err)
+		}
+		if fetcher.ctx == nil || fetcher.ctx.Value(ctxKey{}) != "request" {
+			t.Error("リクエストのcontextで取得していません")
+		}
+		if article.Title != "リンク先のタイトル" {
+			t.Errorf("Title = %q, want リンク先のタイトル", article.Title)
+		}
+		if derefString(article.Excerpt) != "リンク先の概要" {
+			t.Errorf("Excerpt = %q, want リンク先の概要", derefString(article.Excerpt))
+		}
+	})
+
+	t.Run("タイトルを取得できない", func(t *testing.T) {
+		// fetcherがnilの場合は取得しないため、タイトルを補えない
+		s := &articleService{}
+		article := &models.Article{ArticleType: "external", ExternalURL: &externalURL}
+		err := s.completeWithLinkPreview(context.Background(), nil, article)
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Fatalf("err = %v, want *ValidationError", err)
+		}
+	})
+}
@@ -0,0 +1,301 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"github.com/yuin/goldmark"
+	highlighting "github.com/yuin/goldmark-highlighting/v2"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+)
+
+const (
+	// 抜粋の最大文字数
+	excerptLength = 120
+	// 読了時間の目安。日本語は1分あたりの文字数、英語などは1分あたりの単語数で数えます
+	cjkCharsPerMinute = 500
+	wordsPerMinute    = 200
+	// 目次に含める見出しの最も深いレベル
+	maxTOCLevel = 4
+)
+
+// markdownRenderer は記事本文（GitHub Flavored Markdown）をHTMLに変換します
+// 生のHTMLは出力しません。コードブロックはスタイルを埋め込まず、chromaのクラス名を付けて出力します
+var markdownRenderer = goldmark.New(
+	goldmark.WithExtensions(
+		extension.GFM,
+		highlighting.NewHighlighting(
+			highlighting.WithFormatOptions(chromahtml.WithClasses(true)),
+		),
+	),
+	goldmark.WithParserOptions(parser.WithAutoHeadingID()),
+)
+
+// htmlPolicy は記事本文から生成したHTMLに残してよい要素・属性
+// Markdownの変換結果をそのまま信用せず、保存やフィードなど外部に出す前に必ず通します
+var htmlPolicy = newHTMLPolicy(true)
+
+// commentHTMLPolicy はコメントの本文から生成したHTMLに残してよい要素・属性
+// 記事本文と同じですが、記事の見出しのidと重ならないよう見出しのidは残しません
+var commentHTMLPolicy = newHTMLPolicy(false)
+
+func newHTMLPolicy(headingIDs bool) *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	if headingIDs {
+		// 目次からのリンク先になる見出しのid（日本語の見出しもそのままidにします）
+		p.AllowAttrs("id").Matching(regexp.MustCompile(`^[\p{L}\p{N}_-]+$`)).OnElements("h1", "h2", "h3", "h4", "h5", "h6")
+	}
+	// シンタックスハイライトのクラス名
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^[a-zA-Z0-9_ -]+$`)).OnElements("pre", "code", "span")
+	return p
+}
+
+// renderedMarkdown はMarkdownの本文から生成した、保存時にキャッシュしておく内容
+type renderedMarkdown struct {
+	HTML               string
+	TOC                models.TOC
+	ReadingTimeMinutes int
+	Excerpt            string
+}
+
+// renderMarkdown はMarkdownをサニタイズ済みのHTMLに変換し、目次・読了時間・抜粋もあわせて作成します
+func renderMarkdown(source string) (*renderedMarkdown, error) {
+	src := []byte(source)
+	ctx := parser.NewContext(parser.WithIDs(newHeadingIDs()))
+	doc := markdownRenderer.Parser().Parse(text.NewReader(src), parser.WithContext(ctx))
+
+	var buf bytes.Buffer
+	if err := markdownRenderer.Renderer().Render(&buf, src, doc); err != nil {
+		return nil, err
+	}
+
+	body := plainText(doc, src)
+	return &renderedMarkdown{
+		HTML:               htmlPolicy.Sanitize(buf.String()),
+		TOC:                tableOfContents(doc, src),
+		ReadingTimeMinutes: readingTimeMinutes(body),
+		Excerpt:            truncateRunes(body, excerptLength),
+	}, nil
+}
+
+// renderCommentMarkdown はコメントのMarkdownを、記事本文と同じ方法で変換してサニタイズ済みのHTMLにします
+func renderCommentMarkdown(source string) (string, error) {
+	var buf bytes.Buffer
+	if err := markdownRenderer.Convert([]byte(source), &buf); err != nil {
+		return "", err
+	}
+	return commentHTMLPolicy.Sanitize(buf.String()), nil
+}
+
+// applyRenderedContent はMarkdown記事の本文からHTML・目次・読了時間・抜粋を作成して記事に設定します
+// 外部記事や本文のない記事ではHTML・目次・読了時間を空にします（外部記事の抜粋はapplyLinkPreviewで設定します）
+func applyRenderedContent(article *models.Article) error {
+	article.ContentHTML = nil
+	article.TOC = nil
+	article.ReadingTimeMinutes = nil
+	if article.ArticleType != "markdown" || article.Content == nil {
+		if article.ArticleType != "external" {
+			article.Excerpt = nil
+		}
+		return nil
+	}
+
+	rendered, err := renderMarkdown(*article.Content)
+	if err != nil {
+		return err
+	}
+	article.ContentHTML = &rendered.HTML
+	article.TOC = rendered.TOC
+	article.ReadingTimeMinutes = &rendered.ReadingTimeMinutes
+	article.Excerpt = &rendered.Excerpt
+	return nil
+}
+
+// needsRendering はMarkdown記事でHTMLがまだ作成されていない（キャッシュを導入する前に保存された）かを返します
+func needsRendering(article *models.Article) bool {
+	return article.ArticleType == "markdown" && article.Content != nil && article.ContentHTML == nil
+}
+
+// tableOfContents は見出し（h1〜h4）から目次を作成します
+func tableOfContents(doc ast.Node, src []byte) models.TOC {
+	toc := models.TOC{}
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		heading, ok := n.(*ast.Heading)
+		if !entering || !ok {
+			return ast.WalkContinue, nil
+		}
+		if heading.Level <= maxTOCLevel {
+			id, _ := heading.AttributeString("id")
+			idBytes, _ := id.([]byte)
+			toc = append(toc, models.TOCEntry{
+				Level: heading.Level,
+				Text:  collapseSpaces(inlineText(heading, src)),
+				ID:    string(idBytes),
+			})
+		}
+		return ast.WalkSkipChildren, nil
+	})
+	return toc
+}
+
+// plainText は本文から見出しとコードブロックを除いたテキストを取り出します
+func plainText(doc ast.Node, src []byte) string {
+	var b strings.Builder
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch n.(type) {
+		case *ast.Heading, *ast.FencedCodeBlock, *ast.CodeBlock, *ast.HTMLBlock:
+			return ast.WalkSkipChildren, nil
+		case *ast.Paragraph, *ast.TextBlock:
+			b.WriteString(inlineText(n, src))
+			b.WriteByte('\n')
+			return ast.WalkSkipChildren, nil
+		}
+		return ast.WalkContinue, nil
+	})
+	return collapseSpaces(b.String())
+}
+
+// inlineText はインライン要素のテキストを連結します。画像は代替テキストを使います
+func inlineText(n ast.Node, src []byte) string {
+	var b strings.Builder
+	_ = ast.Walk(n, func(c ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch c := c.(type) {
+		case *ast.Text:
+			b.Write(c.Segment.Value(src))
+			if c.SoftLineBreak() || c.HardLineBreak() {
+				b.WriteByte('\n')
+			}
+		case *ast.String:
+			b.Write(c.Value)
+		case *ast.CodeSpan:
+			for child := c.FirstChild(); child != nil; child = child.NextSibling() {
+				if t, ok := child.(*ast.Text); ok {
+					b.Write(t.Segment.Value(src))
+				}
+			}
+			return ast.WalkSkipChildren, nil
+		case *ast.AutoLink:
+			b.Write(c.Label(src))
+		case *ast.RawHTML:
+			return ast.WalkSkipChildren, nil
+		}
+		return ast.WalkContinue, nil
+	})
+	return b.String()
+}
+
+// collapseSpaces は連続する空白・改行を1つの空白にまとめます
+// 日本語の文章は途中で改行しても空白を入れないため、改行の前後が日本語の文字の場合は改行を取り除きます
+func collapseSpaces(s string) string {
+	var b strings.Builder
+	var prev rune
+	pending, onlyNewlines := false, true
+	for _, r := range s {
+		if unicode.IsSpace(r) {
+			if b.Len() > 0 {
+				onlyNewlines = onlyNewlines && (r == '\n' || r == '\r')
+				pending = true
+			}
+			continue
+		}
+		if pending && !(onlyNewlines && isCJK(prev) && isCJK(r)) {
+			b.WriteByte(' ')
+		}
+		pending, onlyNewlines = false, true
+		b.WriteRune(r)
+		prev = r
+	}
+	return b.String()
+}
+
+// readingTimeMinutes は本文を読むのにかかる時間（分）の目安を返します
+// 日本語は単語が空白で区切られないため、漢字・かなの文字数と、それ以外の単語数を別々に数えて合算します
+func readingTimeMinutes(body string) int {
+	cjkChars, words := 0, 0
+	inWord := false
+	for _, r := range body {
+		switch {
+		case isCJK(r):
+			cjkChars++
+			inWord = false
+		case unicode.IsLetter(r) || unicode.IsNumber(r):
+			if !inWord {
+				words++
+			}
+			inWord = true
+		default:
+			inWord = false
+		}
+	}
+	minutes := float64(cjkChars)/cjkCharsPerMinute + float64(words)/wordsPerMinute
+	return max(int(math.Ceil(minutes)), 1)
+}
+
+// isCJK は漢字・ひらがな・カタカナ（全角の長音記号を含む）かを返します
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana) || r == 'ー'
+}
+
+// truncateRunes は文字数がlimitを超える場合に切り詰めて末尾に「…」を付けます
+func truncateRunes(s string, limit int) string {
+	if utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+	runes := []rune(s)
+	return strings.TrimSpace(string(runes[:limit])) + "…"
+}
+
+// headingIDs は見出しのテキストからアンカー用のidを作成します
+// goldmark標準の生成方法は英数字以外を取り除くため、日本語の見出しでもテキストが残るようにしています
+// 同じ本文からは常に同じidになり、重複した場合は末尾に -1, -2 ... を付けます
+type headingIDs struct {
+	used map[string]bool
+}
+
+func newHeadingIDs() parser.IDs {
+	return &headingIDs{used: map[string]bool{}}
+}
+
+func (s *headingIDs) Generate(value []byte, kind ast.NodeKind) []byte {
+	var b strings.Builder
+	for _, r := range strings.TrimSpace(string(value)) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsNumber(r):
+			b.WriteRune(unicode.ToLower(r))
+		case unicode.IsSpace(r) || r == '-' || r == '_':
+			b.WriteRune('-')
+		}
+	}
+	id := b.String()
+	if id == "" {
+		id = "section"
+	}
+
+	candidate := id
+	for i := 1; s.used[candidate]; i++ {
+		candidate = fmt.Sprintf("%s-%d", id, i)
+	}
+	s.used[candidate] = true
+	return []byte(candidate)
+}
+
+func (s *headingIDs) Put(value []byte) {
+	s.used[string(value)] = true
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/yamada-mikiya/team1-hackathon/models"
+)
+
+func TestRenderMarkdownSanitizesHTML(t *testing.T) {
+	tests := []struct {
+		name      string
+		source    string
+		forbidden []string
+	}{
+		{"scriptタグ", "本文\n\n<script>alert(1)</script>\n", []string{"<script", "alert(1)"}},
+		{"インラインのscriptタグ", "本文 <script>alert(1)</script> の続き", []string{"<script"}},
+		{"onerror属性", `<img src="x" onerror="alert(1)">`, []string{"onerror", "<img"}},
+		{"インラインのonerror属性", `画像 <img src="x" onerror="alert(1)"> の続き`, []string{"onerror"}},
+		{"javascript:のリンク", "[クリック](javascript:alert(1))", []string{"javascript:"}},
+		{"大文字のjavascript:のリンク", "[クリック](JaVaScRiPt:alert(1))", []string{"javascript:"}},
+		{"javascript:の自動リンク", "<javascript:alert(1)>", []string{"href="}},
+		{"javascript:の画像", "![画像](javascript:alert(1))", []string{"javascript:"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rendered, err := renderMarkdown(tt.source)
+			if err != nil {
+				t.Fatalf("renderMarkdown: %v", err)
+			}
+			for _, s := range tt.forbidden {
+				if strings.Contains(strings.ToLower(rendered.HTML), s) {
+					t.Errorf("HTMLに %q が残っています: %s", s, rendered.HTML)
+				}
+			}
+		})
+	}
+
+	// 通常のリンクは残す
+	rendered, err := renderMarkdown("[ドキュメント](https://example.com/docs)")
+	if err != nil {
+		t.Fatalf("renderMarkdown: %v", err)
+	}
+	if !strings.Contains(rendered.HTML, `href="https://example.com/docs"`) {
+		t.Errorf("httpsのリンクが削除されています: %s", rendered.HTML)
+	}
+}
+
+func TestRenderMarkdownHeadingIDs(t *testing.T) {
+	source := "# はじめに\n\n## 概要\n\n## 概要\n\n## 概要\n\n### Go API の設計\n\n##### 深い見出し\n"
+	rendered, err := renderMarkdown(source)
+	if err != nil {
+		t.Fatalf("renderMarkdown: %v", err)
+	}
+
+	// 日本語はそのまま、英字は小文字、空白は-にする。重複した見出しには -1, -2 を付ける
+	want := models.TOC{
+		{Level: 1, Text: "はじめに", ID: "はじめに"},
+		{Level: 2, Text: "概要", ID: "概要"},
+		{Level: 2, Text: "概要", ID: "概要-1"},
+		{Level: 2, Text: "概要", ID: "概要-2"},
+		{Level: 3, Text: "Go API の設計", ID: "go-api-の設計"},
+	}
+	if !slices.Equal(rendered.TOC, want) {
+		t.Errorf("TOC = %+v, want %+v", rendered.TOC, want)
+	}
+	// 目次のidは、サニタイズ後のHTMLの見出しにも残る
+	for _, entry := range want {
+		if !strings.Contains(rendered.HTML, `id="`+entry.ID+`"`) {
+			t.Errorf("HTMLに id=%q の見出しがありません: %s", entry.ID, rendered.HTML)
+		}
+	}
+
+	// 同じ本文からは常に同じidになる
+	again, err := renderMarkdown(source)
+	if err != nil {
+		t.Fatalf("renderMarkdown: %v", err)
+	}
+	if again.HTML != rendered.HTML {
+		t.Error("同じ本文から異なるHTMLが作成されました")
+	}
+}
+
+func TestRenderCommentMarkdown(t *testing.T) {
+	// コメントの見出しには目次がないため、日本語のidは残さない
+	html, err := renderCommentMarkdown("# はじめに\n\n<script>alert(1)</script>\n\n[クリック](javascript:alert(1))")
+	if err != nil {
+		t.Fatalf("renderCommentMarkdown: %v", err)
+	}
+	for _, s := range []string{`id="はじめに"`, "<script", "javascript:"} {
+		if strings.Contains(html, s) {
+			t.Errorf("コメントのHTMLに %q が残っています: %s", s, html)
+		}
+	}
+}
+
+func TestReadingTimeMinutes(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"空", "", 1},
+		{"日本語500文字", strings.Repeat("あ", 500), 1},
+		{"日本語501文字", strings.Repeat("あ", 501), 2},
+		{"日本語1500文字（漢字・カタカナを含む）", strings.Repeat("漢字とカタカナー", 1500/8) + strings.Repeat("字", 1500%8), 3},
+		{"英語200単語", strings.Repeat("word ", 200), 1},
+		{"英語201単語", strings.Repeat("word ", 201), 2},
+		// 日本語250文字（0.5分）と英語100単語（0.5分）
+		{"日本語と英語", strings.Repeat("日本語", 250/3) + "本" + strings.Repeat(" Go", 100), 1},
+		{"日本語と英語（1分を超える）", strings.Repeat("あ", 300) + strings.Repeat(" Go", 100), 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := readingTimeMinutes(tt.body); got != tt.want {
+				t.Errorf("readingTimeMinutes = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderMarkdownReadingTimeExcludesCode(t *testing.T) {
+	// コードブロックと見出しは読了時間に含めない
+	source := "# " + strings.Repeat("見出し", 200) + "\n\n" + strings.Repeat("本", 400) + "\n\n```go\n" + strings.Repeat("// コメント\n", 500) + "```\n"
+	rendered, err := renderMarkdown(source)
+	if err != nil {
+		t.Fatalf("renderMarkdown: %v", err)
+	}
+	if rendered.ReadingTimeMinutes != 1 {
+		t.Errorf("ReadingTimeMinutes = %d, want 1", rendered.ReadingTimeMinutes)
+	}
+}
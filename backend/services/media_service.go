@@ -0,0 +1,109 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"io"
+
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"github.com/yamada-mikiya/team1-hackathon/repositories"
+	"github.com/yamada-mikiya/team1-hackathon/storage"
+)
+
+const (
+	// 記事用の画像として受け付けるファイルサイズの上限
+	MaxMediaSize = 10 << 20
+	// 保存する画像（original）の縦横それぞれの最大ピクセル数
+	maxMediaSide = 2560
+)
+
+// mediaVariantWidths は記事用の画像から作る縮小版の幅。元画像より小さいものだけを作ります
+var mediaVariantWidths = []int{320, 640, 1280}
+
+type MediaService interface {
+	Upload(ctx context.Context, userID int, file io.Reader) (*models.MediaResponse, error)
+}
+
+type mediaService struct {
+	userRepo repositories.UserRepository
+	store    storage.BlobStore
+}
+
+func NewMediaService(userRepo repositories.UserRepository, store storage.BlobStore) MediaService {
+	return &mediaService{userRepo: userRepo, store: store}
+}
+
+// Upload は記事用の画像を保存し、元画像と縮小版のURLを返します（記事を作成できるユーザーのみ）
+// 画像は再エンコードしてExifなどのメタデータを取り除きます。保存先のキーは変換後の内容から決めるため、
+// 同じ画像を何度アップロードしても同じURLになり、URLの指す内容が変わることはありません
+func (s *mediaService) Upload(ctx context.Context, userID int, file io.Reader) (*models.MediaResponse, error) {
+	if err := requireWriter(s.userRepo, userID); err != nil {
+		return nil, err
+	}
+
+	img, sourceType, err := readImage(file, MaxMediaSize)
+	if err != nil {
+		return nil, err
+	}
+
+	original := resizeToFit(img, maxMediaSide)
+	data, contentType, ext, err := encodeImage(original, sourceType)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	id := hex.EncodeToString(sum[:16])
+
+	url, err := s.store.Put(ctx, fmt.Sprintf("images/%s/original%s", id, ext), bytes.NewReader(data), contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := original.Bounds()
+	res := &models.MediaResponse{
+		ID:          id,
+		URL:         url,
+		ContentType: contentType,
+		Width:       bounds.Dx(),
+		Height:      bounds.Dy(),
+		Variants:    []models.MediaVariantResponse{},
+	}
+
+	for _, width := range mediaVariantWidths {
+		if width >= bounds.Dx() {
+			break
+		}
+		variant, err := s.putVariant(ctx, original, sourceType, fmt.Sprintf("images/%s/w%d%s", id, width, ext), width)
+		if err != nil {
+			return nil, err
+		}
+		res.Variants = append(res.Variants, *variant)
+	}
+
+	return res, nil
+}
+
+// putVariant は画像を幅widthに縮小してkeyに保存します
+func (s *mediaService) putVariant(ctx context.Context, img image.Image, sourceType, key string, width int) (*models.MediaVariantResponse, error) {
+	resized := resizeToWidth(img, width)
+	data, contentType, _, err := encodeImage(resized, sourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	url, err := s.store.Put(ctx, key, bytes.NewReader(data), contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := resized.Bounds()
+	return &models.MediaVariantResponse{
+		URL:    url,
+		Width:  bounds.Dx(),
+		Height: bounds.Dy(),
+	}, nil
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/yamada-mikiya/team1-hackathon/mailer"
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"github.com/yamada-mikiya/team1-hackathon/repositories"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultPasswordResetTTL = time.Hour
+	minPasswordLength       = 8
+)
+
+type PasswordResetService interface {
+	RequestPasswordReset(ctx context.Context, req models.ForgotPasswordRequest) error
+	ResetPassword(ctx context.Context, req models.ResetPasswordRequest) error
+}
+
+type passwordResetService struct {
+	userRepo         repositories.UserRepository
+	repo             repositories.PasswordResetTokenRepository
+	refreshTokenRepo repositories.RefreshTokenRepository
+	mailer           mailer.Mailer
+	resetURL         string
+	ttl              time.Duration
+}
+
+func NewPasswordResetService(userRepo repositories.UserRepository, repo repositories.PasswordResetTokenRepository, refreshTokenRepo repositories.RefreshTokenRepository, m mailer.Mailer, resetURL string, ttl time.Duration) PasswordResetService {
+	if ttl <= 0 {
+		ttl = defaultPasswordResetTTL
+	}
+	return &passwordResetService{
+		userRepo:         userRepo,
+		repo:             repo,
+		refreshTokenRepo: refreshTokenRepo,
+		mailer:           m,
+		resetURL:         resetURL,
+		ttl:              ttl,
+	}
+}
+
+// RequestPasswordReset はパスワードリセット用のリンクをメールで送信します
+// 登録されているメールアドレスかどうかを推測されないよう、未登録の場合も成功として扱います
+// 同じ理由で、メールの送信は完了を待たず（mailerにはworkers.MailQueueを渡す）、送信の失敗もログに出力するだけにします
+func (s *passwordResetService) RequestPasswordReset(ctx context.Context, req models.ForgotPasswordRequest) error {
+	email := strings.TrimSpace(req.Email)
+	if email == "" {
+		return &ValidationError{Message: "メールアドレスは必須です"}
+	}
+
+	user, err := s.userRepo.GetUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			slog.Info("未登録のメールアドレスへのパスワードリセット要求のため、メールは送信しません")
+			return nil
+		}
+		return err
+	}
+
+	token, err := newOpaqueToken()
+	if err != nil {
+		return err
+	}
+
+	// 以前に発行した未使用のリンクは無効にし、最新のリンクだけを使えるようにする
+	if err := s.repo.InvalidateForUser(ctx, user.ID); err != nil {
+		return err
+	}
+	expiresAt := time.Now().Add(s.ttl)
+	if err := s.repo.Create(ctx, &models.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: hashToken(token),
+		ExpiresAt: expiresAt,
+	}); err != nil {
+		return err
+	}
+
+	if err := s.mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "パスワード再設定のご案内",
+		Body:    s.buildResetMail(user, token, expiresAt),
+	}); err != nil {
+		slog.Error("パスワード再設定のメールの送信に失敗しました", "user_id", user.ID, "error", err)
+	}
+	return nil
+}
+
+// ResetPassword はリセットトークンを使用済みにしてパスワードを変更します
+// 変更後は他の端末のログインも無効にするため、ユーザーのリフレッシュトークンをすべて失効させます
+func (s *passwordResetService) ResetPassword(ctx context.Context, req models.ResetPasswordRequest) error {
+	if req.Token == "" {
+		return ErrInvalidResetToken
+	}
+	if len(req.Password) < minPasswordLength {
+		return &ValidationError{Message: "パスワードは8文字以上である必要があります"}
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	token, err := s.repo.Consume(ctx, hashToken(req.Token), time.Now())
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvalidResetToken
+		}
+		return err
+	}
+
+	if err := s.userRepo.UpdatePassword(ctx, token.UserID, string(hashedPassword)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvalidResetToken
+		}
+		return err
+	}
+	if err := s.repo.InvalidateForUser(ctx, token.UserID); err != nil {
+		return err
+	}
+	return s.refreshTokenRepo.RevokeByUser(ctx, token.UserID)
+}
+
+func (s *passwordResetService) buildResetMail(user *models.User, token string, expiresAt time.Time) string {
+	link := s.resetURL
+	if link == "" {
+		link = "http://localhost:3000/reset-password"
+	}
+	separator := "?"
+	if strings.Contains(link, "?") {
+		separator = "&"
+	}
+	link += separator + "token=" + url.QueryEscape(token)
+
+	return fmt.Sprintf(`%s 様
+
+パスワード再設定のリクエストを受け付けました。
+以下のリンクから新しいパスワードを設定してください。
+
+%s
+
+このリンクは %s まで有効で、一度だけ使用できます。
+お心当たりがない場合は、このメールを破棄してください。パスワードは変更されません。
+`, user.Name, link, expiresAt.Format("2006-01-02 15:04 MST"))
+}
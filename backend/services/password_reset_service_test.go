@@ -0,0 +1,269 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yamada-mikiya/team1-hackathon/mailer"
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"github.com/yamada-mikiya/team1-hackathon/repositories"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// resetUserRepository はメールアドレスでの検索とパスワードの更新だけを行うUserRepositoryの代わり
+type resetUserRepository struct {
+	repositories.UserRepository
+	users map[string]*models.User
+}
+
+func (r *resetUserRepository) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	if user, ok := r.users[email]; ok {
+		return user, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *resetUserRepository) UpdatePassword(ctx context.Context, userID int, passwordHash string) error {
+	for _, user := range r.users {
+		if user.ID == userID {
+			user.PasswordHash = passwordHash
+			return nil
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
+
+// resetTokenRepository はPasswordResetTokenRepositoryをメモリ上で再現します
+type resetTokenRepository struct {
+	tokens []*models.PasswordResetToken
+}
+
+func (r *resetTokenRepository) Create(ctx context.Context, token *models.PasswordResetToken) error {
+	token.ID = len(r.tokens) + 1
+	r.tokens = append(r.tokens, token)
+	return nil
+}
+
+func (r *resetTokenRepository) Consume(ctx context.Context, tokenHash string, now time.Time) (*models.PasswordResetToken, error) {
+	for _, token := range r.tokens {
+		if token.TokenHash == tokenHash && token.UsedAt == nil && token.ExpiresAt.After(now) {
+			token.UsedAt = &now
+			return token, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *resetTokenRepository) InvalidateForUser(ctx context.Context, userID int) error {
+	now := time.Now()
+	for _, token := range r.tokens {
+		if token.UserID == userID && token.UsedAt == nil {
+			token.UsedAt = &now
+		}
+	}
+	return nil
+}
+
+func (r *resetTokenRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	return 0, nil
+}
+
+// resetRefreshTokenRepository はリフレッシュトークンを失効させたユーザーを記録します
+type resetRefreshTokenRepository struct {
+	repositories.RefreshTokenRepository
+	revokedUserIDs []int
+}
+
+func (r *resetRefreshTokenRepository) RevokeByUser(ctx context.Context, userID int) error {
+	r.revokedUserIDs = append(r.revokedUserIDs, userID)
+	return nil
+}
+
+// recordingMailer は送信したメールを記録します
+type recordingMailer struct {
+	messages []mailer.Message
+}
+
+func (m *recordingMailer) Send(ctx context.Context, msg mailer.Message) error {
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+// failingMailer は常に送信に失敗します
+type failingMailer struct{}
+
+func (failingMailer) Send(ctx context.Context, msg mailer.Message) error {
+	return errors.New("SMTPサーバーに接続できません")
+}
+
+var resetLinkTokenPattern = regexp.MustCompile(`[?&]token=(\S+)`)
+
+// resetTokenFromMail はメール本文のリンクからリセットトークンを取り出します
+func resetTokenFromMail(t *testing.T, msg mailer.Message) string {
+	t.Helper()
+	match := resetLinkTokenPattern.FindStringSubmatch(msg.Body)
+	if match == nil {
+		t.Fatalf("メール本文にリセット用のリンクがありません: %s", msg.Body)
+	}
+	token, err := url.QueryUnescape(match[1])
+	if err != nil {
+		t.Fatalf("トークンのデコードに失敗しました: %v", err)
+	}
+	return token
+}
+
+type passwordResetFixture struct {
+	service      PasswordResetService
+	user         *models.User
+	tokens       *resetTokenRepository
+	refreshRepo  *resetRefreshTokenRepository
+	mailer       *recordingMailer
+	originalHash string
+}
+
+func newPasswordResetFixture() *passwordResetFixture {
+	user := &models.User{ID: 1, Name: "山田", Email: "yamada@example.com", PasswordHash: "old-hash"}
+	f := &passwordResetFixture{
+		user:         user,
+		tokens:       &resetTokenRepository{},
+		refreshRepo:  &resetRefreshTokenRepository{},
+		mailer:       &recordingMailer{},
+		originalHash: user.PasswordHash,
+	}
+	userRepo := &resetUserRepository{users: map[string]*models.User{user.Email: user}}
+	f.service = NewPasswordResetService(userRepo, f.tokens, f.refreshRepo, f.mailer, "https://wiki.example.com/reset-password", time.Hour)
+	return f
+}
+
+// requestToken はリセットを要求し、送信されたメールからトークンを取り出します
+func (f *passwordResetFixture) requestToken(t *testing.T) string {
+	t.Helper()
+	if err := f.service.RequestPasswordReset(context.Background(), models.ForgotPasswordRequest{Email: f.user.Email}); err != nil {
+		t.Fatalf("RequestPasswordReset: %v", err)
+	}
+	if len(f.mailer.messages) == 0 {
+		t.Fatal("メールが送信されていません")
+	}
+	return resetTokenFromMail(t, f.mailer.messages[len(f.mailer.messages)-1])
+}
+
+func TestPasswordResetFlow(t *testing.T) {
+	f := newPasswordResetFixture()
+	token := f.requestToken(t)
+
+	msg := f.mailer.messages[0]
+	if msg.To != f.user.Email {
+		t.Errorf("宛先 = %q, want %q", msg.To, f.user.Email)
+	}
+	if !strings.Contains(msg.Body, "https://wiki.example.com/reset-password?token=") {
+		t.Errorf("メール本文に設定したリセット用のURLがありません: %s", msg.Body)
+	}
+	// DBにはトークン本体ではなくハッシュだけを保存する
+	if len(f.tokens.tokens) != 1 || f.tokens.tokens[0].TokenHash != hashToken(token) {
+		t.Fatalf("保存されたトークン = %+v", f.tokens.tokens)
+	}
+
+	if err := f.service.ResetPassword(context.Background(), models.ResetPasswordRequest{Token: token, Password: "new-password"}); err != nil {
+		t.Fatalf("ResetPassword: %v", err)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(f.user.PasswordHash), []byte("new-password")); err != nil {
+		t.Errorf("パスワードが変更されていません: %v", err)
+	}
+	if len(f.refreshRepo.revokedUserIDs) != 1 || f.refreshRepo.revokedUserIDs[0] != f.user.ID {
+		t.Errorf("リフレッシュトークンを失効させたユーザー = %v, want [%d]", f.refreshRepo.revokedUserIDs, f.user.ID)
+	}
+}
+
+func TestResetPasswordTokenIsSingleUse(t *testing.T) {
+	f := newPasswordResetFixture()
+	token := f.requestToken(t)
+
+	if err := f.service.ResetPassword(context.Background(), models.ResetPasswordRequest{Token: token, Password: "new-password"}); err != nil {
+		t.Fatalf("1回目のResetPassword: %v", err)
+	}
+	changedHash := f.user.PasswordHash
+
+	err := f.service.ResetPassword(context.Background(), models.ResetPasswordRequest{Token: token, Password: "another-password"})
+	if !errors.Is(err, ErrInvalidResetToken) {
+		t.Errorf("2回目のResetPassword: err = %v, want ErrInvalidResetToken", err)
+	}
+	if f.user.PasswordHash != changedHash {
+		t.Error("使用済みのトークンでパスワードが変更されています")
+	}
+}
+
+func TestResetPasswordRejectsExpiredToken(t *testing.T) {
+	f := newPasswordResetFixture()
+	token := f.requestToken(t)
+	f.tokens.tokens[0].ExpiresAt = time.Now().Add(-time.Minute)
+
+	err := f.service.ResetPassword(context.Background(), models.ResetPasswordRequest{Token: token, Password: "new-password"})
+	if !errors.Is(err, ErrInvalidResetToken) {
+		t.Errorf("err = %v, want ErrInvalidResetToken", err)
+	}
+	if f.user.PasswordHash != f.originalHash {
+		t.Error("期限切れのトークンでパスワードが変更されています")
+	}
+}
+
+func TestRequestPasswordResetInvalidatesPreviousToken(t *testing.T) {
+	f := newPasswordResetFixture()
+	oldToken := f.requestToken(t)
+	newToken := f.requestToken(t)
+
+	err := f.service.ResetPassword(context.Background(), models.ResetPasswordRequest{Token: oldToken, Password: "new-password"})
+	if !errors.Is(err, ErrInvalidResetToken) {
+		t.Errorf("古いトークン: err = %v, want ErrInvalidResetToken", err)
+	}
+	if err := f.service.ResetPassword(context.Background(), models.ResetPasswordRequest{Token: newToken, Password: "new-password"}); err != nil {
+		t.Errorf("新しいトークン: %v", err)
+	}
+}
+
+func TestResetPasswordValidation(t *testing.T) {
+	f := newPasswordResetFixture()
+	token := f.requestToken(t)
+
+	err := f.service.ResetPassword(context.Background(), models.ResetPasswordRequest{Token: token, Password: "short"})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Errorf("短いパスワード: err = %v, want ValidationError", err)
+	}
+	// 入力エラーの場合はトークンを消費しない
+	if err := f.service.ResetPassword(context.Background(), models.ResetPasswordRequest{Token: token, Password: "new-password"}); err != nil {
+		t.Errorf("入力エラーの後のResetPassword: %v", err)
+	}
+
+	err = f.service.ResetPassword(context.Background(), models.ResetPasswordRequest{Token: "unknown-token", Password: "new-password"})
+	if !errors.Is(err, ErrInvalidResetToken) {
+		t.Errorf("不明なトークン: err = %v, want ErrInvalidResetToken", err)
+	}
+}
+
+func TestRequestPasswordResetUnknownEmail(t *testing.T) {
+	f := newPasswordResetFixture()
+
+	if err := f.service.RequestPasswordReset(context.Background(), models.ForgotPasswordRequest{Email: "unknown@example.com"}); err != nil {
+		t.Fatalf("未登録のメールアドレスでエラーになっています: %v", err)
+	}
+	if len(f.mailer.messages) != 0 || len(f.tokens.tokens) != 0 {
+		t.Errorf("未登録のメールアドレスにトークンを発行しています: mails=%d tokens=%d", len(f.mailer.messages), len(f.tokens.tokens))
+	}
+}
+
+func TestRequestPasswordResetIgnoresMailError(t *testing.T) {
+	f := newPasswordResetFixture()
+	userRepo := &resetUserRepository{users: map[string]*models.User{f.user.Email: f.user}}
+	service := NewPasswordResetService(userRepo, f.tokens, f.refreshRepo, failingMailer{}, "", time.Hour)
+
+	// 送信の失敗をエラーとして返すと、登録されているメールアドレスかどうかを推測できてしまう
+	if err := service.RequestPasswordReset(context.Background(), models.ForgotPasswordRequest{Email: f.user.Email}); err != nil {
+		t.Errorf("メールの送信の失敗がエラーになっています: %v", err)
+	}
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"github.com/yamada-mikiya/team1-hackathon/repositories"
+	"gorm.io/gorm"
+)
+
+const (
+	// previewTokenAudience はログイン用トークンと区別するためのaudクレーム
+	previewTokenAudience   = "article-preview"
+	defaultPreviewTokenTTL = 72 * time.Hour
+)
+
+type PreviewService interface {
+	CreatePreviewToken(slug string, userID int) (*models.PreviewTokenResponse, error)
+	GetPreviewTokens(slug string, userID int) ([]models.PreviewTokenResponse, error)
+	RevokePreviewToken(slug string, userID int, tokenID string) error
+	VerifyPreviewToken(tokenString string) (int, error)
+}
+
+type previewService struct {
+	articleRepo repositories.ArticleRepository
+	userRepo    repositories.UserRepository
+	repo        repositories.PreviewTokenRepository
+	secretKey   string
+	ttl         time.Duration
+}
+
+func NewPreviewService(articleRepo repositories.ArticleRepository, userRepo repositories.UserRepository, repo repositories.PreviewTokenRepository, secretKey string, ttl time.Duration) PreviewService {
+	if ttl <= 0 {
+		ttl = defaultPreviewTokenTTL
+	}
+	return &previewService{
+		articleRepo: articleRepo,
+		userRepo:    userRepo,
+		repo:        repo,
+		secretKey:   secretKey,
+		ttl:         ttl,
+	}
+}
+
+// CreatePreviewToken は記事の著者（または記事を管理できるユーザー）向けに、その記事だけを閲覧できる署名付きプレビューリンクを発行します
+func (s *previewService) CreatePreviewToken(slug string, userID int) (*models.PreviewTokenResponse, error) {
+	article, err := findManageableArticle(s.articleRepo, s.userRepo, slug, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	jti, err := newTokenID()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	record := &models.ArticlePreviewToken{
+		ID:        jti,
+		ArticleID: article.ID,
+		CreatedBy: userID,
+		ExpiresAt: now.Add(s.ttl),
+	}
+	if err := s.repo.Create(record); err != nil {
+		return nil, err
+	}
+
+	claims := &models.PreviewClaims{
+		ArticleID: article.ID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			Audience:  jwt.ClaimStrings{previewTokenAudience},
+			ExpiresAt: jwt.NewNumericDate(record.ExpiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}
+	tokenString, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(s.secretKey))
+	if err != nil {
+		return nil, err
+	}
+
+	res := convertPreviewTokenToResponse(record)
+	res.Token = tokenString
+	res.PreviewPath = "/api/articles/" + url.PathEscape(article.Slug) + "?preview_token=" + url.QueryEscape(tokenString)
+	return &res, nil
+}
+
+// GetPreviewTokens は記事に発行したプレビューリンクの一覧を取得します（トークン本体は含みません）
+func (s *previewService) GetPreviewTokens(slug string, userID int) ([]models.PreviewTokenResponse, error) {
+	article, err := findManageableArticle(s.articleRepo, s.userRepo, slug, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := s.repo.FindByArticleID(article.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]models.PreviewTokenResponse, len(tokens))
+	for i := range tokens {
+		responses[i] = convertPreviewTokenToResponse(&tokens[i])
+	}
+	return responses, nil
+}
+
+// RevokePreviewToken はプレビューリンクを失効させます
+func (s *previewService) RevokePreviewToken(slug string, userID int, tokenID string) error {
+	article, err := findManageableArticle(s.articleRepo, s.userRepo, slug, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Revoke(tokenID, article.ID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrPreviewNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// VerifyPreviewToken はプレビュートークンの署名・有効期限・失効状態を検証し、対象の記事IDを返します
+func (s *previewService) VerifyPreviewToken(tokenString string) (int, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &models.PreviewClaims{}, func(token *jwt.Token) (interface{}, error) {
+		return []byte(s.secretKey), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithAudience(previewTokenAudience), jwt.WithExpirationRequired())
+	if err != nil {
+		return 0, ErrInvalidPreview
+	}
+
+	claims, ok := token.Claims.(*models.PreviewClaims)
+	if !ok || !token.Valid || claims.ArticleID == 0 || claims.ID == "" {
+		return 0, ErrInvalidPreview
+	}
+
+	// 失効済みのリンクは署名が正しくても無効
+	record, err := s.repo.FindByID(claims.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, ErrInvalidPreview
+		}
+		return 0, err
+	}
+	if record.RevokedAt != nil || record.ArticleID != claims.ArticleID {
+		return 0, ErrInvalidPreview
+	}
+
+	return claims.ArticleID, nil
+}
+
+func convertPreviewTokenToResponse(token *models.ArticlePreviewToken) models.PreviewTokenResponse {
+	return models.PreviewTokenResponse{
+		ID:        token.ID,
+		ExpiresAt: token.ExpiresAt,
+		RevokedAt: token.RevokedAt,
+		CreatedAt: token.CreatedAt,
+	}
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"github.com/yamada-mikiya/team1-hackathon/repositories"
+	"gorm.io/gorm"
+)
+
+// validReactions は記事に付けられるリアクションの絵文字
+// thumbs_up: 👍, heart: ❤️, tada: 🎉, laugh: 😄, bulb: 💡, eyes: 👀
+var validReactions = []string{"thumbs_up", "heart", "tada", "laugh", "bulb", "eyes"}
+
+type ReactionService interface {
+	AddReaction(slug string, userID int, emoji string) (*models.ReactionsResponse, error)
+	RemoveReaction(slug string, userID int, emoji string) (*models.ReactionsResponse, error)
+}
+
+type reactionService struct {
+	articleRepo repositories.ArticleRepository
+	repo        repositories.ReactionRepository
+	userRepo    repositories.UserRepository
+}
+
+func NewReactionService(articleRepo repositories.ArticleRepository, repo repositories.ReactionRepository, userRepo repositories.UserRepository) ReactionService {
+	return &reactionService{
+		articleRepo: articleRepo,
+		repo:        repo,
+		userRepo:    userRepo,
+	}
+}
+
+// AddReaction は記事にリアクションを追加します。同じ絵文字で既にリアクションしている場合は何もしません
+// メールアドレスを確認済みのメンバーが、閲覧できる公開中（internal・public）の記事にのみリアクションできます
+func (s *reactionService) AddReaction(slug string, userID int, emoji string) (*models.ReactionsResponse, error) {
+	article, err := s.findReactableArticle(slug, userID, emoji)
+	if err != nil {
+		return nil, err
+	}
+	if article.Status != "internal" && article.Status != "public" {
+		return nil, &ValidationError{Message: "公開中の記事にのみリアクションできます"}
+	}
+
+	counts, err := s.repo.Add(article.ID, userID, emoji)
+	if err != nil {
+		return nil, err
+	}
+	return s.reactionsResponse(article.ID, userID, counts)
+}
+
+// RemoveReaction は記事へのリアクションを取り消します。リアクションしていない場合は何もしません
+func (s *reactionService) RemoveReaction(slug string, userID int, emoji string) (*models.ReactionsResponse, error) {
+	article, err := s.findReactableArticle(slug, userID, emoji)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.repo.Remove(article.ID, userID, emoji)
+	if err != nil {
+		return nil, err
+	}
+	return s.reactionsResponse(article.ID, userID, counts)
+}
+
+// findReactableArticle は絵文字とユーザーを検証し、ユーザーが閲覧できる記事をslugで取得します
+func (s *reactionService) findReactableArticle(slug string, userID int, emoji string) (*models.Article, error) {
+	if !slices.Contains(validReactions, emoji) {
+		return nil, &ValidationError{Message: fmt.Sprintf("リアクションに不明な絵文字 %q が指定されています（%sのいずれか）", emoji, strings.Join(validReactions, ", "))}
+	}
+	if err := requireMember(s.userRepo, userID); err != nil {
+		return nil, err
+	}
+
+	article, err := s.articleRepo.FindBySlug(slug, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrArticleNotFound
+		}
+		return nil, err
+	}
+	return article, nil
+}
+
+func (s *reactionService) reactionsResponse(articleID, userID int, counts models.ReactionCounts) (*models.ReactionsResponse, error) {
+	emojis, err := s.repo.FindEmojisByUser(articleID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if emojis == nil {
+		emojis = []string{}
+	}
+	return &models.ReactionsResponse{
+		ReactionCounts: reactionCountsOf(counts),
+		MyReactions:    emojis,
+	}, nil
+}
+
+// reactionCountsOf は記事のリアクション数をレスポンス用に変換します（リアクションがない場合は空のオブジェクト）
+func reactionCountsOf(counts models.ReactionCounts) map[string]int {
+	if counts == nil {
+		return map[string]int{}
+	}
+	return counts
+}
+
+// reactionEmojisOf は閲覧者自身のリアクションの絵文字を返します
+func reactionEmojisOf(reactions []models.ArticleReaction) []string {
+	if len(reactions) == 0 {
+		return nil
+	}
+	emojis := make([]string, len(reactions))
+	for i, reaction := range reactions {
+		emojis[i] = reaction.Emoji
+	}
+	return emojis
+}
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/yamada-mikiya/team1-hackathon/repositories"
+)
+
+// 1回に読み込んでHTMLを作成する記事の数
+const renderBackfillBatchSize = 100
+
+// BackfillRenderedContent はHTMLなどのキャッシュがまだないMarkdown記事（キャッシュを導入する前に保存された記事）について、
+// 本文から作成して保存し、保存した記事の数を返します
+// 本文のHTMLの作成に失敗した記事はログに残して飛ばします（取得時にその場で作成を試みます）
+func BackfillRenderedContent(ctx context.Context, repo repositories.ArticleRepository) (int, error) {
+	rendered := 0
+	afterID := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return rendered, err
+		}
+		articles, err := repo.FindUnrendered(afterID, renderBackfillBatchSize)
+		if err != nil {
+			return rendered, err
+		}
+
+		for i := range articles {
+			article := &articles[i]
+			afterID = article.ID
+			if err := applyRenderedContent(article); err != nil {
+				slog.Warn("記事本文のHTMLの作成に失敗しました", "article_id", article.ID, "error", err)
+				continue
+			}
+			if err := repo.UpdateRenderedContent(article); err != nil {
+				return rendered, err
+			}
+			rendered++
+		}
+
+		if len(articles) < renderBackfillBatchSize {
+			return rendered, nil
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"github.com/yamada-mikiya/team1-hackathon/repositories"
+)
+
+// renderArticleRepository は本文のキャッシュの作成・保存に使うメソッドだけを実装したArticleRepository
+type renderArticleRepository struct {
+	repositories.ArticleRepository
+	articles []models.Article
+	saved    map[int]*models.Article
+}
+
+func (r *renderArticleRepository) FindUnrendered(afterID, limit int) ([]models.Article, error) {
+	var found []models.Article
+	for _, article := range r.articles {
+		if article.ID > afterID && needsRendering(&article) && r.saved[article.ID] == nil {
+			found = append(found, article)
+		}
+		if len(found) == limit {
+			break
+		}
+	}
+	return found, nil
+}
+
+func (r *renderArticleRepository) UpdateRenderedContent(article *models.Article) error {
+	r.saved[article.ID] = article
+	return nil
+}
+
+func (r *renderArticleRepository) FindBySlug(slug string, userID int) (*models.Article, error) {
+	for _, article := range r.articles {
+		if article.Slug == slug {
+			return &article, nil
+		}
+	}
+	return nil, fmt.Errorf("記事 %s がありません", slug)
+}
+
+func newRenderArticleRepository(n int) *renderArticleRepository {
+	repo := &renderArticleRepository{saved: map[int]*models.Article{}}
+	for i := 1; i <= n; i++ {
+		content := fmt.Sprintf("# 見出し%d\n\n本文", i)
+		repo.articles = append(repo.articles, models.Article{
+			ID:          i,
+			ArticleType: "markdown",
+			Content:     &content,
+			Slug:        fmt.Sprintf("article-%d", i),
+			Status:      "public",
+		})
+	}
+	return repo
+}
+
+func TestBackfillRenderedContent(t *testing.T) {
+	repo := newRenderArticleRepository(renderBackfillBatchSize + 5)
+	// 外部記事はキャッシュを作成しない
+	repo.articles = append(repo.articles, models.Article{ID: 1000, ArticleType: "external", Slug: "external"})
+
+	rendered, err := BackfillRenderedContent(context.Background(), repo)
+	if err != nil {
+		t.Fatalf("BackfillRenderedContent: %v", err)
+	}
+	if rendered != renderBackfillBatchSize+5 || len(repo.saved) != rendered {
+		t.Fatalf("rendered = %d, saved = %d, want %d", rendered, len(repo.saved), renderBackfillBatchSize+5)
+	}
+	article := repo.saved[1]
+	if article.ContentHTML == nil || len(article.TOC) != 1 || article.TOC[0].Text != "見出し1" {
+		t.Errorf("saved[1] = {content_html: %v, toc: %v}, want HTMLと目次", article.ContentHTML, article.TOC)
+	}
+	if repo.saved[1000] != nil {
+		t.Errorf("外部記事のキャッシュを保存しています")
+	}
+}
+
+func TestBackfillRenderedContentCanceled(t *testing.T) {
+	repo := newRenderArticleRepository(3)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := BackfillRenderedContent(ctx, repo); err != context.Canceled {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if len(repo.saved) != 0 {
+		t.Errorf("saved = %d, want 0", len(repo.saved))
+	}
+}
+
+func TestGetArticleBySlugDoesNotSaveRenderedContent(t *testing.T) {
+	repo := newRenderArticleRepository(1)
+	service := NewArticleService(repo, nil, nil, nil, nil)
+
+	res, err := service.GetArticleBySlug("article-1", 0)
+	if err != nil {
+		t.Fatalf("GetArticleBySlug: %v", err)
+	}
+	if res.ContentHTML == nil || res.ReadingTimeMinutes == nil {
+		t.Errorf("レスポンスにキャッシュがない記事のHTMLが含まれていません")
+	}
+	if len(repo.saved) != 0 {
+		t.Errorf("記事の取得時にキャッシュを保存しています")
+	}
+}
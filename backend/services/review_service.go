@@ -0,0 +1,290 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"github.com/yamada-mikiya/team1-hackathon/repositories"
+	"gorm.io/gorm"
+)
+
+const (
+	reviewStateApproved         = "approved"
+	reviewStateChangesRequested = "changes_requested"
+)
+
+type ReviewService interface {
+	SubmitForReview(ctx context.Context, slug string, userID int, req models.SubmitReviewRequest) (*models.ReviewStatusResponse, error)
+	GetReviewStatus(ctx context.Context, slug string, userID int) (*models.ReviewStatusResponse, error)
+	Approve(ctx context.Context, slug string, userID int, req models.ApproveReviewRequest) (*models.ReviewStatusResponse, error)
+	RequestChanges(ctx context.Context, slug string, userID int, comment string) (*models.ReviewStatusResponse, error)
+	AddComment(ctx context.Context, slug string, userID int, comment string) (*models.ReviewStatusResponse, error)
+}
+
+type reviewService struct {
+	articleRepo repositories.ArticleRepository
+	repo        repositories.ReviewRepository
+	userRepo    repositories.UserRepository
+}
+
+func NewReviewService(articleRepo repositories.ArticleRepository, repo repositories.ReviewRepository, userRepo repositories.UserRepository) ReviewService {
+	return &reviewService{
+		articleRepo: articleRepo,
+		repo:        repo,
+		userRepo:    userRepo,
+	}
+}
+
+// SubmitForReview は記事にレビュアーを割り当て、ステータスをin_reviewにします
+// 公開できるようにするため、記事の部署に所属するレビュアーを1人以上含める必要があります
+// 自分の依頼を自分で承認できないよう、著者とレビューを依頼するユーザー自身はレビュアーに指定できません
+func (s *reviewService) SubmitForReview(ctx context.Context, slug string, userID int, req models.SubmitReviewRequest) (*models.ReviewStatusResponse, error) {
+	article, err := findManageableArticle(s.articleRepo, s.userRepo, slug, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if article.Status == "public" {
+		return nil, &ValidationError{Message: "公開中の記事はレビューを依頼できません"}
+	}
+
+	reviewerIDs := make([]int, 0, len(req.ReviewerIDs))
+	for _, id := range req.ReviewerIDs {
+		if id == article.AuthorID {
+			return nil, &ValidationError{Message: "著者自身をレビュアーに指定することはできません"}
+		}
+		if id == userID {
+			return nil, &ValidationError{Message: "レビューを依頼するユーザー自身をレビュアーに指定することはできません"}
+		}
+		if !slices.Contains(reviewerIDs, id) {
+			reviewerIDs = append(reviewerIDs, id)
+		}
+	}
+	if len(reviewerIDs) == 0 {
+		return nil, &ValidationError{Message: "レビュアーを1人以上指定してください"}
+	}
+
+	reviewers, err := s.userRepo.GetUsersByIDs(ctx, reviewerIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(reviewers) != len(reviewerIDs) {
+		return nil, &ValidationError{Message: "存在しないユーザーがレビュアーに指定されています"}
+	}
+	hasDepartmentReviewer := slices.ContainsFunc(reviewers, func(u models.User) bool {
+		return u.Department != nil && *u.Department == article.Department
+	})
+	if !hasDepartmentReviewer {
+		return nil, &ValidationError{Message: "記事の部署（" + article.Department + "）に所属するレビュアーを1人以上指定してください"}
+	}
+
+	if err := s.repo.AssignReviewers(article.ID, reviewerIDs); err != nil {
+		return nil, err
+	}
+
+	if article.Status != "in_review" {
+		article.Status = "in_review"
+		if err := s.articleRepo.Update(article, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Comment != nil && strings.TrimSpace(*req.Comment) != "" {
+		if err := s.addComment(article.ID, userID, nil, *req.Comment); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.buildStatus(article)
+}
+
+// GetReviewStatus は記事のレビュー状況を取得します。著者・レビュアー・記事を管理できるユーザーのみ閲覧できます
+func (s *reviewService) GetReviewStatus(ctx context.Context, slug string, userID int) (*models.ReviewStatusResponse, error) {
+	article, _, err := s.findReviewableArticle(ctx, slug, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildStatus(article)
+}
+
+// Approve はレビュアーとして記事を承認します
+// 承認はレビュアーが確認したリビジョンに対して行い、その後に内容が更新されていた場合は承認できません
+func (s *reviewService) Approve(ctx context.Context, slug string, userID int, req models.ApproveReviewRequest) (*models.ReviewStatusResponse, error) {
+	if req.RevisionNumber <= 0 {
+		return nil, &ValidationError{Message: "確認したリビジョンの番号（revision_number）を指定してください"}
+	}
+
+	article, err := s.findArticleForReviewer(ctx, slug, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Approve(article.ID, userID, req.RevisionNumber); err != nil {
+		return nil, err
+	}
+
+	action := reviewStateApproved
+	comment := req.Comment
+	if strings.TrimSpace(comment) == "" {
+		comment = "承認しました"
+	}
+	if err := s.addComment(article.ID, userID, &action, comment); err != nil {
+		return nil, err
+	}
+
+	return s.buildStatus(article)
+}
+
+// RequestChanges はレビュアーとして修正を依頼し、記事を下書きに戻します
+func (s *reviewService) RequestChanges(ctx context.Context, slug string, userID int, comment string) (*models.ReviewStatusResponse, error) {
+	if strings.TrimSpace(comment) == "" {
+		return nil, &ValidationError{Message: "修正依頼にはコメントが必要です"}
+	}
+
+	article, err := s.findArticleForReviewer(ctx, slug, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateState(article.ID, userID, reviewStateChangesRequested); err != nil {
+		return nil, err
+	}
+
+	action := reviewStateChangesRequested
+	if err := s.addComment(article.ID, userID, &action, comment); err != nil {
+		return nil, err
+	}
+
+	article.Status = "draft"
+	if err := s.articleRepo.Update(article, userID); err != nil {
+		return nil, err
+	}
+
+	return s.buildStatus(article)
+}
+
+// AddComment は著者またはレビュアーとしてレビューコメントを追加します
+func (s *reviewService) AddComment(ctx context.Context, slug string, userID int, comment string) (*models.ReviewStatusResponse, error) {
+	if strings.TrimSpace(comment) == "" {
+		return nil, &ValidationError{Message: "コメントを入力してください"}
+	}
+
+	article, _, err := s.findReviewableArticle(ctx, slug, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.addComment(article.ID, userID, nil, comment); err != nil {
+		return nil, err
+	}
+
+	return s.buildStatus(article)
+}
+
+// findReviewableArticle は記事を取得し、ユーザーが著者・レビュアー・記事の管理者のいずれかであることを確認します
+func (s *reviewService) findReviewableArticle(ctx context.Context, slug string, userID int) (*models.Article, bool, error) {
+	article, err := s.articleRepo.FindBySlugAnyStatus(slug)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, false, ErrArticleNotFound
+		}
+		return nil, false, err
+	}
+	article.Author = nil
+
+	isReviewer, err := s.repo.IsReviewer(article.ID, userID)
+	if err != nil {
+		return nil, false, err
+	}
+	if article.AuthorID != userID && !isReviewer {
+		// 著者・レビュアー以外でも記事を管理できるユーザー（管理者・部署の編集者）は参加できる
+		user, err := s.userRepo.GetUserByID(ctx, userID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, false, err
+		}
+		if user == nil || !canManageArticle(user, article) {
+			return nil, false, ErrNotReviewer
+		}
+	}
+	return article, isReviewer, nil
+}
+
+// findArticleForReviewer はレビュー中の記事を取得し、ユーザーがそのレビュアーであることを確認します
+func (s *reviewService) findArticleForReviewer(ctx context.Context, slug string, userID int) (*models.Article, error) {
+	article, isReviewer, err := s.findReviewableArticle(ctx, slug, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isReviewer {
+		return nil, ErrNotReviewer
+	}
+	if article.Status != "in_review" {
+		return nil, ErrInvalidReviewState
+	}
+	return article, nil
+}
+
+func (s *reviewService) addComment(articleID, userID int, action *string, body string) error {
+	return s.repo.CreateComment(&models.ArticleReviewComment{
+		ArticleID: articleID,
+		UserID:    userID,
+		Action:    action,
+		Body:      strings.TrimSpace(body),
+	})
+}
+
+// buildStatus はレビュアーとコメントをまとめたレビュー状況を組み立てます
+func (s *reviewService) buildStatus(article *models.Article) (*models.ReviewStatusResponse, error) {
+	reviews, err := s.repo.FindByArticleID(article.ID)
+	if err != nil {
+		return nil, err
+	}
+	comments, err := s.repo.FindComments(article.ID)
+	if err != nil {
+		return nil, err
+	}
+	canPublish, err := s.repo.HasDepartmentApproval(article.ID, article.Department)
+	if err != nil {
+		return nil, err
+	}
+	revisionNumber, err := s.repo.LatestRevisionNumber(article.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &models.ReviewStatusResponse{
+		ArticleStatus:  article.Status,
+		Department:     article.Department,
+		CanPublish:     canPublish,
+		RevisionNumber: revisionNumber,
+		Reviewers:      make([]models.ReviewerResponse, 0, len(reviews)),
+		Comments:       make([]models.ReviewCommentResponse, 0, len(comments)),
+	}
+	for _, review := range reviews {
+		reviewer := models.ReviewerResponse{
+			State:     review.State,
+			UpdatedAt: review.UpdatedAt,
+		}
+		if review.Reviewer != nil {
+			reviewer.Reviewer = convertUserToAuthorResponse(review.Reviewer)
+			reviewer.Department = review.Reviewer.Department
+		}
+		res.Reviewers = append(res.Reviewers, reviewer)
+	}
+	for _, comment := range comments {
+		c := models.ReviewCommentResponse{
+			ID:        comment.ID,
+			Action:    comment.Action,
+			Body:      comment.Body,
+			CreatedAt: comment.CreatedAt,
+		}
+		if comment.User != nil {
+			c.User = convertUserToAuthorResponse(comment.User)
+		}
+		res.Comments = append(res.Comments, c)
+	}
+	return res, nil
+}
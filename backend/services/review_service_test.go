@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"github.com/yamada-mikiya/team1-hackathon/repositories"
+	"gorm.io/gorm"
+)
+
+// reviewUserRepository はユーザーの取得だけを実装したUserRepository
+type reviewUserRepository struct {
+	repositories.UserRepository
+	users map[int]*models.User
+}
+
+func (r *reviewUserRepository) GetUserByID(ctx context.Context, userID int) (*models.User, error) {
+	user, ok := r.users[userID]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return user, nil
+}
+
+// reviewArticleRepository は記事の取得だけを実装したArticleRepository
+type reviewArticleRepository struct {
+	repositories.ArticleRepository
+	article *models.Article
+}
+
+func (r *reviewArticleRepository) FindBySlugAnyStatus(slug string) (*models.Article, error) {
+	if r.article.Slug != slug {
+		return nil, gorm.ErrRecordNotFound
+	}
+	article := *r.article
+	return &article, nil
+}
+
+func TestCheckStatusTransitionPublicArticle(t *testing.T) {
+	s := &articleService{}
+	content := "本文"
+	edited := "レビューしていない本文"
+	public := models.Article{ID: 1, ArticleType: "markdown", Title: "タイトル", Content: &content, Slug: "article", Department: "Dev", Status: "public", Tags: []models.Tag{{Name: "Go"}, {Name: "API"}}}
+
+	tests := []struct {
+		name   string
+		modify func(a *models.Article)
+		want   error
+	}{
+		{"本文の変更", func(a *models.Article) { a.Content = &edited }, ErrReviewApprovalRequired},
+		{"タイトルの変更", func(a *models.Article) { a.Title = "別のタイトル" }, ErrReviewApprovalRequired},
+		{"部署の変更", func(a *models.Article) { a.Department = "MKT" }, ErrReviewApprovalRequired},
+		{"サムネイルの変更", func(a *models.Article) { thumbnail := "https://example.com/a.png"; a.ThumbnailURL = &thumbnail }, ErrReviewApprovalRequired},
+		{"記事の種類の変更", func(a *models.Article) { a.ArticleType = "external" }, ErrReviewApprovalRequired},
+		{"スラグの変更", func(a *models.Article) { a.Slug = "renamed" }, ErrReviewApprovalRequired},
+		{"予約公開の設定のみの変更", func(a *models.Article) { publishAt := time.Now(); a.PublishAt = &publishAt }, nil},
+		{"公開を取り下げて本文を変更", func(a *models.Article) { a.Status = "draft"; a.Content = &edited }, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			after := public
+			tt.modify(&after)
+			if err := s.checkStatusTransition(&public, &after, []string{"API", "Go"}); !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	// タグも公開ページに表示されるため、レビューを経ずに変更できない
+	if err := s.checkStatusTransition(&public, &public, []string{"Go", "Echo"}); !errors.Is(err, ErrReviewApprovalRequired) {
+		t.Errorf("タグの変更: err = %v, want %v", err, ErrReviewApprovalRequired)
+	}
+}
+
+func TestSubmitForReviewRejectsSelfAssignment(t *testing.T) {
+	now := time.Now()
+	dev := "Dev"
+	article := &models.Article{ID: 1, AuthorID: 1, Slug: "article", Department: dev, Status: "draft"}
+	editor := &models.User{ID: 2, Role: models.RoleEditor, Department: &dev, EmailVerifiedAt: &now}
+	service := NewReviewService(
+		&reviewArticleRepository{article: article},
+		nil,
+		&reviewUserRepository{users: map[int]*models.User{2: editor}},
+	)
+
+	// 記事を管理できる部署の編集者が、自分をレビュアーにして承認できないようにする
+	_, err := service.SubmitForReview(context.Background(), "article", editor.ID, models.SubmitReviewRequest{ReviewerIDs: []int{editor.ID}})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("err = %v, want *ValidationError", err)
+	}
+}
+
+func TestApproveRequiresRevisionNumber(t *testing.T) {
+	service := NewReviewService(nil, nil, nil)
+
+	// 確認したリビジョンを指定しない承認は、記事を取得する前に拒否する
+	_, err := service.Approve(context.Background(), "article", 2, models.ApproveReviewRequest{Comment: "問題ありません"})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("err = %v, want *ValidationError", err)
+	}
+}
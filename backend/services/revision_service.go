@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"github.com/yamada-mikiya/team1-hackathon/repositories"
+	"gorm.io/gorm"
+)
+
+// 差分計算の対象にできる行数の上限（両リビジョンの合計）
+// 差分の計算時間は行数と変更された行数の積に比例するため、大きすぎる本文は比較しない
+const maxDiffLines = 20000
+
+type RevisionService interface {
+	GetRevisions(slug string, userID int) ([]models.RevisionResponse, error)
+	GetRevision(slug string, userID int, revisionNumber int) (*models.RevisionResponse, error)
+	DiffRevisions(slug string, userID int, from, to int) (*models.RevisionDiffResponse, error)
+	RestoreRevision(ctx context.Context, slug string, userID int, revisionNumber int) (*models.ArticleResponse, error)
+}
+
+type revisionService struct {
+	articleRepo    repositories.ArticleRepository
+	userRepo       repositories.UserRepository
+	repo           repositories.RevisionRepository
+	articleService ArticleService
+}
+
+func NewRevisionService(articleRepo repositories.ArticleRepository, userRepo repositories.UserRepository, repo repositories.RevisionRepository, articleService ArticleService) RevisionService {
+	return &revisionService{
+		articleRepo:    articleRepo,
+		userRepo:       userRepo,
+		repo:           repo,
+		articleService: articleService,
+	}
+}
+
+// GetRevisions は記事のリビジョン一覧を新しい順に取得します
+func (s *revisionService) GetRevisions(slug string, userID int) ([]models.RevisionResponse, error) {
+	article, err := findManageableArticle(s.articleRepo, s.userRepo, slug, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.repo.FindByArticleID(article.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]models.RevisionResponse, len(revisions))
+	for i := range revisions {
+		responses[i] = convertRevisionToResponse(&revisions[i])
+		responses[i].Content = nil
+	}
+	return responses, nil
+}
+
+// GetRevision は指定した番号のリビジョンを本文付きで取得します
+func (s *revisionService) GetRevision(slug string, userID int, revisionNumber int) (*models.RevisionResponse, error) {
+	article, err := findManageableArticle(s.articleRepo, s.userRepo, slug, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	revision, err := s.findRevision(article.ID, revisionNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	res := convertRevisionToResponse(revision)
+	return &res, nil
+}
+
+// DiffRevisions は2つのリビジョンの本文を行単位で比較します
+func (s *revisionService) DiffRevisions(slug string, userID int, from, to int) (*models.RevisionDiffResponse, error) {
+	article, err := findManageableArticle(s.articleRepo, s.userRepo, slug, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	fromRevision, err := s.findRevision(article.ID, from)
+	if err != nil {
+		return nil, err
+	}
+	toRevision, err := s.findRevision(article.ID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	oldText := derefString(fromRevision.Content)
+	newText := derefString(toRevision.Content)
+	if len(splitLines(oldText))+len(splitLines(newText)) > maxDiffLines {
+		return nil, &ValidationError{Message: "本文が大きすぎるため差分を表示できません"}
+	}
+
+	return &models.RevisionDiffResponse{
+		From:      fromRevision.RevisionNumber,
+		To:        toRevision.RevisionNumber,
+		FromTitle: fromRevision.Title,
+		ToTitle:   toRevision.Title,
+		Lines:     diffLines(oldText, newText),
+	}, nil
+}
+
+// RestoreRevision は過去のリビジョンのタイトルと本文を、新しいリビジョンとして記事に書き戻します
+// 公開範囲が意図せず変わらないよう、ステータスは現在の値を維持します
+func (s *revisionService) RestoreRevision(ctx context.Context, slug string, userID int, revisionNumber int) (*models.ArticleResponse, error) {
+	article, err := findManageableArticle(s.articleRepo, s.userRepo, slug, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	revision, err := s.findRevision(article.ID, revisionNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.articleService.PatchArticle(ctx, slug, userID, models.PatchArticleRequest{
+		Title:   &revision.Title,
+		Content: revision.Content,
+	})
+}
+
+func (s *revisionService) findRevision(articleID, revisionNumber int) (*models.ArticleRevision, error) {
+	revision, err := s.repo.FindByNumber(articleID, revisionNumber)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRevisionNotFound
+		}
+		return nil, err
+	}
+	return revision, nil
+}
+
+func convertRevisionToResponse(revision *models.ArticleRevision) models.RevisionResponse {
+	res := models.RevisionResponse{
+		RevisionNumber: revision.RevisionNumber,
+		Title:          revision.Title,
+		Content:        revision.Content,
+		Status:         revision.Status,
+		CreatedAt:      revision.CreatedAt,
+	}
+	if revision.Editor != nil {
+		editor := convertUserToAuthorResponse(revision.Editor)
+		res.Editor = &editor
+	}
+	return res
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
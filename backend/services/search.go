@@ -0,0 +1,108 @@
+package services
+
+import (
+	"html"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	maxSearchQueryLength = 100
+	maxSearchKeywords    = 5
+	snippetLeadingRunes  = 40
+	snippetLengthRunes   = 120
+)
+
+// parseSearchQuery は検索文字列を空白（全角スペースを含む）で区切ってキーワードに分割します
+func parseSearchQuery(q string) ([]string, error) {
+	if utf8.RuneCountInString(q) > maxSearchQueryLength {
+		return nil, &ValidationError{Message: "検索キーワードは100文字以内で指定してください"}
+	}
+
+	keywords := make([]string, 0, maxSearchKeywords)
+	for _, keyword := range strings.Fields(q) {
+		if slices.Contains(keywords, keyword) {
+			continue
+		}
+		if len(keywords) == maxSearchKeywords {
+			return nil, &ValidationError{Message: "検索キーワードは5個まで指定できます"}
+		}
+		keywords = append(keywords, keyword)
+	}
+	return keywords, nil
+}
+
+// buildSnippet は本文からキーワード周辺を切り出し、一致箇所を<mark>で囲んだHTMLを返します
+// 日本語は単語区切りがないため、単語単位ではなく文字（rune）単位で切り出します
+func buildSnippet(content string, keywords []string) string {
+	text := []rune(strings.Join(strings.Fields(content), " "))
+	if len(text) == 0 {
+		return ""
+	}
+
+	lowerText := toLowerRunes(text)
+	lowerKeywords := make([][]rune, len(keywords))
+	for i, keyword := range keywords {
+		lowerKeywords[i] = toLowerRunes([]rune(keyword))
+	}
+
+	// 最初に一致した位置の少し前から切り出す
+	start := 0
+	if pos := firstMatch(lowerText, lowerKeywords); pos >= 0 {
+		start = max(0, pos-snippetLeadingRunes)
+	}
+	end := min(len(text), start+snippetLengthRunes)
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	for i := start; i < end; {
+		if n := matchLength(lowerText[i:], lowerKeywords); n > 0 {
+			n = min(n, end-i)
+			b.WriteString("<mark>")
+			b.WriteString(html.EscapeString(string(text[i : i+n])))
+			b.WriteString("</mark>")
+			i += n
+			continue
+		}
+		b.WriteString(html.EscapeString(string(text[i])))
+		i++
+	}
+	if end < len(text) {
+		b.WriteString("…")
+	}
+	return b.String()
+}
+
+// firstMatch はいずれかのキーワードが最初に現れる位置を返します。見つからなければ-1
+func firstMatch(text []rune, keywords [][]rune) int {
+	for i := range text {
+		if matchLength(text[i:], keywords) > 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// matchLength はtextの先頭に一致する最も長いキーワードの長さを返します
+func matchLength(text []rune, keywords [][]rune) int {
+	longest := 0
+	for _, keyword := range keywords {
+		if len(keyword) > longest && len(keyword) <= len(text) && slices.Equal(text[:len(keyword)], keyword) {
+			longest = len(keyword)
+		}
+	}
+	return longest
+}
+
+// toLowerRunes は文字数を変えずに小文字化します（位置をそのまま元の文字列に対応させるため）
+func toLowerRunes(runes []rune) []rune {
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+	return lower
+}
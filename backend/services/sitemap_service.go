@@ -0,0 +1,144 @@
+package services
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/yamada-mikiya/team1-hackathon/repositories"
+)
+
+// sitemapMaxURLs は1つのサイトマップに載せられるURLの上限（sitemaps.orgの仕様）
+// 公開記事がこれを超える場合、sitemap.xmlはサイトマップインデックスになり、記事は/sitemaps/<n>.xmlに分割します
+const sitemapMaxURLs = 50000
+
+const sitemapNamespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// defaultRobotsDisallow はrobots.txtで巡回を禁止するパスの既定値
+var defaultRobotsDisallow = []string{"/api/", "/swagger/"}
+
+type SitemapService interface {
+	GetSitemap() ([]byte, error)
+	GetSitemapPage(page int) ([]byte, error)
+	GetRobots() string
+}
+
+type sitemapService struct {
+	repo        repositories.ArticleRepository
+	siteURL     string
+	baseURL     string
+	disallow    []string
+	disallowAll bool
+}
+
+// NewSitemapService はsitemap.xmlとrobots.txtを生成するサービスを返します
+// siteURLは記事ページ（フロントエンド）のURL、baseURLはsitemap.xmlを配信するこのAPIサーバーのURLです
+func NewSitemapService(repo repositories.ArticleRepository, siteURL, baseURL string, disallow []string, disallowAll bool) SitemapService {
+	if siteURL == "" {
+		siteURL = defaultSiteURL
+	}
+	if disallow == nil {
+		disallow = defaultRobotsDisallow
+	}
+	return &sitemapService{
+		repo:        repo,
+		siteURL:     strings.TrimSuffix(siteURL, "/"),
+		baseURL:     strings.TrimSuffix(baseURL, "/"),
+		disallow:    disallow,
+		disallowAll: disallowAll,
+	}
+}
+
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name       `xml:"sitemapindex"`
+	Xmlns    string         `xml:"xmlns,attr"`
+	Sitemaps []sitemapEntry `xml:"sitemap"`
+}
+
+type sitemapEntry struct {
+	Loc string `xml:"loc"`
+}
+
+// GetSitemap はsitemap.xmlを生成します
+// 公開記事が上限以下であれば全記事のurlsetを、上限を超える場合は分割したサイトマップのインデックスを返します
+func (s *sitemapService) GetSitemap() ([]byte, error) {
+	count, err := s.repo.CountPublic()
+	if err != nil {
+		return nil, err
+	}
+	if count <= int64(sitemapMaxURLs) {
+		return s.GetSitemapPage(1)
+	}
+
+	pages := int((count + int64(sitemapMaxURLs) - 1) / int64(sitemapMaxURLs))
+	index := sitemapIndex{Xmlns: sitemapNamespace, Sitemaps: make([]sitemapEntry, pages)}
+	for i := range pages {
+		index.Sitemaps[i] = sitemapEntry{Loc: fmt.Sprintf("%s/sitemaps/%d.xml", s.baseURL, i+1)}
+	}
+	return marshalSitemap(index)
+}
+
+// GetSitemapPage は公開記事をID順に上限件数ずつ区切ったpage番目（1始まり）のサイトマップを生成します
+func (s *sitemapService) GetSitemapPage(page int) ([]byte, error) {
+	if page < 1 {
+		return nil, ErrSitemapNotFound
+	}
+
+	articles, err := s.repo.FindPublicSitemapEntries((page-1)*sitemapMaxURLs, sitemapMaxURLs)
+	if err != nil {
+		return nil, err
+	}
+	// 1ページ目は記事が0件でも空のurlsetを返す
+	if len(articles) == 0 && page > 1 {
+		return nil, ErrSitemapNotFound
+	}
+
+	urlSet := sitemapURLSet{Xmlns: sitemapNamespace, URLs: make([]sitemapURL, len(articles))}
+	for i, article := range articles {
+		urlSet.URLs[i] = sitemapURL{
+			Loc:     s.siteURL + articlePagePath + url.PathEscape(article.Slug),
+			LastMod: article.UpdatedAt.UTC().Format(time.RFC3339),
+		}
+	}
+	return marshalSitemap(urlSet)
+}
+
+// GetRobots はrobots.txtを生成します
+func (s *sitemapService) GetRobots() string {
+	var b strings.Builder
+	b.WriteString("User-agent: *\n")
+	if s.disallowAll {
+		b.WriteString("Disallow: /\n")
+		return b.String()
+	}
+	if len(s.disallow) == 0 {
+		// 空のDisallowはすべてのパスの巡回を許可する
+		b.WriteString("Disallow:\n")
+	}
+	for _, path := range s.disallow {
+		fmt.Fprintf(&b, "Disallow: %s\n", path)
+	}
+	fmt.Fprintf(&b, "\nSitemap: %s/sitemap.xml\n", s.baseURL)
+	return b.String()
+}
+
+func marshalSitemap(v any) ([]byte, error) {
+	body, err := xml.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
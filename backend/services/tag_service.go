@@ -0,0 +1,55 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"github.com/yamada-mikiya/team1-hackathon/repositories"
+	"gorm.io/gorm"
+)
+
+type TagService interface {
+	GetTags(isCategory bool, viewerID int) ([]models.TagResponse, error)
+	GetTagByName(name string) (*models.Tag, error)
+}
+
+type tagService struct {
+	repo repositories.TagRepository
+}
+
+func NewTagService(repo repositories.TagRepository) TagService {
+	return &tagService{repo: repo}
+}
+
+// GetTags はタグまたはカテゴリの一覧を、閲覧者が見られる記事数付きで取得します
+func (s *tagService) GetTags(isCategory bool, viewerID int) ([]models.TagResponse, error) {
+	tags, err := s.repo.FindAllWithArticleCount(isCategory, repositories.ArticleFilters{
+		ViewerID: viewerID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]models.TagResponse, len(tags))
+	for i, tag := range tags {
+		responses[i] = models.TagResponse{
+			ID:           tag.ID,
+			Name:         tag.Name,
+			IsCategory:   tag.IsCategory,
+			ArticleCount: int(tag.ArticleCount),
+		}
+	}
+	return responses, nil
+}
+
+// GetTagByName は名前を指定してタグを取得します
+func (s *tagService) GetTagByName(name string) (*models.Tag, error) {
+	tag, err := s.repo.FindByName(name)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTagNotFound
+		}
+		return nil, err
+	}
+	return tag, nil
+}
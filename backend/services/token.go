@@ -0,0 +1,33 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// newTokenID はjtiとして使うランダムなIDを生成します
+func newTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// newOpaqueToken はリフレッシュトークン等に使う推測できないランダムな文字列を生成します
+func newOpaqueToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken はデータベースに保存するためのトークンのハッシュ（SHA-256の16進数）を返します
+// トークンは十分なエントロピーを持つため、パスワードと違いソルトやストレッチングは不要です
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
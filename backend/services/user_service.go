@@ -0,0 +1,261 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/yamada-mikiya/team1-hackathon/models"
+	"github.com/yamada-mikiya/team1-hackathon/repositories"
+	"github.com/yamada-mikiya/team1-hackathon/storage"
+	"gorm.io/gorm"
+)
+
+const (
+	// アイコン画像として受け付けるファイルサイズの上限
+	MaxIconSize = 5 << 20
+	// アイコン画像のサムネイルの一辺のピクセル数
+	iconSize = 256
+	// 名前・所属の最大文字数（usersテーブルのVARCHAR(255)に合わせる）
+	maxProfileFieldLength = 255
+	// 公開プロフィールに載せる最近の記事の件数
+	profileRecentArticles = 5
+)
+
+type UserService interface {
+	GetUsers(ctx context.Context, actorID int, page, limit int) (*models.UserListResponse, error)
+	UpdateUserRole(ctx context.Context, actorID int, userID int, req models.UpdateUserRoleRequest) (*models.UserResponse, error)
+	UpdateProfile(ctx context.Context, userID int, req models.UpdateProfileRequest) (*models.UserResponse, error)
+	UploadIcon(ctx context.Context, userID int, file io.Reader) (*models.UserResponse, error)
+	GetUserProfile(ctx context.Context, userID int, viewerID int) (*models.UserProfileResponse, error)
+}
+
+type userService struct {
+	repo           repositories.UserRepository
+	store          storage.BlobStore
+	articleService ArticleService
+}
+
+func NewUserService(repo repositories.UserRepository, store storage.BlobStore, articleService ArticleService) UserService {
+	return &userService{repo: repo, store: store, articleService: articleService}
+}
+
+// GetUsers はユーザー一覧を取得します（管理者のみ）
+func (s *userService) GetUsers(ctx context.Context, actorID int, page, limit int) (*models.UserListResponse, error) {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	users, totalCount, err := s.repo.FindAll(ctx, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	userResponses := make([]models.UserResponse, len(users))
+	for i := range users {
+		userResponses[i] = convertUserToUserResponse(&users[i])
+	}
+
+	totalPages := int((totalCount + int64(limit) - 1) / int64(limit))
+
+	return &models.UserListResponse{
+		Users:      userResponses,
+		TotalCount: int(totalCount),
+		Page:       page,
+		Limit:      limit,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// UpdateUserRole はユーザーのロールと所属部署を変更します（管理者のみ）
+// 管理者が不在になるのを防ぐため、自分自身のロールは変更できません
+func (s *userService) UpdateUserRole(ctx context.Context, actorID int, userID int, req models.UpdateUserRoleRequest) (*models.UserResponse, error) {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	if req.Role != nil {
+		if !slices.Contains(validRoles, *req.Role) {
+			return nil, &ValidationError{Message: "roleはadmin, editor, writer, readerのいずれかを指定してください"}
+		}
+		if userID == actorID && *req.Role != user.Role {
+			return nil, &ValidationError{Message: "自分自身のロールは変更できません"}
+		}
+		user.Role = *req.Role
+	}
+	if req.Department != nil {
+		if *req.Department == "" {
+			user.Department = nil
+		} else if !slices.Contains(validDepartments, *req.Department) {
+			return nil, &ValidationError{Message: "departmentはDev, MKT, Opsのいずれかを指定してください"}
+		} else {
+			user.Department = req.Department
+		}
+	}
+
+	if err := s.repo.UpdateRole(ctx, user.ID, user.Role, user.Department); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	res := convertUserToUserResponse(user)
+	return &res, nil
+}
+
+// UpdateProfile は自分の名前と所属を更新します
+// affiliationに空文字を指定すると所属を削除します
+func (s *userService) UpdateProfile(ctx context.Context, userID int, req models.UpdateProfileRequest) (*models.UserResponse, error) {
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, &ValidationError{Message: "nameは必須です"}
+		}
+		if utf8.RuneCountInString(name) > maxProfileFieldLength {
+			return nil, &ValidationError{Message: fmt.Sprintf("nameは%d文字以内で指定してください", maxProfileFieldLength)}
+		}
+		user.Name = name
+	}
+	if req.Affiliation != nil {
+		affiliation := strings.TrimSpace(*req.Affiliation)
+		if utf8.RuneCountInString(affiliation) > maxProfileFieldLength {
+			return nil, &ValidationError{Message: fmt.Sprintf("affiliationは%d文字以内で指定してください", maxProfileFieldLength)}
+		}
+		if affiliation == "" {
+			user.Affiliation = nil
+		} else {
+			user.Affiliation = &affiliation
+		}
+	}
+
+	if err := s.repo.UpdateProfile(ctx, user.ID, user.Name, user.Affiliation); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	res := convertUserToUserResponse(user)
+	return &res, nil
+}
+
+// UploadIcon はアップロードされた画像を正方形のサムネイルに変換してアイコンとして保存します
+// キャッシュされた古い画像が表示され続けないよう、アップロードのたびに新しいキーで保存し、古いアイコンは削除します
+func (s *userService) UploadIcon(ctx context.Context, userID int, file io.Reader) (*models.UserResponse, error) {
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	img, contentType, err := readImage(file, MaxIconSize)
+	if err != nil {
+		return nil, err
+	}
+	data, contentType, ext, err := encodeImage(squareThumbnail(img, iconSize), contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := newTokenID()
+	if err != nil {
+		return nil, err
+	}
+	key := fmt.Sprintf("icons/%d/%s%s", user.ID, name, ext)
+	iconURL, err := s.store.Put(ctx, key, bytes.NewReader(data), contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateIconURL(ctx, user.ID, iconURL); err != nil {
+		if deleteErr := s.store.Delete(ctx, key); deleteErr != nil {
+			slog.Warn("保存に失敗したアイコン画像の削除に失敗しました", "key", key, "error", deleteErr)
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	if user.IconURL != nil {
+		if oldKey, ok := s.store.KeyFromURL(*user.IconURL); ok {
+			if err := s.store.Delete(ctx, oldKey); err != nil {
+				slog.Warn("古いアイコン画像の削除に失敗しました", "key", oldKey, "error", err)
+			}
+		}
+	}
+
+	user.IconURL = &iconURL
+	res := convertUserToUserResponse(user)
+	return &res, nil
+}
+
+// GetUserProfile はユーザーの公開プロフィールと最近の記事を取得します
+// 記事の閲覧ルールは記事一覧と同じです（viewerIDには閲覧者のユーザーID、ゲストの場合は0を指定します）
+func (s *userService) GetUserProfile(ctx context.Context, userID int, viewerID int) (*models.UserProfileResponse, error) {
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	articles, err := s.articleService.GetArticles(repositories.ArticleFilters{
+		AuthorID: user.ID,
+		ViewerID: viewerID,
+	}, 1, profileRecentArticles)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.UserProfileResponse{
+		ID:             user.ID,
+		Name:           user.Name,
+		Affiliation:    user.Affiliation,
+		Department:     user.Department,
+		IconURL:        user.IconURL,
+		ArticleCount:   *articles.TotalCount,
+		RecentArticles: articles.Articles,
+	}, nil
+}
+
+// requireAdmin はユーザーが管理者であることをデータベースの値で確認します
+func (s *userService) requireAdmin(ctx context.Context, userID int) error {
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrPermissionDenied
+		}
+		return err
+	}
+	if user.Role != models.RoleAdmin {
+		return ErrPermissionDenied
+	}
+	return nil
+}
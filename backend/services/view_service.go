@@ -0,0 +1,56 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+
+	"github.com/yamada-mikiya/team1-hackathon/models"
+)
+
+// ViewRecorder は記事の閲覧を記録します（workers.ViewCounterが実装します）
+// viewerKeyが同じ閲覧は一定期間内であれば1回と数えます
+type ViewRecorder interface {
+	Record(articleID int, viewerKey string)
+}
+
+type ViewService interface {
+	RecordView(article *models.ArticleResponse, viewerID int, ip, userAgent string)
+}
+
+type viewService struct {
+	recorder ViewRecorder
+}
+
+// NewViewService は記事の閲覧を数えるサービスを作成します
+// recorderがnilの場合は閲覧を記録しません
+func NewViewService(recorder ViewRecorder) ViewService {
+	return &viewService{recorder: recorder}
+}
+
+// RecordView は閲覧者が記事の詳細を表示したことを記録します
+// 公開中（internal・public）の記事のみを数え、著者本人の閲覧は数えません
+// ログインユーザーはユーザーID、ゲストはIPアドレスとUser-Agentで同じ閲覧者かを判定します
+func (s *viewService) RecordView(article *models.ArticleResponse, viewerID int, ip, userAgent string) {
+	if s.recorder == nil {
+		return
+	}
+	if article.Status != "internal" && article.Status != "public" {
+		return
+	}
+	if viewerID != 0 && viewerID == article.Author.ID {
+		return
+	}
+
+	s.recorder.Record(article.ID, viewerKey(viewerID, ip, userAgent))
+}
+
+// viewerKey は閲覧の重複を判定するための閲覧者のキーを返します
+// ゲストのIPアドレスとUser-Agentはメモリ上でもそのまま持たないよう、ハッシュにします
+func viewerKey(viewerID int, ip, userAgent string) string {
+	if viewerID != 0 {
+		return "user:" + strconv.Itoa(viewerID)
+	}
+	sum := sha256.Sum256([]byte(ip + "\x00" + userAgent))
+	return "guest:" + hex.EncodeToString(sum[:16])
+}
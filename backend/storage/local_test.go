@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLocalStore(t *testing.T) {
+	dir := t.TempDir()
+	store := NewLocalStore(filepath.Join(dir, "uploads"), "")
+	ctx := context.Background()
+
+	url, err := store.Put(ctx, "images/2026/01/a.png", strings.NewReader("png"), "image/png")
+	if err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if url != "/media/images/2026/01/a.png" {
+		t.Errorf("Put: url = %q, want /media/images/2026/01/a.png", url)
+	}
+	key, ok := store.KeyFromURL(url)
+	if !ok || key != "images/2026/01/a.png" {
+		t.Errorf("KeyFromURL(%q) = %q, %v, want images/2026/01/a.png, true", url, key, ok)
+	}
+
+	obj, err := store.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	body, err := io.ReadAll(obj.Body)
+	obj.Body.Close()
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if string(body) != "png" || obj.Size != 3 || obj.ContentType != "image/png" {
+		t.Errorf("Get: body = %q, size = %d, content type = %q, want png, 3, image/png", body, obj.Size, obj.ContentType)
+	}
+	// 書き込み途中の一時ファイルは残らない
+	entries, err := os.ReadDir(filepath.Join(dir, "uploads", "images", "2026", "01"))
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("保存先のファイル = %d個, want 1個", len(entries))
+	}
+
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := store.Get(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Errorf("削除後のGet: err = %v, want ErrNotFound", err)
+	}
+	// 存在しないファイルの削除は何もしない
+	if err := store.Delete(ctx, key); err != nil {
+		t.Errorf("存在しないファイルのDelete: %v", err)
+	}
+	// ディレクトリはファイルとして返さない
+	if _, err := store.Get(ctx, "images"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("ディレクトリのGet: err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestLocalStoreRejectsKeysOutsideDir(t *testing.T) {
+	dir := t.TempDir()
+	store := NewLocalStore(filepath.Join(dir, "uploads"), "")
+	ctx := context.Background()
+
+	for _, key := range []string{"../outside.txt", "a/../../outside.txt", `..\outside.txt`, ""} {
+		if _, err := store.Put(ctx, key, strings.NewReader("x"), "text/plain"); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Put(%q): err = %v, want ErrInvalidKey", key, err)
+		}
+		if _, err := store.Get(ctx, key); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Get(%q): err = %v, want ErrInvalidKey", key, err)
+		}
+		if err := store.Delete(ctx, key); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Delete(%q): err = %v, want ErrInvalidKey", key, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "outside.txt")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("保存先の外にファイルが作成されています: %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/yamada-mikiya/team1-hackathon/config"
+)
+
+// S3Store はファイルをS3互換のオブジェクトストレージ（AWS S3、MinIOなど）に保存します
+type S3Store struct {
+	publicURLs
+	client *minio.Client
+	bucket string
+}
+
+func NewS3Store(cfg config.S3Config, baseURL string) (*S3Store, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" {
+		return nil, errors.New("storage.s3のendpointとbucketを設定してください")
+	}
+
+	lookup := minio.BucketLookupAuto
+	if cfg.UsePathStyle {
+		lookup = minio.BucketLookupPath
+	}
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:        credentials.NewStaticV4(cfg.AccessKeyID, cfg.SecretAccessKey, ""),
+		Secure:       cfg.UseSSL,
+		Region:       cfg.Region,
+		BucketLookup: lookup,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &S3Store{publicURLs: newPublicURLs(baseURL), client: client, bucket: cfg.Bucket}, nil
+}
+
+// Put はbodyをバケットのkeyにアップロードします
+func (s *S3Store) Put(ctx context.Context, key string, body io.Reader, contentType string) (string, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	// サイズが分かる場合は指定する（不明な場合はマルチパートアップロード用に大きなバッファを確保するため）
+	size := int64(-1)
+	if r, ok := body.(interface{ Len() int }); ok {
+		size = int64(r.Len())
+	}
+
+	if _, err := s.client.PutObject(ctx, s.bucket, key, body, size, minio.PutObjectOptions{
+		ContentType: contentType,
+	}); err != nil {
+		return "", err
+	}
+
+	return s.url(key), nil
+}
+
+// Get はバケットのkeyのオブジェクトを取得します
+func (s *S3Store) Get(ctx context.Context, key string) (*Object, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	// GetObjectは実際の取得を遅延するため、Statで存在を確認する
+	info, err := obj.Stat()
+	if err != nil {
+		obj.Close()
+		if minio.ToErrorResponse(err).Code == minio.NoSuchKey {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &Object{
+		Body:         obj,
+		ContentType:  info.ContentType,
+		Size:         info.Size,
+		LastModified: info.LastModified,
+		ETag:         info.ETag,
+	}, nil
+}
+
+// Delete はバケットのkeyのオブジェクトを削除します
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	key, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+	return s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{})
+}
@@ -0,0 +1,192 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yamada-mikiya/team1-hackathon/config"
+)
+
+// fakeS3Object はfakeS3に保存したオブジェクト
+type fakeS3Object struct {
+	body        []byte
+	contentType string
+}
+
+// fakeS3 はパス形式のPUT・GET・HEAD・DELETEだけに対応したS3互換サーバー
+type fakeS3 struct {
+	bucket  string
+	mu      sync.Mutex
+	objects map[string]fakeS3Object
+}
+
+func (s *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key, ok := strings.CutPrefix(r.URL.Path, "/"+s.bucket+"/")
+	if !ok || r.URL.RawQuery != "" {
+		http.Error(w, "対応していないリクエストです: "+r.Method+" "+r.URL.String(), http.StatusNotImplemented)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		body, err := io.ReadAll(r.Body)
+		// 暗号化しない接続では、minio-goは署名付きのチャンク形式（aws-chunked）で送る
+		if err == nil && strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+			body, err = decodeAWSChunked(body)
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.objects[key] = fakeS3Object{body: body, contentType: r.Header.Get("Content-Type")}
+		w.Header().Set("ETag", etag(body))
+	case http.MethodGet, http.MethodHead:
+		obj, ok := s.objects[key]
+		if !ok {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			if r.Method == http.MethodGet {
+				io.WriteString(w, `<?xml version="1.0" encoding="UTF-8"?><Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`)
+			}
+			return
+		}
+		w.Header().Set("Content-Type", obj.contentType)
+		w.Header().Set("Content-Length", strconv.Itoa(len(obj.body)))
+		w.Header().Set("ETag", etag(obj.body))
+		w.Header().Set("Last-Modified", time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC).Format(http.TimeFormat))
+		if r.Method == http.MethodGet {
+			w.Write(obj.body)
+		}
+	case http.MethodDelete:
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "対応していないメソッドです", http.StatusMethodNotAllowed)
+	}
+}
+
+// decodeAWSChunked は「<16進数のサイズ>;chunk-signature=...\r\n<データ>\r\n」の繰り返しからデータを取り出します（署名は検証しません）
+func decodeAWSChunked(body []byte) ([]byte, error) {
+	var data []byte
+	for {
+		header, rest, ok := bytes.Cut(body, []byte("\r\n"))
+		if !ok {
+			return nil, errors.New("チャンクの形式が不正です")
+		}
+		sizeHex, _, _ := bytes.Cut(header, []byte(";"))
+		size, err := strconv.ParseInt(string(sizeHex), 16, 64)
+		if err != nil || int64(len(rest)) < size+2 {
+			return nil, errors.New("チャンクのサイズが不正です")
+		}
+		if size == 0 {
+			return data, nil
+		}
+		data = append(data, rest[:size]...)
+		body = rest[size+2:]
+	}
+}
+
+func etag(body []byte) string {
+	sum := md5.Sum(body)
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+func newTestS3Store(t *testing.T) (*S3Store, *fakeS3) {
+	t.Helper()
+	fake := &fakeS3{bucket: "media", objects: map[string]fakeS3Object{}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	store, err := NewS3Store(config.S3Config{
+		Endpoint:        strings.TrimPrefix(server.URL, "http://"),
+		Region:          "ap-northeast-1",
+		Bucket:          fake.bucket,
+		AccessKeyID:     "test",
+		SecretAccessKey: "test-secret",
+		UsePathStyle:    true,
+	}, "https://cdn.example.com/media")
+	if err != nil {
+		t.Fatalf("NewS3Store: %v", err)
+	}
+	return store, fake
+}
+
+func TestS3Store(t *testing.T) {
+	store, fake := newTestS3Store(t)
+	ctx := context.Background()
+
+	url, err := store.Put(ctx, "images/2026/01/a.png", bytes.NewReader([]byte("png")), "image/png")
+	if err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if url != "https://cdn.example.com/media/images/2026/01/a.png" {
+		t.Errorf("Put: url = %q", url)
+	}
+	if obj := fake.objects["images/2026/01/a.png"]; string(obj.body) != "png" || obj.contentType != "image/png" {
+		t.Errorf("保存したオブジェクト = %q（%s）, want png（image/png）", obj.body, obj.contentType)
+	}
+	key, ok := store.KeyFromURL(url)
+	if !ok || key != "images/2026/01/a.png" {
+		t.Errorf("KeyFromURL(%q) = %q, %v, want images/2026/01/a.png, true", url, key, ok)
+	}
+
+	obj, err := store.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	body, err := io.ReadAll(obj.Body)
+	obj.Body.Close()
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if string(body) != "png" || obj.Size != 3 || obj.ContentType != "image/png" || obj.ETag == "" || obj.LastModified.IsZero() {
+		t.Errorf("Get = %q, %+v", body, obj)
+	}
+
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, ok := fake.objects[key]; ok {
+		t.Error("Delete: オブジェクトが削除されていません")
+	}
+	if _, err := store.Get(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Errorf("削除後のGet: err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestS3StoreRejectsInvalidKeys(t *testing.T) {
+	store, fake := newTestS3Store(t)
+	ctx := context.Background()
+
+	for _, key := range []string{"../a.png", "a/../../b", `images\a.png`, ""} {
+		if _, err := store.Put(ctx, key, bytes.NewReader([]byte("x")), "text/plain"); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Put(%q): err = %v, want ErrInvalidKey", key, err)
+		}
+		if _, err := store.Get(ctx, key); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Get(%q): err = %v, want ErrInvalidKey", key, err)
+		}
+		if err := store.Delete(ctx, key); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Delete(%q): err = %v, want ErrInvalidKey", key, err)
+		}
+	}
+	if len(fake.objects) != 0 {
+		t.Errorf("不正なキーでオブジェクトが保存されています: %v", fake.objects)
+	}
+	if _, ok := store.KeyFromURL("https://example.com/media/a.png"); ok {
+		t.Error("KeyFromURL: 別のURLからキーを取り出しました")
+	}
+}
@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/yamada-mikiya/team1-hackathon/config"
+)
+
+// PublicPathPrefix は保存したファイルをEchoで配信するパス。保存先に関わらずこのサーバーから配信します
+const PublicPathPrefix = "/media"
+
+var (
+	// ErrInvalidKey は保存先のキーとして使えない文字列が指定された場合のエラー
+	ErrInvalidKey = errors.New("保存先のキーが不正です")
+	// ErrNotFound は指定したキーのファイルが存在しない場合のエラー
+	ErrNotFound = errors.New("ファイルが見つかりません")
+)
+
+// BlobStore はアップロードされたファイルを保存するインターフェース。設定に応じて保存先の実装を切り替えます
+type BlobStore interface {
+	// Put はbodyをkeyに保存し、配信用のURLを返します。同じkeyが既にある場合は上書きします
+	Put(ctx context.Context, key string, body io.Reader, contentType string) (string, error)
+	// Get はkeyのファイルを返します。存在しない場合はErrNotFoundを返します。呼び出し側でBodyを閉じてください
+	Get(ctx context.Context, key string) (*Object, error)
+	// Delete はkeyのファイルを削除します。存在しない場合は何もしません
+	Delete(ctx context.Context, key string) error
+	// KeyFromURL はPutが返したURLからkeyを取り出します。このストアのURLでない場合はfalseを返します
+	KeyFromURL(url string) (string, bool)
+}
+
+// Object は保存されているファイルの内容と属性
+type Object struct {
+	Body         io.ReadSeekCloser
+	ContentType  string
+	Size         int64
+	LastModified time.Time
+	ETag         string
+}
+
+// New は設定のdriver（local, s3）に応じたBlobStoreを返します。未設定の場合はローカルディスクに保存します
+func New(cfg config.StorageConfig) (BlobStore, error) {
+	switch cfg.Driver {
+	case "s3":
+		return NewS3Store(cfg.S3, cfg.PublicBaseURL)
+	case "", "local":
+		return NewLocalStore(cfg.LocalDir, cfg.PublicBaseURL), nil
+	default:
+		slog.Warn("不明な保存先のため、ローカルディスクに保存します", "driver", cfg.Driver)
+		return NewLocalStore(cfg.LocalDir, cfg.PublicBaseURL), nil
+	}
+}
+
+// cleanKey はkeyを正規化し、保存先の外を指すものや空のものを拒否します
+func cleanKey(key string) (string, error) {
+	cleaned := path.Clean("/" + key)[1:]
+	if cleaned == "" || cleaned != key || strings.Contains(key, "\\") {
+		return "", ErrInvalidKey
+	}
+	return cleaned, nil
+}
+
+// publicURLs はkeyと配信用URL（<baseURL>/<key>）を相互に変換します。各実装に埋め込んで使います
+type publicURLs struct {
+	baseURL string
+}
+
+func newPublicURLs(baseURL string) publicURLs {
+	if baseURL == "" {
+		baseURL = PublicPathPrefix
+	}
+	return publicURLs{baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+func (u publicURLs) url(key string) string {
+	return u.baseURL + "/" + key
+}
+
+// KeyFromURL は<baseURL>/<key>形式のURLからkeyを取り出します
+func (u publicURLs) KeyFromURL(url string) (string, bool) {
+	key, ok := strings.CutPrefix(url, u.baseURL+"/")
+	if !ok {
+		return "", false
+	}
+	if _, err := cleanKey(key); err != nil {
+		return "", false
+	}
+	return key, true
+}
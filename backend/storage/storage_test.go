@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCleanKey(t *testing.T) {
+	tests := []struct {
+		key     string
+		wantErr bool
+	}{
+		{"images/2026/01/a.png", false},
+		{"a.png", false},
+		{"", true},
+		{"/", true},
+		{"../a.png", true},
+		{"images/../../a.png", true},
+		{"a/../../b", true},
+		{"a/../b", true},
+		{"./a.png", true},
+		{"a//b.png", true},
+		{"/images/a.png", true},
+		{"images/", true},
+		{`images\a.png`, true},
+		{`..\..\a.png`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got, err := cleanKey(tt.key)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidKey) {
+					t.Errorf("cleanKey(%q) = %q, %v, want ErrInvalidKey", tt.key, got, err)
+				}
+				return
+			}
+			if err != nil || got != tt.key {
+				t.Errorf("cleanKey(%q) = %q, %v, want %q", tt.key, got, err, tt.key)
+			}
+		})
+	}
+}
+
+func TestKeyFromURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		url     string
+		want    string
+		wantOK  bool
+	}{
+		{"既定の配信パス", "", "/media/images/a.png", "images/a.png", true},
+		{"公開URLを設定", "https://cdn.example.com/media/", "https://cdn.example.com/media/images/a.png", "images/a.png", true},
+		{"別のストアのURL", "https://cdn.example.com/media", "https://example.com/media/images/a.png", "", false},
+		{"保存先の外を指すキー", "", "/media/../config.yaml", "", false},
+		{"キーがない", "", "/media/", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := newPublicURLs(tt.baseURL).KeyFromURL(tt.url)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("KeyFromURL(%q) = %q, %v, want %q, %v", tt.url, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/yamada-mikiya/team1-hackathon/mailer"
+)
+
+const (
+	// 送信待ちにできるメールの最大数
+	mailQueueSize = 1000
+	// 1通のメールの送信のタイムアウト
+	mailSendTimeout = 30 * time.Second
+)
+
+// ErrMailQueueFull は送信待ちのメールが多すぎてキューに積めなかったことを表します
+var ErrMailQueueFull = errors.New("送信待ちのメールが多すぎます")
+
+// MailQueue はメールをキューに積み、バックグラウンドで順番に送信するワーカー
+// Sendは送信の完了を待たないため、APIの応答時間や送信エラーからメールアドレスが登録されているかを推測されません
+// 送信に失敗したメールはログに出力して破棄します
+type MailQueue struct {
+	mailer mailer.Mailer
+	queue  chan mailer.Message
+}
+
+func NewMailQueue(m mailer.Mailer) *MailQueue {
+	return &MailQueue{mailer: m, queue: make(chan mailer.Message, mailQueueSize)}
+}
+
+// Send はメールを送信待ちのキューに積みます。キューがいっぱいの場合はErrMailQueueFullを返します
+func (q *MailQueue) Send(ctx context.Context, msg mailer.Message) error {
+	select {
+	case q.queue <- msg:
+		return nil
+	default:
+		return ErrMailQueueFull
+	}
+}
+
+// Run はctxがキャンセルされるまでキューのメールを送信します
+// キャンセルされた時点でキューに残っているメールは送信してから終了します
+func (q *MailQueue) Run(ctx context.Context) {
+	for {
+		select {
+		case msg := <-q.queue:
+			q.send(msg)
+		case <-ctx.Done():
+			for {
+				select {
+				case msg := <-q.queue:
+					q.send(msg)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (q *MailQueue) send(msg mailer.Message) {
+	ctx, cancel := context.WithTimeout(context.Background(), mailSendTimeout)
+	defer cancel()
+	if err := q.mailer.Send(ctx, msg); err != nil {
+		slog.Error("メールの送信に失敗しました", "to", msg.To, "subject", msg.Subject, "error", err)
+	}
+}
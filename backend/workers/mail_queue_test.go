@@ -0,0 +1,71 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yamada-mikiya/team1-hackathon/mailer"
+)
+
+// blockingMailer はreleaseが閉じられるまで送信を待ち、送信したメールを記録します
+type blockingMailer struct {
+	release chan struct{}
+	mu      sync.Mutex
+	sent    []string
+}
+
+func (m *blockingMailer) Send(ctx context.Context, msg mailer.Message) error {
+	<-m.release
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sent = append(m.sent, msg.To)
+	if msg.To == "fail@example.com" {
+		return errors.New("送信に失敗しました")
+	}
+	return nil
+}
+
+func TestMailQueueSendDoesNotWait(t *testing.T) {
+	m := &blockingMailer{release: make(chan struct{})}
+	q := NewMailQueue(m)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		q.Run(ctx)
+		close(done)
+	}()
+
+	// 送信が終わらなくても、Sendはすぐに戻る
+	for _, to := range []string{"a@example.com", "fail@example.com", "b@example.com"} {
+		if err := q.Send(context.Background(), mailer.Message{To: to}); err != nil {
+			t.Fatalf("Send(%s): %v", to, err)
+		}
+	}
+
+	// 停止時は送信待ちのメールを送信してから終了する（送信の失敗で止まらない）
+	cancel()
+	close(m.release)
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Runが終了しません")
+	}
+	if len(m.sent) != 3 || m.sent[2] != "b@example.com" {
+		t.Errorf("送信したメール = %v", m.sent)
+	}
+}
+
+func TestMailQueueFull(t *testing.T) {
+	q := NewMailQueue(&blockingMailer{release: make(chan struct{})})
+	for i := 0; i < mailQueueSize; i++ {
+		if err := q.Send(context.Background(), mailer.Message{To: "a@example.com"}); err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+	}
+	if err := q.Send(context.Background(), mailer.Message{To: "a@example.com"}); !errors.Is(err, ErrMailQueueFull) {
+		t.Errorf("err = %v, want ErrMailQueueFull", err)
+	}
+}
@@ -0,0 +1,37 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/yamada-mikiya/team1-hackathon/repositories"
+	"github.com/yamada-mikiya/team1-hackathon/services"
+	"gorm.io/gorm"
+)
+
+// RenderBackfill は起動時に一度だけ、HTMLなどのキャッシュがまだない記事（キャッシュを導入する前に保存された記事）について
+// 本文から作成して保存するワーカー。記事の取得時にDBへ書き込まないようにするために使います
+type RenderBackfill struct {
+	repo repositories.ArticleRepository
+}
+
+func NewRenderBackfill(db *gorm.DB) *RenderBackfill {
+	return &RenderBackfill{
+		repo: repositories.NewArticleRepository(db),
+	}
+}
+
+// Run はキャッシュがない記事がなくなるか、ctxがキャンセルされるまでHTMLを作成して保存します
+// 途中で停止した場合は、次回の起動時に残りを処理します
+func (b *RenderBackfill) Run(ctx context.Context) {
+	rendered, err := services.BackfillRenderedContent(ctx, b.repo)
+	switch {
+	case errors.Is(err, context.Canceled):
+		slog.Info("記事本文のHTMLの作成を中断しました", "count", rendered)
+	case err != nil:
+		slog.Error("記事本文のHTMLの作成に失敗しました", "error", err, "count", rendered)
+	case rendered > 0:
+		slog.Info("キャッシュがない記事の本文のHTMLを作成しました", "count", rendered)
+	}
+}
@@ -0,0 +1,71 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/yamada-mikiya/team1-hackathon/repositories"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultPublishInterval = time.Minute
+	// 1回の実行で切り替える記事の最大数（溜まっている場合は次の実行で続きを処理）
+	publishBatchSize = 100
+)
+
+// ScheduledPublisher は予約公開の日時を過ぎた記事のステータスを定期的に切り替えるワーカー
+type ScheduledPublisher struct {
+	repo     repositories.ArticleRepository
+	interval time.Duration
+}
+
+func NewScheduledPublisher(db *gorm.DB, interval time.Duration) *ScheduledPublisher {
+	if interval <= 0 {
+		interval = defaultPublishInterval
+	}
+	return &ScheduledPublisher{
+		repo:     repositories.NewArticleRepository(db),
+		interval: interval,
+	}
+}
+
+// Run はctxがキャンセルされるまで一定間隔で予約公開を実行します
+func (p *ScheduledPublisher) Run(ctx context.Context) {
+	slog.Info("予約公開ワーカーを起動しました", "interval", p.interval)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	// 起動直後にも一度実行して、停止中に期限を迎えた記事を反映する
+	p.publishDue()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("予約公開ワーカーを停止しました")
+			return
+		case <-ticker.C:
+			p.publishDue()
+		}
+	}
+}
+
+func (p *ScheduledPublisher) publishDue() {
+	for {
+		articles, err := p.repo.PublishDueArticles(time.Now(), publishBatchSize)
+		if err != nil {
+			slog.Error("予約公開の実行に失敗しました", "error", err)
+			return
+		}
+
+		for _, article := range articles {
+			slog.Info("予約公開により記事のステータスを変更しました", "slug", article.Slug, "status", article.Status)
+		}
+
+		if len(articles) < publishBatchSize {
+			return
+		}
+	}
+}
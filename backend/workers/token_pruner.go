@@ -0,0 +1,77 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/yamada-mikiya/team1-hackathon/repositories"
+	"gorm.io/gorm"
+)
+
+const defaultPruneInterval = time.Hour
+
+// TokenPruner は有効期限を過ぎた失効済みトークン・リフレッシュトークン・パスワードリセットトークンを定期的に削除するワーカー
+type TokenPruner struct {
+	revokedTokenRepo  repositories.RevokedTokenRepository
+	refreshTokenRepo  repositories.RefreshTokenRepository
+	passwordResetRepo repositories.PasswordResetTokenRepository
+	interval          time.Duration
+}
+
+func NewTokenPruner(db *gorm.DB, interval time.Duration) *TokenPruner {
+	if interval <= 0 {
+		interval = defaultPruneInterval
+	}
+	return &TokenPruner{
+		revokedTokenRepo:  repositories.NewRevokedTokenRepository(db),
+		refreshTokenRepo:  repositories.NewRefreshTokenRepository(db),
+		passwordResetRepo: repositories.NewPasswordResetTokenRepository(db),
+		interval:          interval,
+	}
+}
+
+// Run はctxがキャンセルされるまで一定間隔で期限切れのトークンを削除します
+func (p *TokenPruner) Run(ctx context.Context) {
+	slog.Info("トークン削除ワーカーを起動しました", "interval", p.interval)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	p.prune(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("トークン削除ワーカーを停止しました")
+			return
+		case <-ticker.C:
+			p.prune(ctx)
+		}
+	}
+}
+
+func (p *TokenPruner) prune(ctx context.Context) {
+	now := time.Now()
+
+	deleted, err := p.revokedTokenRepo.DeleteExpired(ctx, now)
+	if err != nil {
+		slog.Error("期限切れの失効トークンの削除に失敗しました", "error", err)
+	} else if deleted > 0 {
+		slog.Info("期限切れの失効トークンを削除しました", "count", deleted)
+	}
+
+	deleted, err = p.refreshTokenRepo.DeleteExpired(ctx, now)
+	if err != nil {
+		slog.Error("期限切れのリフレッシュトークンの削除に失敗しました", "error", err)
+	} else if deleted > 0 {
+		slog.Info("期限切れのリフレッシュトークンを削除しました", "count", deleted)
+	}
+
+	deleted, err = p.passwordResetRepo.DeleteExpired(ctx, now)
+	if err != nil {
+		slog.Error("期限切れのパスワードリセットトークンの削除に失敗しました", "error", err)
+	} else if deleted > 0 {
+		slog.Info("期限切れのパスワードリセットトークンを削除しました", "count", deleted)
+	}
+}
@@ -0,0 +1,163 @@
+package workers
+
+import (
+	"container/list"
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/yamada-mikiya/team1-hackathon/repositories"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultViewDedupWindow   = 30 * time.Minute
+	defaultViewFlushInterval = 10 * time.Second
+	// 重複の判定のために覚えておく閲覧者の最大数。超えた場合は最も前に数えた閲覧者から忘れる
+	maxTrackedViewers = 100000
+	// 集計期間を過ぎた1時間ごとの閲覧数を削除する間隔
+	hourlyViewPruneInterval = time.Hour
+	// 停止時に残りの閲覧数を書き込む際のタイムアウト
+	finalFlushTimeout = 5 * time.Second
+)
+
+type viewKey struct {
+	articleID int
+	viewer    string
+}
+
+// seenViewer は閲覧者と最後に数えた日時
+type seenViewer struct {
+	key viewKey
+	at  time.Time
+}
+
+// ViewCounter は記事の閲覧をメモリ上で数え、一定間隔でまとめてDBに書き込むワーカー
+// 記事の取得のたびにDBへ書き込まないようにし、同じ閲覧者の閲覧はdedupWindowの間は1回と数えます
+// 閲覧数はサーバーのメモリ上に溜めるため、異常終了した場合は最後の書き込み以降の閲覧が失われます
+type ViewCounter struct {
+	repo          repositories.ArticleViewRepository
+	dedupWindow   time.Duration
+	flushInterval time.Duration
+	lastPrunedAt  time.Time // Runのゴルーチンからのみ使う
+
+	mu        sync.Mutex
+	seen      map[viewKey]*list.Element // 閲覧者ごとの、seenOrderの要素
+	seenOrder *list.List                // 最後に数えた日時が古い順に並べたseenViewer
+	pending   map[int]int64             // まだ書き込んでいない記事ごとの閲覧数
+}
+
+func NewViewCounter(db *gorm.DB, dedupWindow, flushInterval time.Duration) *ViewCounter {
+	if dedupWindow <= 0 {
+		dedupWindow = defaultViewDedupWindow
+	}
+	if flushInterval <= 0 {
+		flushInterval = defaultViewFlushInterval
+	}
+	return &ViewCounter{
+		repo:          repositories.NewArticleViewRepository(db),
+		dedupWindow:   dedupWindow,
+		flushInterval: flushInterval,
+		seen:          map[viewKey]*list.Element{},
+		seenOrder:     list.New(),
+		pending:       map[int]int64{},
+	}
+}
+
+// Record は記事の閲覧を1回数えます。viewerが同じ閲覧をdedupWindow以内に数えている場合は何もしません
+// 覚えている閲覧者がmaxTrackedViewersに達した場合は、最も前に数えた閲覧者を忘れてから新しい閲覧者を覚えます
+func (v *ViewCounter) Record(articleID int, viewer string) {
+	now := time.Now()
+	key := viewKey{articleID: articleID, viewer: viewer}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if elem, ok := v.seen[key]; ok {
+		entry := elem.Value.(*seenViewer)
+		if now.Sub(entry.at) < v.dedupWindow {
+			return
+		}
+		entry.at = now
+		v.seenOrder.MoveToBack(elem)
+	} else {
+		if v.seenOrder.Len() >= maxTrackedViewers {
+			oldest := v.seenOrder.Front()
+			delete(v.seen, oldest.Value.(*seenViewer).key)
+			v.seenOrder.Remove(oldest)
+		}
+		v.seen[key] = v.seenOrder.PushBack(&seenViewer{key: key, at: now})
+	}
+	v.pending[articleID]++
+}
+
+// Run はctxがキャンセルされるまで一定間隔で閲覧数を書き込みます。停止時には残りの閲覧数を書き込んでから終了します
+func (v *ViewCounter) Run(ctx context.Context) {
+	slog.Info("閲覧数ワーカーを起動しました", "flush_interval", v.flushInterval, "dedup_window", v.dedupWindow)
+
+	ticker := time.NewTicker(v.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), finalFlushTimeout)
+			v.flush(flushCtx)
+			cancel()
+			slog.Info("閲覧数ワーカーを停止しました")
+			return
+		case <-ticker.C:
+			v.flush(ctx)
+			v.pruneHourlyViews(ctx)
+		}
+	}
+}
+
+// flush は溜まっている閲覧数を書き込み、重複の判定期間を過ぎた閲覧者を忘れます
+// 書き込みに失敗した場合は閲覧数を戻し、次回に再度書き込みます
+func (v *ViewCounter) flush(ctx context.Context) {
+	now := time.Now()
+
+	v.mu.Lock()
+	counts := v.pending
+	v.pending = map[int]int64{}
+	// seenOrderは数えた日時の古い順なので、先頭から期間を過ぎた閲覧者を忘れる
+	for elem := v.seenOrder.Front(); elem != nil; elem = v.seenOrder.Front() {
+		entry := elem.Value.(*seenViewer)
+		if now.Sub(entry.at) < v.dedupWindow {
+			break
+		}
+		delete(v.seen, entry.key)
+		v.seenOrder.Remove(elem)
+	}
+	v.mu.Unlock()
+
+	if len(counts) == 0 {
+		return
+	}
+	if err := v.repo.AddViews(ctx, counts, now); err != nil {
+		slog.Error("閲覧数の書き込みに失敗しました", "error", err, "articles", len(counts))
+		v.mu.Lock()
+		for articleID, n := range counts {
+			v.pending[articleID] += n
+		}
+		v.mu.Unlock()
+	}
+}
+
+// pruneHourlyViews はトレンドの集計期間を過ぎた1時間ごとの閲覧数を削除します（hourlyViewPruneIntervalに1回）
+func (v *ViewCounter) pruneHourlyViews(ctx context.Context) {
+	now := time.Now()
+	if now.Sub(v.lastPrunedAt) < hourlyViewPruneInterval {
+		return
+	}
+	v.lastPrunedAt = now
+
+	deleted, err := v.repo.DeleteHourlyViewsBefore(ctx, now.Add(-repositories.HourlyViewRetention))
+	if err != nil {
+		slog.Error("古い閲覧数の削除に失敗しました", "error", err)
+	} else if deleted > 0 {
+		slog.Info("古い閲覧数を削除しました", "count", deleted)
+	}
+}
@@ -0,0 +1,62 @@
+package workers
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestViewCounterDedupWhenFull(t *testing.T) {
+	v := NewViewCounter(nil, time.Hour, time.Minute)
+	for i := range maxTrackedViewers {
+		v.Record(1, fmt.Sprintf("viewer-%d", i))
+	}
+	if got := v.pending[1]; got != maxTrackedViewers {
+		t.Fatalf("閲覧数 = %d, want %d", got, maxTrackedViewers)
+	}
+
+	// 覚えている閲覧者が上限に達した後も、新しい閲覧者の2回目以降の閲覧は数えない
+	for range 3 {
+		v.Record(2, "new-viewer")
+	}
+	if got := v.pending[2]; got != 1 {
+		t.Errorf("上限に達した後の新しい閲覧者の閲覧数 = %d, want 1", got)
+	}
+	if got := len(v.seen); got != maxTrackedViewers {
+		t.Errorf("覚えている閲覧者 = %d人, want %d人", got, maxTrackedViewers)
+	}
+
+	// 最も前に数えた閲覧者から忘れる
+	if _, ok := v.seen[viewKey{articleID: 1, viewer: "viewer-0"}]; ok {
+		t.Error("最も前に数えた閲覧者を忘れていません")
+	}
+	if _, ok := v.seen[viewKey{articleID: 1, viewer: "viewer-1"}]; !ok {
+		t.Error("2番目に数えた閲覧者を忘れています")
+	}
+}
+
+func TestViewCounterForgetsExpiredViewers(t *testing.T) {
+	v := NewViewCounter(nil, time.Hour, time.Minute)
+	v.Record(1, "old-viewer")
+	v.Record(1, "recent-viewer")
+	// old-viewerだけ重複の判定期間を過ぎたことにする
+	v.seen[viewKey{articleID: 1, viewer: "old-viewer"}].Value.(*seenViewer).at = time.Now().Add(-2 * time.Hour)
+
+	v.mu.Lock()
+	v.pending = map[int]int64{}
+	v.mu.Unlock()
+	v.flush(t.Context())
+
+	if _, ok := v.seen[viewKey{articleID: 1, viewer: "old-viewer"}]; ok {
+		t.Error("重複の判定期間を過ぎた閲覧者を忘れていません")
+	}
+	if _, ok := v.seen[viewKey{articleID: 1, viewer: "recent-viewer"}]; !ok {
+		t.Error("重複の判定期間内の閲覧者を忘れています")
+	}
+
+	v.Record(1, "old-viewer")
+	v.Record(1, "recent-viewer")
+	if got := v.pending[1]; got != 1 {
+		t.Errorf("閲覧数 = %d, want 1（期間を過ぎた閲覧者だけを数え直す）", got)
+	}
+}